@@ -184,6 +184,16 @@ func (s *KubeletServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CloudConfigFile, "cloud-config", s.CloudConfigFile, "The path to the cloud provider configuration file.  Empty string for no configuration file.")
 	fs.StringVar(&s.FeatureGates, "feature-gates", s.FeatureGates, "A set of key=value pairs that describe feature gates for alpha/experimental features. "+
 		"Options are:\n"+strings.Join(utilfeature.DefaultFeatureGate.KnownFeatures(), "\n"))
+	fs.StringVar(&s.DockershimFeatureGates, "experimental-dockershim-feature-gates", s.DockershimFeatureGates, "A set of key=value pairs that describe feature gates local to the dockershim, independent of --feature-gates.")
+	fs.StringVar(&s.DockershimOOMPolicy, "experimental-dockershim-oom-policy", s.DockershimOOMPolicy, "OOM score adjustment policy applied by the dockershim: 'default', 'protect-system' or 'invert'.")
+	fs.StringVar(&s.DockershimSecurityProfile, "experimental-dockershim-security-profile", s.DockershimSecurityProfile, "Hardening defaults the dockershim applies to sandboxes and containers: 'default' or 'hardened'.")
+	fs.StringVar(&s.DockershimHostConfigExtensions, "experimental-dockershim-hostconfig-extensions", s.DockershimHostConfigExtensions, "Comma-separated allowlist of annotation-driven docker HostConfig extensions the dockershim honors: 'ulimits' and/or 'ipc-mode' ('shm-size' is accepted for compatibility but no longer gated).")
+	fs.StringVar(&s.DockershimUlimitCaps, "experimental-dockershim-ulimit-caps", s.DockershimUlimitCaps, "Comma-separated '<name>=<max>' pairs bounding the ulimits pods may request through the dockershim's ulimits extension, e.g. 'nofile=1048576,nproc=65536'. Empty means no bound.")
+	fs.StringVar(&s.DockershimImagePolicyEndpoint, "experimental-dockershim-image-policy-endpoint", s.DockershimImagePolicyEndpoint, "URL of an external image policy endpoint the dockershim consults before creating containers. Empty allows every image.")
+	fs.StringVar(&s.DockershimCredentialHelpers, "experimental-dockershim-credential-helpers", s.DockershimCredentialHelpers, "Comma-separated '<registry>=<helper>' pairs mapping registries to external credential helper binaries the dockershim invokes at image pull time, e.g. '*.gcr.io=docker-credential-gcr'.")
+	fs.StringVar(&s.DockershimNetworkPolicyHook, "experimental-dockershim-network-policy-hook", s.DockershimNetworkPolicyHook, "NetworkPolicy agent binary the dockershim invokes synchronously during sandbox network setup, as '<path>[,fail-open|fail-closed]'. Empty disables the hook.")
+	fs.StringVar(&s.DockershimNamespacePolicyFile, "experimental-dockershim-namespace-policy-file", s.DockershimNamespacePolicyFile, "JSON file mapping namespaces to runtime defaults (sandbox image, seccomp profile, sysctl allowlist, OCI runtime) the dockershim applies to their pods. Empty keeps the node-level defaults for every namespace.")
+	fs.StringVar(&s.DockershimMinimumDockerAPIVersion, "experimental-dockershim-minimum-docker-api-version", s.DockershimMinimumDockerAPIVersion, "The oldest docker API version the dockershim accepts. The kubelet fails to start against a daemon reporting an older version.")
 
 	fs.StringVar(&s.KubeletCgroups, "kubelet-cgroups", s.KubeletCgroups, "Optional absolute name of cgroups to create and run the Kubelet in.")
 	fs.StringVar(&s.SystemCgroups, "system-cgroups", s.SystemCgroups, "Optional absolute name of cgroups in which to place all non-kernel processes that are not already inside a cgroup under `/`. Empty for no container. Rolling back the flag requires a reboot. (Default: \"\").")
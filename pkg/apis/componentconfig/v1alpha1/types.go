@@ -509,6 +509,55 @@ type KubeletConfiguration struct {
 	// featureGates is a string of comma-separated key=value pairs that describe feature
 	// gates for alpha/experimental features.
 	FeatureGates string `json:"featureGates,omitempty"`
+	// dockershimFeatureGates is a string of comma-separated key=value pairs that
+	// describe feature gates local to the dockershim, independent of the global
+	// feature gates.
+	// +optional
+	DockershimFeatureGates string `json:"dockershimFeatureGates,omitempty"`
+	// dockershimOOMPolicy selects the OOM score adjustment policy the
+	// dockershim applies to sandboxes and containers: 'default',
+	// 'protect-system' or 'invert'.
+	// +optional
+	DockershimOOMPolicy string `json:"dockershimOOMPolicy,omitempty"`
+	// dockershimSecurityProfile selects the hardening defaults the dockershim
+	// applies to sandboxes and containers: 'default' or 'hardened'.
+	// +optional
+	DockershimSecurityProfile string `json:"dockershimSecurityProfile,omitempty"`
+	// dockershimHostConfigExtensions is a comma-separated allowlist of
+	// annotation-driven docker HostConfig extensions the dockershim honors,
+	// e.g. 'ulimits,ipc-mode'.
+	// +optional
+	DockershimHostConfigExtensions string `json:"dockershimHostConfigExtensions,omitempty"`
+	// dockershimUlimitCaps bounds the ulimits pods may request through the
+	// dockershim's ulimits extension, comma-separated '<name>=<max>' pairs,
+	// e.g. 'nofile=1048576,nproc=65536'.
+	// +optional
+	DockershimUlimitCaps string `json:"dockershimUlimitCaps,omitempty"`
+	// dockershimImagePolicyEndpoint is the URL of an external image policy
+	// endpoint the dockershim consults before creating containers. Empty
+	// allows every image.
+	// +optional
+	DockershimImagePolicyEndpoint string `json:"dockershimImagePolicyEndpoint,omitempty"`
+	// dockershimCredentialHelpers maps registries to external credential
+	// helper binaries invoked at image pull time, comma-separated
+	// '<registry>=<helper>' pairs, e.g. '*.gcr.io=docker-credential-gcr'.
+	// +optional
+	DockershimCredentialHelpers string `json:"dockershimCredentialHelpers,omitempty"`
+	// dockershimNetworkPolicyHook is a NetworkPolicy agent binary the
+	// dockershim invokes synchronously during sandbox network setup, as
+	// '<path>[,fail-open|fail-closed]'. Empty disables the hook.
+	// +optional
+	DockershimNetworkPolicyHook string `json:"dockershimNetworkPolicyHook,omitempty"`
+	// dockershimNamespacePolicyFile is a JSON file mapping namespaces to
+	// runtime defaults (sandbox image, seccomp profile, sysctl allowlist,
+	// OCI runtime) the dockershim applies to their pods. Empty keeps the
+	// node-level defaults for every namespace.
+	// +optional
+	DockershimNamespacePolicyFile string `json:"dockershimNamespacePolicyFile,omitempty"`
+	// dockershimMinimumDockerAPIVersion is the oldest docker API version the
+	// dockershim accepts; the kubelet fails to start against an older daemon.
+	// +optional
+	DockershimMinimumDockerAPIVersion string `json:"dockershimMinimumDockerAPIVersion,omitempty"`
 	// Enable Container Runtime Interface (CRI) integration.
 	// +optional
 	EnableCRI *bool `json:"enableCRI,omitempty"`
@@ -410,6 +410,16 @@ func autoConvert_v1alpha1_KubeletConfiguration_To_componentconfig_KubeletConfigu
 	}
 	out.AllowedUnsafeSysctls = *(*[]string)(unsafe.Pointer(&in.AllowedUnsafeSysctls))
 	out.FeatureGates = in.FeatureGates
+	out.DockershimFeatureGates = in.DockershimFeatureGates
+	out.DockershimOOMPolicy = in.DockershimOOMPolicy
+	out.DockershimSecurityProfile = in.DockershimSecurityProfile
+	out.DockershimHostConfigExtensions = in.DockershimHostConfigExtensions
+	out.DockershimUlimitCaps = in.DockershimUlimitCaps
+	out.DockershimImagePolicyEndpoint = in.DockershimImagePolicyEndpoint
+	out.DockershimCredentialHelpers = in.DockershimCredentialHelpers
+	out.DockershimNetworkPolicyHook = in.DockershimNetworkPolicyHook
+	out.DockershimNamespacePolicyFile = in.DockershimNamespacePolicyFile
+	out.DockershimMinimumDockerAPIVersion = in.DockershimMinimumDockerAPIVersion
 	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableCRI, &out.EnableCRI, s); err != nil {
 		return err
 	}
@@ -584,6 +594,16 @@ func autoConvert_componentconfig_KubeletConfiguration_To_v1alpha1_KubeletConfigu
 	}
 	out.AllowedUnsafeSysctls = *(*[]string)(unsafe.Pointer(&in.AllowedUnsafeSysctls))
 	out.FeatureGates = in.FeatureGates
+	out.DockershimFeatureGates = in.DockershimFeatureGates
+	out.DockershimOOMPolicy = in.DockershimOOMPolicy
+	out.DockershimSecurityProfile = in.DockershimSecurityProfile
+	out.DockershimHostConfigExtensions = in.DockershimHostConfigExtensions
+	out.DockershimUlimitCaps = in.DockershimUlimitCaps
+	out.DockershimImagePolicyEndpoint = in.DockershimImagePolicyEndpoint
+	out.DockershimCredentialHelpers = in.DockershimCredentialHelpers
+	out.DockershimNetworkPolicyHook = in.DockershimNetworkPolicyHook
+	out.DockershimNamespacePolicyFile = in.DockershimNamespacePolicyFile
+	out.DockershimMinimumDockerAPIVersion = in.DockershimMinimumDockerAPIVersion
 	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableCRI, &out.EnableCRI, s); err != nil {
 		return err
 	}
@@ -66,6 +66,30 @@ const (
 	// and also prevents them from being evicted from a node.
 	// Note: This feature is not supported for `BestEffort` pods.
 	ExperimentalCriticalPodAnnotation utilfeature.Feature = "ExperimentalCriticalPodAnnotation"
+
+	// owner: @kubernetes/sig-node
+	// alpha: v1.6
+	//
+	// Allows operators to exec into the sandbox (infra) container of a pod via
+	// the docker CRI shim for network debugging. Disabled by default.
+	ExperimentalSandboxExec utilfeature.Feature = "ExperimentalSandboxExec"
+
+	// owner: @kubernetes/sig-node
+	// alpha: v1.6
+	//
+	// Allows operators to attach a time-boxed packet capture to the network
+	// namespace of a pod's sandbox via the docker CRI shim, streaming the pcap
+	// output for live network debugging. Disabled by default.
+	ExperimentalSandboxPacketCapture utilfeature.Feature = "ExperimentalSandboxPacketCapture"
+
+	// owner: @kubernetes/sig-node
+	// alpha: v1.6
+	//
+	// Delegates postStart/preStop exec hook execution to the docker CRI shim,
+	// which ties the hook to the container start/stop transition itself and
+	// reports hook failures through container status. HTTP hooks still run in
+	// the kubelet. Disabled by default.
+	ExperimentalRuntimeLifecycleHooks utilfeature.Feature = "ExperimentalRuntimeLifecycleHooks"
 )
 
 func init() {
@@ -82,6 +106,9 @@ var defaultKubernetesFeatureGates = map[utilfeature.Feature]utilfeature.FeatureS
 	DynamicVolumeProvisioning:                   {Default: true, PreRelease: utilfeature.Alpha},
 	ExperimentalHostUserNamespaceDefaultingGate: {Default: false, PreRelease: utilfeature.Beta},
 	ExperimentalCriticalPodAnnotation:           {Default: false, PreRelease: utilfeature.Alpha},
+	ExperimentalSandboxExec:                     {Default: false, PreRelease: utilfeature.Alpha},
+	ExperimentalSandboxPacketCapture:            {Default: false, PreRelease: utilfeature.Alpha},
+	ExperimentalRuntimeLifecycleHooks:           {Default: false, PreRelease: utilfeature.Alpha},
 
 	// inherited features from generic apiserver, relisted here to get a conflict if it is changed
 	// unintentionally on either side:
@@ -63,6 +63,24 @@ type RuntimeStats struct {
 	// Usage here refers to the total number of bytes occupied by images on the filesystem.
 	// +optional
 	ImageFs *FsStats `json:"imageFs,omitempty"`
+	// Aggregate resource usage of the pod infrastructure (sandbox) containers
+	// on the node, quantifying the per-pod overhead of the runtime.
+	// +optional
+	SandboxOverhead *SandboxOverheadStats `json:"sandboxOverhead,omitempty"`
+}
+
+// SandboxOverheadStats holds the aggregate resource usage of all pod
+// infrastructure (sandbox) containers on the node.
+type SandboxOverheadStats struct {
+	// Number of sandbox containers included in the aggregate.
+	SandboxCount uint64 `json:"sandboxCount"`
+	// Cumulative CPU usage (sum of all cores) of the sandbox containers
+	// since their creation, in core-nanoseconds.
+	// +optional
+	UsageCoreNanoSeconds *uint64 `json:"usageCoreNanoSeconds,omitempty"`
+	// Total working set memory of the sandbox containers in bytes.
+	// +optional
+	WorkingSetBytes *uint64 `json:"workingSetBytes,omitempty"`
 }
 
 const (
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+)
+
+// This file detects whether the node can actually run GPU workloads and
+// reports the answer through the runtime status, so the kubelet's device
+// plugin manager and scheduler extenders can trust the node's capability
+// before binding GPU pods to it. Availability requires both halves: the
+// accelerator OCI runtime registered in the docker daemon, and the device
+// nodes the driver creates.
+
+const (
+	// acceleratorReadyCondition is the runtime condition reporting whether
+	// GPU workloads can run on this node.
+	acceleratorReadyCondition = "AcceleratorRuntimeReady"
+	// nvidiaRuntimeName is the OCI runtime name nvidia-docker registers in
+	// the docker daemon.
+	nvidiaRuntimeName = "nvidia"
+)
+
+// nvidiaDeviceNodes are the device nodes the nvidia driver must have created
+// before containers can reach the GPUs. /dev/nvidia0 stands in for "at least
+// one GPU"; the driver numbers further devices from there.
+var nvidiaDeviceNodes = []string{"/dev/nvidiactl", "/dev/nvidia0"}
+
+// acceleratorStatus reports whether GPU workloads can run on this node, with
+// a reason when they cannot. It is evaluated on every Status call, so a
+// driver or runtime installed after kubelet start flips the condition
+// without a restart.
+func (ds *dockerService) acceleratorStatus() (bool, string) {
+	info, err := ds.client.Info()
+	if err != nil {
+		return false, fmt.Sprintf("failed to get docker info: %v", err)
+	}
+	if _, ok := info.Runtimes[nvidiaRuntimeName]; !ok {
+		return false, fmt.Sprintf("OCI runtime %q is not registered in the docker daemon", nvidiaRuntimeName)
+	}
+	for _, node := range nvidiaDeviceNodes {
+		if _, err := ds.os.Stat(node); err != nil {
+			return false, fmt.Sprintf("device node %s is not present: %v", node, err)
+		}
+	}
+	return true, ""
+}
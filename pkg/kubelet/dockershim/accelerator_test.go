@@ -0,0 +1,65 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"os"
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
+)
+
+// TestAcceleratorStatus tests the GPU availability detection.
+func TestAcceleratorStatus(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+
+	// No accelerator runtime registered in the daemon.
+	ok, reason := ds.acceleratorStatus()
+	assert.False(t, ok)
+	assert.Contains(t, reason, "not registered")
+
+	// Runtime registered, but the driver has not created the device nodes.
+	fDocker.Information.Runtimes = map[string]dockertypes.Runtime{
+		"runc":            {},
+		nvidiaRuntimeName: {},
+	}
+	ok, reason = ds.acceleratorStatus()
+	assert.False(t, ok)
+	assert.Contains(t, reason, "device node")
+
+	// Both halves present.
+	ds.os = &containertest.FakeOS{StatFn: func(string) (os.FileInfo, error) { return nil, nil }}
+	ok, reason = ds.acceleratorStatus()
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	// The condition is surfaced through the runtime status.
+	status, err := ds.Status()
+	require.NoError(t, err)
+	found := false
+	for _, c := range status.GetConditions() {
+		if c.Type == acceleratorReadyCondition {
+			found = true
+			assert.True(t, c.Status)
+		}
+	}
+	assert.True(t, found)
+}
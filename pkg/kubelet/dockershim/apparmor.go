@@ -0,0 +1,79 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/security/apparmor"
+)
+
+// appArmorProfilesPath is where the kernel exposes the loaded AppArmor
+// profiles. It is a variable to allow overriding in tests.
+var appArmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+
+// getAppArmorSecurityOpts resolves the AppArmor annotation for the given
+// container to formatted docker security options, verifying that a
+// localhost/ profile is actually loaded on the host. The sandbox container
+// uses the annotation keyed by the infra container name.
+func getAppArmorSecurityOpts(annotations map[string]string, ctrName string, separator rune) ([]string, error) {
+	profile := apparmor.GetProfileNameFromPodAnnotations(annotations, ctrName)
+	if profile == "" || profile == apparmor.ProfileRuntimeDefault {
+		// The docker daemon applies the default profile by default.
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(profile, apparmor.ProfileNamePrefix) {
+		return nil, fmt.Errorf("unknown AppArmor profile option: %s", profile)
+	}
+
+	profileName := strings.TrimPrefix(profile, apparmor.ProfileNamePrefix)
+	if err := verifyAppArmorProfileLoaded(profileName); err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("apparmor%c%s", separator, profileName)}, nil
+}
+
+// verifyAppArmorProfileLoaded checks the kernel's list of loaded profiles
+// for the given profile name.
+func verifyAppArmorProfileLoaded(profileName string) error {
+	profilesFile, err := os.Open(appArmorProfilesPath)
+	if err != nil {
+		return fmt.Errorf("cannot verify AppArmor profile %q, is AppArmor enabled on the host? %v", profileName, err)
+	}
+	defer profilesFile.Close()
+
+	scanner := bufio.NewScanner(profilesFile)
+	for scanner.Scan() {
+		// Lines are formatted as "profile-name (mode)".
+		modeIndex := strings.IndexRune(scanner.Text(), '(')
+		if modeIndex < 0 {
+			// Unknown line format; skip it.
+			continue
+		}
+		if strings.TrimSpace(scanner.Text()[:modeIndex]) == profileName {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("cannot read loaded AppArmor profiles: %v", err)
+	}
+	return fmt.Errorf("AppArmor profile %q is not loaded on the host", profileName)
+}
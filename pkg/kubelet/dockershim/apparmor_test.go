@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/kubernetes/pkg/security/apparmor"
+)
+
+// setLoadedAppArmorProfiles points the loaded profile check at a fake
+// profiles file listing the given profiles. The returned function restores
+// the real path.
+func setLoadedAppArmorProfiles(t *testing.T, profiles ...string) func() {
+	f, err := ioutil.TempFile("", "apparmor-profiles")
+	require.NoError(t, err)
+	for _, p := range profiles {
+		_, err := f.WriteString(p + " (enforce)\n")
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	origPath := appArmorProfilesPath
+	appArmorProfilesPath = f.Name()
+	return func() {
+		appArmorProfilesPath = origPath
+		os.Remove(f.Name())
+	}
+}
+
+func TestGetAppArmorSecurityOpts(t *testing.T) {
+	defer setLoadedAppArmorProfiles(t, "foo")()
+	containerName := "bar"
+	makeAnnotations := func(profile string) map[string]string {
+		return map[string]string{apparmor.ContainerAnnotationKeyPrefix + containerName: profile}
+	}
+
+	tests := []struct {
+		msg          string
+		annotations  map[string]string
+		expectedOpts []string
+		expectError  bool
+	}{{
+		msg:          "No AppArmor annotation",
+		annotations:  nil,
+		expectedOpts: nil,
+	}, {
+		msg:          "AppArmor runtime/default",
+		annotations:  makeAnnotations(apparmor.ProfileRuntimeDefault),
+		expectedOpts: nil,
+	}, {
+		msg:          "AppArmor loaded local profile",
+		annotations:  makeAnnotations(apparmor.ProfileNamePrefix + "foo"),
+		expectedOpts: []string{"apparmor=foo"},
+	}, {
+		msg:         "AppArmor local profile not loaded",
+		annotations: makeAnnotations(apparmor.ProfileNamePrefix + "not-loaded"),
+		expectError: true,
+	}, {
+		msg:         "Unknown profile option",
+		annotations: makeAnnotations("banana"),
+		expectError: true,
+	}}
+
+	for i, test := range tests {
+		opts, err := getAppArmorSecurityOpts(test.annotations, containerName, '=')
+		if test.expectError {
+			assert.Error(t, err, "TestCase[%d]: %s", i, test.msg)
+			continue
+		}
+		assert.NoError(t, err, "TestCase[%d]: %s", i, test.msg)
+		assert.Equal(t, test.expectedOpts, opts, "TestCase[%d]: %s", i, test.msg)
+	}
+}
+
+// TestVerifyAppArmorProfileLoaded tests that verification fails when the
+// profiles file is unreadable, i.e. when AppArmor is not enabled on the host.
+func TestVerifyAppArmorProfileLoadedNoAppArmor(t *testing.T) {
+	origPath := appArmorProfilesPath
+	appArmorProfilesPath = "/nonexistent/apparmor/profiles"
+	defer func() { appArmorProfilesPath = origPath }()
+
+	err := verifyAppArmorProfileLoaded("foo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is AppArmor enabled on the host?")
+}
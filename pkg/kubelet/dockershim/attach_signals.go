@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io"
+	"time"
+)
+
+// An attach session with a TTY gets signal handling for free: the terminal
+// line discipline inside the container turns ^C into SIGINT for the
+// foreground process group. Without a TTY the control bytes arrive as plain
+// input and the attached process never sees a signal, so an interactive
+// kubectl attach cannot interrupt it the way docker attach (whose sig-proxy
+// is on by default) can. For non-TTY attach streams the shim therefore
+// interprets the usual control bytes on stdin and forwards the matching
+// signal to the container.
+
+// interruptEscalationWindow is how soon a second interrupt must follow the
+// first to be escalated to SIGTERM instead of repeating SIGINT.
+const interruptEscalationWindow = 2 * time.Second
+
+// signalForwardingReader wraps an attach stdin stream, stripping terminal
+// control bytes from it and forwarding them as signals to the container.
+type signalForwardingReader struct {
+	r io.Reader
+	// kill delivers a signal, named as in docker (e.g. "SIGINT"), to the
+	// attached container.
+	kill func(signal string)
+	// lastInterrupt is when the last SIGINT was forwarded, for escalation.
+	lastInterrupt time.Time
+}
+
+func newSignalForwardingReader(r io.Reader, kill func(signal string)) *signalForwardingReader {
+	return &signalForwardingReader{r: r, kill: kill}
+}
+
+func (s *signalForwardingReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	kept := 0
+	for _, b := range p[:n] {
+		if signal := s.signalFor(b); signal != "" {
+			s.kill(signal)
+			continue
+		}
+		p[kept] = b
+		kept++
+	}
+	return kept, err
+}
+
+// signalFor maps a control byte to the signal to forward, or "" for ordinary
+// input. An interrupt repeated within interruptEscalationWindow escalates to
+// SIGTERM, matching the instinct of hammering ^C at a process that ignored
+// the first one.
+func (s *signalForwardingReader) signalFor(b byte) string {
+	switch b {
+	case 0x03: // ETX, ^C
+		now := time.Now()
+		if !s.lastInterrupt.IsZero() && now.Sub(s.lastInterrupt) <= interruptEscalationWindow {
+			s.lastInterrupt = time.Time{}
+			return "SIGTERM"
+		}
+		s.lastInterrupt = now
+		return "SIGINT"
+	case 0x1c: // FS, ^\
+		return "SIGQUIT"
+	}
+	return ""
+}
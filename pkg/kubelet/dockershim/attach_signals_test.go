@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignalForwardingReader tests that control bytes are stripped from the
+// stream and forwarded as signals while ordinary input passes through.
+func TestSignalForwardingReader(t *testing.T) {
+	var signals []string
+	r := newSignalForwardingReader(bytes.NewReader([]byte("ab\x03cd\x1cef")), func(signal string) {
+		signals = append(signals, signal)
+	})
+	out, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(out))
+	assert.Equal(t, []string{"SIGINT", "SIGQUIT"}, signals)
+}
+
+// TestSignalForwardingEscalation tests that a second interrupt inside the
+// escalation window becomes SIGTERM and a third starts over with SIGINT.
+func TestSignalForwardingEscalation(t *testing.T) {
+	var signals []string
+	r := newSignalForwardingReader(bytes.NewReader([]byte("\x03\x03\x03")), func(signal string) {
+		signals = append(signals, signal)
+	})
+	out, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	assert.Equal(t, []string{"SIGINT", "SIGTERM", "SIGINT"}, signals)
+
+	// An interrupt long after the previous one does not escalate.
+	r.lastInterrupt = time.Now().Add(-2 * interruptEscalationWindow)
+	assert.Equal(t, "SIGINT", r.signalFor(0x03))
+}
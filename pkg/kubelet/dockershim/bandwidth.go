@@ -0,0 +1,94 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/util/bandwidth"
+)
+
+// This file applies the pod bandwidth annotations
+// (kubernetes.io/ingress-bandwidth, kubernetes.io/egress-bandwidth) under the
+// shim. Traffic is shaped with tc on the bridge device, keyed by the pod IP,
+// the same way kubenet shapes pods. Plugins that shape traffic themselves,
+// like kubenet or a CNI chain including the bandwidth plugin, advertise the
+// shaping capability and the shim leaves their pods alone.
+
+// defaultShapingInterface is the device the tc rules are attached to: the
+// bridge created by the standard CNI bridge plugin.
+const defaultShapingInterface = "cni0"
+
+// shaper returns the bandwidth shaper and, if it hasn't been created before,
+// initializes it on first use, mirroring kubenet.
+func (ds *dockerService) shaper() bandwidth.BandwidthShaper {
+	if ds.bandwidthShaper == nil {
+		ds.bandwidthShaper = bandwidth.NewTCShaper(defaultShapingInterface)
+		ds.bandwidthShaper.ReconcileInterface()
+	}
+	return ds.bandwidthShaper
+}
+
+// setUpSandboxBandwidth applies the bandwidth limits requested in the pod's
+// annotations. It must be called after the network plugin has set up the
+// pod, since it needs the pod IP.
+func (ds *dockerService) setUpSandboxBandwidth(podSandboxID string, annotations map[string]string) error {
+	ingress, egress, err := bandwidth.ExtractPodBandwidthResources(annotations)
+	if err != nil {
+		return fmt.Errorf("error reading pod bandwidth annotations: %v", err)
+	}
+	if ingress == nil && egress == nil {
+		return nil
+	}
+	if ds.networkPlugin.Capabilities().Has(network.NET_PLUGIN_CAPABILITY_SHAPING) {
+		// The plugin shapes traffic itself; nothing to do for the shim.
+		return nil
+	}
+	r, err := ds.client.InspectContainer(podSandboxID)
+	if err != nil {
+		return err
+	}
+	podIP, err := ds.getIP(r)
+	if err != nil {
+		return err
+	}
+	if podIP == "" {
+		return fmt.Errorf("no IP found for sandbox %q", podSandboxID)
+	}
+	// The shaper wants a /32.
+	if err := ds.shaper().ReconcileCIDR(fmt.Sprintf("%s/32", podIP), egress, ingress); err != nil {
+		return fmt.Errorf("failed to add sandbox %q to shaper: %v", podSandboxID, err)
+	}
+	glog.V(2).Infof("Set up bandwidth limits for sandbox %q (ingress: %v, egress: %v)", podSandboxID, ingress, egress)
+	return nil
+}
+
+// tearDownSandboxBandwidth removes the tc rules of the sandbox. The pod IP
+// must be passed in, since by teardown time the sandbox may no longer have
+// one. Failures are logged only; shaping may simply not have been enabled
+// for the pod.
+func (ds *dockerService) tearDownSandboxBandwidth(podSandboxID, podIP string) {
+	if podIP == "" || ds.networkPlugin.Capabilities().Has(network.NET_PLUGIN_CAPABILITY_SHAPING) {
+		return
+	}
+	if err := ds.shaper().Reset(fmt.Sprintf("%s/32", podIP)); err != nil {
+		glog.V(4).Infof("Failed to remove sandbox %q (IP %s) from shaper: %v", podSandboxID, podIP, err)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// recordingShaper records the CIDRs added to and removed from the shaper.
+type recordingShaper struct {
+	limited []string
+	reset   []string
+}
+
+func (s *recordingShaper) Limit(cidr string, egress, ingress *resource.Quantity) error {
+	s.limited = append(s.limited, cidr)
+	return nil
+}
+
+func (s *recordingShaper) Reset(cidr string) error {
+	s.reset = append(s.reset, cidr)
+	return nil
+}
+
+func (s *recordingShaper) ReconcileInterface() error {
+	return nil
+}
+
+func (s *recordingShaper) ReconcileCIDR(cidr string, egress, ingress *resource.Quantity) error {
+	s.limited = append(s.limited, cidr)
+	return nil
+}
+
+func (s *recordingShaper) GetCIDRs() ([]string, error) {
+	return s.limited, nil
+}
+
+// TestSandboxBandwidthShaping verifies that the pod bandwidth annotations
+// are applied through the shaper when the sandbox comes up and removed again
+// when it is stopped.
+func TestSandboxBandwidthShaping(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	require.NoError(t, ds.featureGate.set("BandwidthShaping=true"))
+	shaper := &recordingShaper{}
+	ds.bandwidthShaper = shaper
+
+	c := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{},
+		map[string]string{"kubernetes.io/ingress-bandwidth": "10M"})
+	id, err := ds.RunPodSandbox(c)
+	require.NoError(t, err)
+	// The fake docker client reports 2.3.4.5 for started containers.
+	assert.Equal(t, []string{"2.3.4.5/32"}, shaper.limited)
+
+	require.NoError(t, ds.StopPodSandbox(id))
+	assert.Equal(t, []string{"2.3.4.5/32"}, shaper.reset)
+}
+
+// TestSandboxBandwidthShapingSkipped verifies the cases in which the shim
+// does not touch the shaper.
+func TestSandboxBandwidthShapingSkipped(t *testing.T) {
+	// Without bandwidth annotations nothing is shaped.
+	ds, _, _ := newTestDockerService()
+	require.NoError(t, ds.featureGate.set("BandwidthShaping=true"))
+	shaper := &recordingShaper{}
+	ds.bandwidthShaper = shaper
+
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.NoError(t, err)
+	assert.Empty(t, shaper.limited)
+	// Teardown always resets; whether the pod was shaped is not tracked, and
+	// a reset for an unshaped pod is tolerated.
+	require.NoError(t, ds.StopPodSandbox(id))
+
+	// With the feature gate off the annotations are ignored.
+	ds, _, _ = newTestDockerService()
+	ds.bandwidthShaper = shaper
+	c := makeSandboxConfigWithLabelsAndAnnotations("foo2", "bar", "2", 0,
+		map[string]string{},
+		map[string]string{"kubernetes.io/egress-bandwidth": "1M"})
+	_, err = ds.RunPodSandbox(c)
+	require.NoError(t, err)
+	assert.Empty(t, shaper.limited)
+
+	// Malformed annotations fail the sandbox.
+	ds, _, _ = newTestDockerService()
+	require.NoError(t, ds.featureGate.set("BandwidthShaping=true"))
+	ds.bandwidthShaper = shaper
+	c = makeSandboxConfigWithLabelsAndAnnotations("foo3", "bar", "3", 0,
+		map[string]string{},
+		map[string]string{"kubernetes.io/ingress-bandwidth": "not-a-quantity"})
+	_, err = ds.RunPodSandbox(c)
+	assert.Error(t, err)
+}
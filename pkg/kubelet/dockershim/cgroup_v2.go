@@ -0,0 +1,129 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/golang/glog"
+)
+
+// This file lets dockershim run on nodes that mount the unified cgroup
+// hierarchy (cgroup v2). The docker API in use only speaks cgroup v1: it
+// carries cpu.shares and a combined memory+swap limit, neither of which
+// exists on the unified hierarchy. The shim therefore translates the
+// container's resource settings into their v2 equivalents and writes them
+// into the container's cgroup right after the container starts, the same way
+// hugetlb limits are applied.
+
+const (
+	// cgroupV2ControllersFile only exists when the unified cgroup hierarchy
+	// is mounted at the conventional location; its presence identifies a
+	// cgroup v2 node.
+	cgroupV2ControllersFile = "/sys/fs/cgroup/cgroup.controllers"
+	// unifiedCgroupRoot is the mount point of the unified hierarchy.
+	unifiedCgroupRoot = "/sys/fs/cgroup"
+)
+
+// isCgroupV2UnifiedMode reports whether the node runs with the unified
+// cgroup hierarchy.
+func isCgroupV2UnifiedMode() bool {
+	_, err := os.Stat(cgroupV2ControllersFile)
+	return err == nil
+}
+
+// cpuSharesToCgroupV2Weight converts a cgroup v1 cpu.shares value, in
+// [2, 262144], to the equivalent cgroup v2 cpu.weight value, in [1, 10000].
+// A non-positive shares value means no weight was requested and maps to zero.
+func cpuSharesToCgroupV2Weight(shares int64) int64 {
+	if shares <= 0 {
+		return 0
+	}
+	if shares < 2 {
+		shares = 2
+	} else if shares > 262144 {
+		shares = 262144
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+// containerCgroupV2Path returns the container's directory in the unified
+// hierarchy. The layout depends on the cgroup driver docker runs with.
+func (ds *dockerService) containerCgroupV2Path(cgroupParent, containerID string) string {
+	if ds.cgroupDriver == "systemd" {
+		return filepath.Join(unifiedCgroupRoot, cgroupParent, fmt.Sprintf("docker-%s.scope", containerID))
+	}
+	return filepath.Join(unifiedCgroupRoot, cgroupParent, containerID)
+}
+
+// applyCgroupV2Resources translates the v1-style resource fields into their
+// unified-hierarchy equivalents and writes them into the container's cgroup
+// directory.
+func applyCgroupV2Resources(cgroupPath string, resources *dockercontainer.Resources) error {
+	settings := map[string]string{}
+	if weight := cpuSharesToCgroupV2Weight(resources.CPUShares); weight > 0 {
+		settings["cpu.weight"] = strconv.FormatInt(weight, 10)
+	}
+	if resources.Memory > 0 {
+		settings["memory.max"] = strconv.FormatInt(resources.Memory, 10)
+	}
+	// MemoryReservation is a soft floor (reclaim protection), which the
+	// unified hierarchy spells memory.low; memory.high would throttle the
+	// workload at the reservation instead.
+	if resources.MemoryReservation > 0 {
+		settings["memory.low"] = strconv.FormatInt(resources.MemoryReservation, 10)
+	}
+	// The unified hierarchy accounts swap separately, while the docker field
+	// carries a combined memory+swap limit.
+	if resources.MemorySwap < 0 {
+		settings["memory.swap.max"] = "max"
+	} else if resources.MemorySwap > 0 && resources.Memory > 0 {
+		swap := resources.MemorySwap - resources.Memory
+		if swap < 0 {
+			swap = 0
+		}
+		settings["memory.swap.max"] = strconv.FormatInt(swap, 10)
+	}
+	for file, value := range settings {
+		if err := ioutil.WriteFile(filepath.Join(cgroupPath, file), []byte(value), 0644); err != nil {
+			return fmt.Errorf("failed to set %s to %s: %v", file, value, err)
+		}
+		glog.V(4).Infof("Set %s to %s in %s", file, value, cgroupPath)
+	}
+	return nil
+}
+
+// setUpContainerCgroupV2Resources applies the container's resource settings
+// to its cgroup on unified-hierarchy nodes. It is a no-op on cgroup v1 nodes,
+// where docker enforces the settings itself. It must run after the container
+// started, once the cgroup exists.
+func (ds *dockerService) setUpContainerCgroupV2Resources(containerID string) error {
+	if !ds.cgroupV2 {
+		return nil
+	}
+	r, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return err
+	}
+	cgroupPath := ds.containerCgroupV2Path(r.HostConfig.CgroupParent, containerID)
+	return applyCgroupV2Resources(cgroupPath, &r.HostConfig.Resources)
+}
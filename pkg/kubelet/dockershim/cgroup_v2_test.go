@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUSharesToCgroupV2Weight(t *testing.T) {
+	for shares, weight := range map[int64]int64{
+		0:      0,
+		2:      1,
+		1024:   39,
+		262144: 10000,
+		// Out-of-range values are clamped.
+		1:      1,
+		500000: 10000,
+	} {
+		assert.Equal(t, weight, cpuSharesToCgroupV2Weight(shares), "shares %d", shares)
+	}
+}
+
+func TestContainerCgroupV2Path(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.cgroupDriver = "cgroupfs"
+	assert.Equal(t, "/sys/fs/cgroup/kubepods/c1", ds.containerCgroupV2Path("/kubepods", "c1"))
+	ds.cgroupDriver = "systemd"
+	assert.Equal(t, "/sys/fs/cgroup/kubepods.slice/docker-c1.scope", ds.containerCgroupV2Path("kubepods.slice", "c1"))
+}
+
+func TestApplyCgroupV2Resources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroupv2")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	resources := &dockercontainer.Resources{
+		CPUShares:         1024,
+		Memory:            2048,
+		MemoryReservation: 1024,
+		MemorySwap:        3072,
+	}
+	require.NoError(t, applyCgroupV2Resources(dir, resources))
+	for file, want := range map[string]string{
+		"cpu.weight":      "39",
+		"memory.max":      "2048",
+		"memory.low":      "1024",
+		"memory.swap.max": "1024",
+	} {
+		value, err := ioutil.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err, file)
+		assert.Equal(t, want, string(value), file)
+	}
+
+	// An unlimited memory+swap limit lifts the swap limit.
+	resources.MemorySwap = -1
+	require.NoError(t, applyCgroupV2Resources(dir, resources))
+	value, err := ioutil.ReadFile(filepath.Join(dir, "memory.swap.max"))
+	require.NoError(t, err)
+	assert.Equal(t, "max", string(value))
+}
+
+func TestGenerateExpectedCgroupParentCgroupV2(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.cgroupDriver = "cgroupfs"
+
+	parent, err := ds.GenerateExpectedCgroupParent("/kubepods/burstable")
+	require.NoError(t, err)
+	assert.Equal(t, "/kubepods/burstable", parent)
+
+	// On cgroup v2 nodes systemd-style names are expected even with the
+	// cgroupfs driver.
+	ds.cgroupV2 = true
+	parent, err = ds.GenerateExpectedCgroupParent("/kubepods/burstable")
+	require.NoError(t, err)
+	assert.Equal(t, "burstable", parent)
+}
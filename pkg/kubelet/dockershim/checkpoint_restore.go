@@ -0,0 +1,124 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"os"
+
+	dockertypes "github.com/docker/engine-api/types"
+)
+
+// Experimental container checkpoint/restore, backed by docker's CRIU
+// integration. A checkpoint freezes a running container's process tree and
+// writes its memory and state to disk; restoring starts the container again
+// from that image instead of from scratch, preserving in-memory state. This
+// is the building block for pod live-migration experiments. The operations
+// are gated behind the CheckpointRestore dockershim feature gate and require
+// an experimental docker daemon with criu installed on the node.
+
+// defaultContainerCheckpointDir is where container checkpoint images are
+// stored when the caller does not name a directory. It is deliberately
+// distinct from the sandbox checkpoint store under /var/lib/dockershim,
+// which holds shim metadata rather than CRIU images.
+const defaultContainerCheckpointDir = "/var/lib/dockershim/container-checkpoints"
+
+// CheckpointContainer checkpoints a running container into checkpointDir
+// (the default directory if empty) under the given checkpoint name. With
+// leaveRunning the container keeps running after the dump; otherwise it
+// exits, which is the variant a migration wants.
+func (ds *dockerService) CheckpointContainer(containerID string, checkpointID string, checkpointDir string, leaveRunning bool) error {
+	if !ds.featureGate.Enabled(checkpointRestoreFeature) {
+		return fmt.Errorf("checkpointing container %q is disabled; enable the %s dockershim feature gate to use it", containerID, checkpointRestoreFeature)
+	}
+	if checkpointID == "" {
+		return fmt.Errorf("checkpoint name may not be empty")
+	}
+	if checkpointDir == "" {
+		checkpointDir = defaultContainerCheckpointDir
+	}
+	if err := os.MkdirAll(checkpointDir, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory %q: %v", checkpointDir, err)
+	}
+	if _, err := checkContainerStatus(ds.client, containerID); err != nil {
+		return err
+	}
+	if err := ds.client.CheckpointContainer(containerID, dockertypes.CheckpointCreateOptions{
+		CheckpointID:  checkpointID,
+		CheckpointDir: checkpointDir,
+		Exit:          !leaveRunning,
+	}); err != nil {
+		return fmt.Errorf("failed to checkpoint container %q: %v", containerID, err)
+	}
+	return nil
+}
+
+// RestoreContainer starts a stopped container from a previously created
+// checkpoint. The container must already exist with a matching
+// configuration; only its process state is restored from the image.
+func (ds *dockerService) RestoreContainer(containerID string, checkpointID string, checkpointDir string) error {
+	if !ds.featureGate.Enabled(checkpointRestoreFeature) {
+		return fmt.Errorf("restoring container %q is disabled; enable the %s dockershim feature gate to use it", containerID, checkpointRestoreFeature)
+	}
+	if checkpointDir == "" {
+		checkpointDir = defaultContainerCheckpointDir
+	}
+	container, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return err
+	}
+	if container.State.Running {
+		return fmt.Errorf("cannot restore container %q: container is running", containerID)
+	}
+	checkpoints, err := ds.client.ListCheckpoints(containerID, dockertypes.CheckpointListOptions{CheckpointDir: checkpointDir})
+	if err != nil {
+		return fmt.Errorf("failed to list checkpoints of container %q: %v", containerID, err)
+	}
+	found := false
+	for _, c := range checkpoints {
+		if c.Name == checkpointID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("container %q has no checkpoint %q in %q", containerID, checkpointID, checkpointDir)
+	}
+	if err := ds.client.StartContainerFromCheckpoint(containerID, checkpointID, checkpointDir); err != nil {
+		return fmt.Errorf("failed to restore container %q from checkpoint %q: %v", containerID, checkpointID, err)
+	}
+	ds.inspectCache.invalidate(containerID)
+	return nil
+}
+
+// RemoveContainerCheckpoint deletes a checkpoint image that is no longer
+// needed, e.g. after a successful migration.
+func (ds *dockerService) RemoveContainerCheckpoint(containerID string, checkpointID string, checkpointDir string) error {
+	if !ds.featureGate.Enabled(checkpointRestoreFeature) {
+		return fmt.Errorf("removing checkpoints of container %q is disabled; enable the %s dockershim feature gate to use it", containerID, checkpointRestoreFeature)
+	}
+	if checkpointDir == "" {
+		checkpointDir = defaultContainerCheckpointDir
+	}
+	if err := ds.client.RemoveCheckpoint(containerID, dockertypes.CheckpointDeleteOptions{
+		CheckpointID:  checkpointID,
+		CheckpointDir: checkpointDir,
+	}); err != nil {
+		return fmt.Errorf("failed to remove checkpoint %q of container %q: %v", checkpointID, containerID, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckpointRestore tests the experimental checkpoint/restore cycle
+// against the fake docker client.
+func TestCheckpointRestore(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	sConfig := makeSandboxConfig("foo", "default", "1", 0)
+	config := makeContainerConfig(sConfig, "bar", "busybox", 0, nil, nil)
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.NoError(t, ds.StartContainer(id))
+
+	dir, err := ioutil.TempDir("", "container-checkpoints")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Both operations are disabled until the feature gate is flipped.
+	err = ds.CheckpointContainer(id, "cp0", dir, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), checkpointRestoreFeature)
+	err = ds.RestoreContainer(id, "cp0", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), checkpointRestoreFeature)
+
+	require.NoError(t, ds.featureGate.set("CheckpointRestore=true"))
+	require.NoError(t, ds.CheckpointContainer(id, "cp0", dir, true))
+	assert.Equal(t, []string{"cp0"}, fDocker.Checkpoints[id])
+
+	// A running container cannot be restored in place.
+	err = ds.RestoreContainer(id, "cp0", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "container is running")
+
+	require.NoError(t, ds.StopContainer(id, 0))
+	require.Error(t, ds.RestoreContainer(id, "does-not-exist", dir))
+	require.NoError(t, ds.RestoreContainer(id, "cp0", dir))
+	r, err := fDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.True(t, r.State.Running)
+
+	require.NoError(t, ds.RemoveContainerCheckpoint(id, "cp0", dir))
+	assert.Empty(t, fDocker.Checkpoints[id])
+}
+
+// TestCheckpointContainerValidation tests the argument checks that happen
+// before the daemon is involved.
+func TestCheckpointContainerValidation(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	require.NoError(t, ds.featureGate.set("CheckpointRestore=true"))
+
+	err := ds.CheckpointContainer("whatever", "", "", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checkpoint name may not be empty")
+}
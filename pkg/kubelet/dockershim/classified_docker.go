@@ -0,0 +1,151 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"strings"
+
+	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+// classifyDockerError attaches one of the shim's error classes to an error
+// returned by the docker client, so callers can branch on error class instead
+// of matching message strings. The wrapped error keeps the original message;
+// errors that fit no class are returned unchanged.
+func classifyDockerError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case dockertools.IsContainerNotFoundError(err), dockertools.IsImageNotFoundError(err):
+		return errors.NewNotFound(err)
+	case dockertools.IsOperationTimeoutError(err):
+		return errors.NewTimeout(err)
+	case strings.Contains(err.Error(), "Cannot connect to the Docker daemon"):
+		return errors.NewDaemonUnavailable(err)
+	case strings.Contains(strings.ToLower(err.Error()), "conflict"),
+		strings.Contains(err.Error(), "already in progress"):
+		return errors.NewConflict(err)
+	}
+	return err
+}
+
+// isContainerNotFound checks whether the error, classified or not, says the
+// container does not exist. The legacy message match covers errors that did
+// not pass through the classifying client wrapper (e.g. in tests).
+func isContainerNotFound(err error) bool {
+	return errors.IsNotFound(err) || dockertools.IsContainerNotFoundError(err)
+}
+
+// isImageNotFound is the image analogue of isContainerNotFound.
+func isImageNotFound(err error) bool {
+	return errors.IsNotFound(err) || dockertools.IsImageNotFoundError(err)
+}
+
+// classifiedDockerInterface wraps a DockerInterface and classifies the errors
+// of the operations the shim's sandbox, container and image services use.
+// Operations it does not override pass through unclassified.
+type classifiedDockerInterface struct {
+	dockertools.DockerInterface
+}
+
+// newClassifiedDockerInterface creates an instance of classifiedDockerInterface.
+func newClassifiedDockerInterface(client dockertools.DockerInterface) dockertools.DockerInterface {
+	return &classifiedDockerInterface{DockerInterface: client}
+}
+
+func (c *classifiedDockerInterface) ListContainers(options dockertypes.ContainerListOptions) ([]dockertypes.Container, error) {
+	out, err := c.DockerInterface.ListContainers(options)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) InspectContainer(id string) (*dockertypes.ContainerJSON, error) {
+	out, err := c.DockerInterface.InspectContainer(id)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) InspectContainerWithSize(id string) (*dockertypes.ContainerJSON, error) {
+	out, err := c.DockerInterface.InspectContainerWithSize(id)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) CreateContainer(opts dockertypes.ContainerCreateConfig) (*dockertypes.ContainerCreateResponse, error) {
+	out, err := c.DockerInterface.CreateContainer(opts)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) StartContainer(id string) error {
+	return classifyDockerError(c.DockerInterface.StartContainer(id))
+}
+
+func (c *classifiedDockerInterface) StopContainer(id string, timeout int) error {
+	return classifyDockerError(c.DockerInterface.StopContainer(id, timeout))
+}
+
+func (c *classifiedDockerInterface) KillContainer(id string, signal string) error {
+	return classifyDockerError(c.DockerInterface.KillContainer(id, signal))
+}
+
+func (c *classifiedDockerInterface) RemoveContainer(id string, opts dockertypes.ContainerRemoveOptions) error {
+	return classifyDockerError(c.DockerInterface.RemoveContainer(id, opts))
+}
+
+func (c *classifiedDockerInterface) UpdateContainerResources(id string, updateConfig dockercontainer.UpdateConfig) error {
+	return classifyDockerError(c.DockerInterface.UpdateContainerResources(id, updateConfig))
+}
+
+func (c *classifiedDockerInterface) InspectImageByRef(imageRef string) (*dockertypes.ImageInspect, error) {
+	out, err := c.DockerInterface.InspectImageByRef(imageRef)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) InspectImageByID(imageID string) (*dockertypes.ImageInspect, error) {
+	out, err := c.DockerInterface.InspectImageByID(imageID)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) ListImages(opts dockertypes.ImageListOptions) ([]dockertypes.Image, error) {
+	out, err := c.DockerInterface.ListImages(opts)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) PullImage(image string, auth dockertypes.AuthConfig, opts dockertypes.ImagePullOptions) error {
+	return classifyDockerError(c.DockerInterface.PullImage(image, auth, opts))
+}
+
+func (c *classifiedDockerInterface) TagImage(image string, ref string) error {
+	return classifyDockerError(c.DockerInterface.TagImage(image, ref))
+}
+
+func (c *classifiedDockerInterface) RemoveImage(image string, opts dockertypes.ImageRemoveOptions) ([]dockertypes.ImageDelete, error) {
+	out, err := c.DockerInterface.RemoveImage(image, opts)
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) Version() (*dockertypes.Version, error) {
+	out, err := c.DockerInterface.Version()
+	return out, classifyDockerError(err)
+}
+
+func (c *classifiedDockerInterface) Info() (*dockertypes.Info, error) {
+	out, err := c.DockerInterface.Info()
+	return out, classifyDockerError(err)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/flowcontrol"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+)
+
+// TestClassifyDockerError tests the mapping from raw docker client errors to
+// the shim's error classes, and that classification keeps the message.
+func TestClassifyDockerError(t *testing.T) {
+	for desc, test := range map[string]struct {
+		err   error
+		class errors.ErrorClass
+	}{
+		"container not found": {
+			err:   fmt.Errorf("Error response from daemon: No such container: 2f659923hd"),
+			class: errors.NotFound,
+		},
+		"daemon unavailable": {
+			err:   fmt.Errorf("Cannot connect to the Docker daemon. Is the docker daemon running on this host?"),
+			class: errors.DaemonUnavailable,
+		},
+		"conflict": {
+			err:   fmt.Errorf("Error response from daemon: Conflict. The name \"/foo\" is already in use"),
+			class: errors.Conflict,
+		},
+		"removal in progress": {
+			err:   fmt.Errorf("removal of container 2f659923hd is already in progress"),
+			class: errors.Conflict,
+		},
+		"unclassified": {
+			err:   fmt.Errorf("something else went wrong"),
+			class: "",
+		},
+	} {
+		classified := classifyDockerError(test.err)
+		assert.Equal(t, test.class, errors.ClassOf(classified), desc)
+		assert.Equal(t, test.err.Error(), classified.Error(), desc)
+	}
+	assert.NoError(t, classifyDockerError(nil))
+}
+
+// TestClassifiedDockerInterface tests that errors surface classified through
+// the client wrapper.
+func TestClassifiedDockerInterface(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	ds.client = newClassifiedDockerInterface(fDocker)
+
+	fDocker.InjectError("inspect_container", fmt.Errorf("No such container: 2f659923hd"))
+	_, err := ds.client.InspectContainer("2f659923hd")
+	assert.True(t, errors.IsNotFound(err))
+	assert.True(t, isContainerNotFound(err))
+}
+
+// TestPullImageBackoffClass tests that a throttled pull is classified so the
+// kubelet can branch into its image pull backoff.
+func TestPullImageBackoffClass(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.pullLimiter = flowcontrol.NewFakeNeverRateLimiter()
+
+	_, err := ds.PullImage(&runtimeapi.ImageSpec{Image: "foo"}, nil)
+	assert.True(t, errors.IsImagePullBackoff(err))
+}
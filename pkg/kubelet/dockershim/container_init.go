@@ -0,0 +1,50 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// containerInitAnnotationKey opts a pod's containers in to (or out of)
+	// docker's init process (--init), which reaps zombies and forwards
+	// signals for images whose entrypoint is not a proper PID 1. The value
+	// is a bool; when the annotation is absent the node-level default from
+	// the ContainerInit feature gate applies.
+	containerInitAnnotationKey = "docker.alpha.kubernetes.io/container-init"
+)
+
+// containerInitFor decides whether the container runs under docker's init
+// process. A pod annotation wins in either direction; otherwise the
+// ContainerInit feature gate supplies the node default. A nil result leaves
+// the choice to the docker daemon's own configuration.
+func (ds *dockerService) containerInitFor(annotations map[string]string) (*bool, error) {
+	if value, ok := annotations[containerInitAnnotationKey]; ok {
+		useInit, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for annotation %q: %v", value, containerInitAnnotationKey, err)
+		}
+		return &useInit, nil
+	}
+	if ds.featureGate.Enabled(containerInitFeature) {
+		useInit := true
+		return &useInit, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContainerInitFor tests the annotation and feature gate precedence.
+func TestContainerInitFor(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	// Without the annotation and the gate the daemon decides.
+	useInit, err := ds.containerInitFor(nil)
+	require.NoError(t, err)
+	assert.Nil(t, useInit)
+
+	// The gate supplies the node default.
+	require.NoError(t, ds.featureGate.set("ContainerInit=true"))
+	useInit, err = ds.containerInitFor(nil)
+	require.NoError(t, err)
+	require.NotNil(t, useInit)
+	assert.True(t, *useInit)
+
+	// The annotation overrides the gate in either direction.
+	useInit, err = ds.containerInitFor(map[string]string{containerInitAnnotationKey: "false"})
+	require.NoError(t, err)
+	require.NotNil(t, useInit)
+	assert.False(t, *useInit)
+
+	require.NoError(t, ds.featureGate.set("ContainerInit=false"))
+	useInit, err = ds.containerInitFor(map[string]string{containerInitAnnotationKey: "true"})
+	require.NoError(t, err)
+	require.NotNil(t, useInit)
+	assert.True(t, *useInit)
+
+	// Non-bool values fail the container instead of being ignored.
+	_, err = ds.containerInitFor(map[string]string{containerInitAnnotationKey: "tini"})
+	assert.Error(t, err)
+}
+
+// TestMakeContainerDockerConfigInit tests that the decision reaches the
+// container's host config.
+func TestMakeContainerDockerConfigInit(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil, nil)
+	containerConfig, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	assert.Nil(t, containerConfig.HostConfig.Init)
+
+	sConfig = makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{}, map[string]string{containerInitAnnotationKey: "true"})
+	containerConfig, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.NotNil(t, containerConfig.HostConfig.Init)
+	assert.True(t, *containerConfig.HostConfig.Init)
+
+	sConfig = makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{}, map[string]string{containerInitAnnotationKey: "sometimes"})
+	_, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	assert.Error(t, err)
+}
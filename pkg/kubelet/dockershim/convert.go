@@ -17,167 +17,60 @@ limitations under the License.
 package dockershim
 
 import (
-	"fmt"
-	"strings"
-	"time"
-
 	dockertypes "github.com/docker/engine-api/types"
 
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/convert"
 )
 
-// This file contains helper functions to convert docker API types to runtime
-// API types, or vice versa.
-
-const (
-	// Status of a container returned by docker ListContainers
-	statusRunningPrefix = "Up"
-	statusCreatedPrefix = "Created"
-	statusExitedPrefix  = "Exited"
-)
+// The conversion between docker API types and runtime API types lives in the
+// convert sub-package so that external node tooling stays in sync with the
+// shim. This file only keeps thin aliases for the shim's internal use.
 
 func imageToRuntimeAPIImage(image *dockertypes.Image) (*runtimeapi.Image, error) {
-	if image == nil {
-		return nil, fmt.Errorf("unable to convert a nil pointer to a runtime API image")
-	}
-
-	size := uint64(image.VirtualSize)
-	return &runtimeapi.Image{
-		Id:          image.ID,
-		RepoTags:    image.RepoTags,
-		RepoDigests: image.RepoDigests,
-		Size_:       size,
-	}, nil
+	return convert.ImageToRuntimeAPIImage(image)
 }
 
 func imageInspectToRuntimeAPIImage(image *dockertypes.ImageInspect) (*runtimeapi.Image, error) {
-	if image == nil {
-		return nil, fmt.Errorf("unable to convert a nil pointer to a runtime API image")
-	}
-
-	size := uint64(image.VirtualSize)
-	runtimeImage := &runtimeapi.Image{
-		Id:          image.ID,
-		RepoTags:    image.RepoTags,
-		RepoDigests: image.RepoDigests,
-		Size_:       size,
-	}
-
-	uid, username := getUserFromImageUser(image.Config.User)
-	if uid != nil {
-		runtimeImage.Uid = &runtimeapi.Int64Value{Value: *uid}
-	}
-	runtimeImage.Username = username
-	return runtimeImage, nil
+	return convert.ImageInspectToRuntimeAPIImage(image)
 }
 
 func toPullableImageID(id string, image *dockertypes.ImageInspect) string {
-	// Default to the image ID, but if RepoDigests is not empty, use
-	// the first digest instead.
-	imageID := DockerImageIDPrefix + id
-	if len(image.RepoDigests) > 0 {
-		imageID = DockerPullableImageIDPrefix + image.RepoDigests[0]
-	}
-	return imageID
+	return convert.ToPullableImageID(id, image)
 }
 
 func toRuntimeAPIContainer(c *dockertypes.Container) (*runtimeapi.Container, error) {
-	state := toRuntimeAPIContainerState(c.Status)
-	if len(c.Names) == 0 {
-		return nil, fmt.Errorf("unexpected empty container name: %+v", c)
-	}
-	metadata, err := parseContainerName(c.Names[0])
-	if err != nil {
-		return nil, err
-	}
-	labels, annotations := extractLabels(c.Labels)
-	sandboxID := c.Labels[sandboxIDLabelKey]
-	// The timestamp in dockertypes.Container is in seconds.
-	createdAt := c.Created * int64(time.Second)
-	return &runtimeapi.Container{
-		Id:           c.ID,
-		PodSandboxId: sandboxID,
-		Metadata:     metadata,
-		Image:        &runtimeapi.ImageSpec{Image: c.Image},
-		ImageRef:     c.ImageID,
-		State:        state,
-		CreatedAt:    createdAt,
-		Labels:       labels,
-		Annotations:  annotations,
-	}, nil
+	return convert.ToRuntimeAPIContainer(c)
 }
 
 func toDockerContainerStatus(state runtimeapi.ContainerState) string {
-	switch state {
-	case runtimeapi.ContainerState_CONTAINER_CREATED:
-		return "created"
-	case runtimeapi.ContainerState_CONTAINER_RUNNING:
-		return "running"
-	case runtimeapi.ContainerState_CONTAINER_EXITED:
-		return "exited"
-	case runtimeapi.ContainerState_CONTAINER_UNKNOWN:
-		fallthrough
-	default:
-		return "unknown"
-	}
+	return convert.ToDockerContainerStatus(state)
 }
 
 func toRuntimeAPIContainerState(state string) runtimeapi.ContainerState {
-	// Parse the state string in dockertypes.Container. This could break when
-	// we upgrade docker.
-	switch {
-	case strings.HasPrefix(state, statusRunningPrefix):
-		return runtimeapi.ContainerState_CONTAINER_RUNNING
-	case strings.HasPrefix(state, statusExitedPrefix):
-		return runtimeapi.ContainerState_CONTAINER_EXITED
-	case strings.HasPrefix(state, statusCreatedPrefix):
-		return runtimeapi.ContainerState_CONTAINER_CREATED
-	default:
-		return runtimeapi.ContainerState_CONTAINER_UNKNOWN
-	}
+	return convert.ToRuntimeAPIContainerState(state)
 }
 
 func toRuntimeAPISandboxState(state string) runtimeapi.PodSandboxState {
-	// Parse the state string in dockertypes.Container. This could break when
-	// we upgrade docker.
-	switch {
-	case strings.HasPrefix(state, statusRunningPrefix):
-		return runtimeapi.PodSandboxState_SANDBOX_READY
-	default:
-		return runtimeapi.PodSandboxState_SANDBOX_NOTREADY
-	}
+	return convert.ToRuntimeAPISandboxState(state)
 }
 
 func containerToRuntimeAPISandbox(c *dockertypes.Container) (*runtimeapi.PodSandbox, error) {
-	state := toRuntimeAPISandboxState(c.Status)
-	if len(c.Names) == 0 {
-		return nil, fmt.Errorf("unexpected empty sandbox name: %+v", c)
-	}
-	metadata, err := parseSandboxName(c.Names[0])
-	if err != nil {
-		return nil, err
-	}
-	labels, annotations := extractLabels(c.Labels)
-	// The timestamp in dockertypes.Container is in seconds.
-	createdAt := c.Created * int64(time.Second)
-	return &runtimeapi.PodSandbox{
-		Id:          c.ID,
-		Metadata:    metadata,
-		State:       state,
-		CreatedAt:   createdAt,
-		Labels:      labels,
-		Annotations: annotations,
-	}, nil
+	return convert.ContainerToRuntimeAPISandbox(c)
 }
 
 func checkpointToRuntimeAPISandbox(id string, checkpoint *PodSandboxCheckpoint) *runtimeapi.PodSandbox {
 	state := runtimeapi.PodSandboxState_SANDBOX_NOTREADY
+	metadata := &runtimeapi.PodSandboxMetadata{
+		Name:      checkpoint.Name,
+		Namespace: checkpoint.Namespace,
+	}
+	if checkpoint.Data != nil {
+		metadata.Attempt = checkpoint.Data.Attempt
+	}
 	return &runtimeapi.PodSandbox{
-		Id: id,
-		Metadata: &runtimeapi.PodSandboxMetadata{
-			Name:      checkpoint.Name,
-			Namespace: checkpoint.Namespace,
-		},
-		State: state,
+		Id:       id,
+		Metadata: metadata,
+		State:    state,
 	}
 }
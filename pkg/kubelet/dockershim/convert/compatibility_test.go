@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// The tests in this file pin the on-node formats (docker names and label
+// keys) that external tooling relies on. If one of them fails, the change is
+// backward incompatible and must not be made lightly.
+
+// TestNameFormatCompatibility verifies the exact docker name format generated
+// for sandboxes and containers.
+func TestNameFormatCompatibility(t *testing.T) {
+	config := &runtimeapi.PodSandboxConfig{
+		Metadata: &runtimeapi.PodSandboxMetadata{
+			Name:      "podname",
+			Namespace: "podnamespace",
+			Uid:       "poduid",
+			Attempt:   1,
+		},
+	}
+	containerConfig := &runtimeapi.ContainerConfig{
+		Metadata: &runtimeapi.ContainerMetadata{
+			Name:    "containername",
+			Attempt: 2,
+		},
+	}
+	assert.Equal(t, "k8s_POD_podname_podnamespace_poduid_1", MakeSandboxName(config))
+	assert.Equal(t, "k8s_containername_podname_podnamespace_poduid_2", MakeContainerName(config, containerConfig))
+}
+
+// TestNameParseRoundTrip verifies that generated names parse back to the
+// original metadata, with or without the random suffix appended on docker
+// name conflicts.
+func TestNameParseRoundTrip(t *testing.T) {
+	config := &runtimeapi.PodSandboxConfig{
+		Metadata: &runtimeapi.PodSandboxMetadata{
+			Name:      "podname",
+			Namespace: "podnamespace",
+			Uid:       "poduid",
+			Attempt:   1,
+		},
+	}
+	containerConfig := &runtimeapi.ContainerConfig{
+		Metadata: &runtimeapi.ContainerMetadata{
+			Name:    "containername",
+			Attempt: 2,
+		},
+	}
+
+	for _, name := range []string{MakeSandboxName(config), RandomizeName(MakeSandboxName(config))} {
+		metadata, err := ParseSandboxName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, config.Metadata, metadata)
+		// Docker adds a "/" prefix to names; parsing must tolerate it.
+		metadata, err = ParseSandboxName("/" + name)
+		assert.NoError(t, err)
+		assert.Equal(t, config.Metadata, metadata)
+	}
+
+	for _, name := range []string{MakeContainerName(config, containerConfig), RandomizeName(MakeContainerName(config, containerConfig))} {
+		metadata, err := ParseContainerName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, containerConfig.Metadata, metadata)
+	}
+
+	// Containers not managed by kubernetes must be rejected.
+	for _, name := range []string{"", "unknown", "frontend_POD_podname_podnamespace_poduid_1"} {
+		_, err := ParseSandboxName(name)
+		assert.Error(t, err, "sandbox name %q should not parse", name)
+		_, err = ParseContainerName(name)
+		assert.Error(t, err, "container name %q should not parse", name)
+	}
+}
+
+// TestLabelKeyCompatibility pins the docker label keys used by the shim.
+func TestLabelKeyCompatibility(t *testing.T) {
+	assert.Equal(t, "annotation.", AnnotationPrefix)
+	assert.Equal(t, "io.kubernetes.docker.type", ContainerTypeLabelKey)
+	assert.Equal(t, "podsandbox", ContainerTypeLabelSandbox)
+	assert.Equal(t, "container", ContainerTypeLabelContainer)
+	assert.Equal(t, "io.kubernetes.container.logpath", ContainerLogPathLabelKey)
+	assert.Equal(t, "io.kubernetes.sandbox.id", SandboxIDLabelKey)
+	assert.Equal(t, "io.kubernetes.docker.image-digest", ImageDigestLabelKey)
+	assert.Equal(t, "io.kubernetes.docker.audit.pod-uid", AuditPodUIDLabelKey)
+	assert.Equal(t, "io.kubernetes.docker.audit.attempt", AuditAttemptLabelKey)
+	assert.Equal(t, "io.kubernetes.docker.audit.kubelet-id", AuditKubeletIDLabelKey)
+	assert.Equal(t, "io.kubernetes.docker.audit.creation-reason", AuditCreationReasonLabelKey)
+}
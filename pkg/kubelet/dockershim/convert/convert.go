@@ -0,0 +1,213 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package convert contains the helpers used by the docker CRI shim to
+// convert docker API types to runtime API types, and to construct and parse
+// the docker names and labels managed by the shim. It is a stable,
+// self-contained package so external node tooling that reads docker state on
+// Kubernetes nodes can interpret it without reimplementing the parsing.
+package convert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+const (
+	// Status of a container returned by docker ListContainers
+	statusRunningPrefix = "Up"
+	statusCreatedPrefix = "Created"
+	statusExitedPrefix  = "Exited"
+
+	// DockerImageIDPrefix is the prefix of image id in container status.
+	DockerImageIDPrefix = dockertools.DockerPrefix
+	// DockerPullableImageIDPrefix is the prefix of pullable image id in container status.
+	DockerPullableImageIDPrefix = dockertools.DockerPullablePrefix
+)
+
+// ImageToRuntimeAPIImage converts the docker image to a runtime API image.
+func ImageToRuntimeAPIImage(image *dockertypes.Image) (*runtimeapi.Image, error) {
+	if image == nil {
+		return nil, fmt.Errorf("unable to convert a nil pointer to a runtime API image")
+	}
+
+	size := uint64(image.VirtualSize)
+	return &runtimeapi.Image{
+		Id:          image.ID,
+		RepoTags:    image.RepoTags,
+		RepoDigests: image.RepoDigests,
+		Size_:       size,
+	}, nil
+}
+
+// ImageInspectToRuntimeAPIImage converts the output of a docker image
+// inspect to a runtime API image.
+func ImageInspectToRuntimeAPIImage(image *dockertypes.ImageInspect) (*runtimeapi.Image, error) {
+	if image == nil {
+		return nil, fmt.Errorf("unable to convert a nil pointer to a runtime API image")
+	}
+
+	size := uint64(image.VirtualSize)
+	runtimeImage := &runtimeapi.Image{
+		Id:          image.ID,
+		RepoTags:    image.RepoTags,
+		RepoDigests: image.RepoDigests,
+		Size_:       size,
+	}
+
+	uid, username := GetUserFromImageUser(image.Config.User)
+	if uid != nil {
+		runtimeImage.Uid = &runtimeapi.Int64Value{Value: *uid}
+	}
+	runtimeImage.Username = username
+	return runtimeImage, nil
+}
+
+// ToPullableImageID returns the image id in container status that can be
+// used to pull the image again, preferring the repo digest if present.
+func ToPullableImageID(id string, image *dockertypes.ImageInspect) string {
+	// Default to the image ID, but if RepoDigests is not empty, use
+	// the first digest instead.
+	imageID := DockerImageIDPrefix + id
+	if len(image.RepoDigests) > 0 {
+		imageID = DockerPullableImageIDPrefix + image.RepoDigests[0]
+	}
+	return imageID
+}
+
+// GetUserFromImageUser gets uid or user name of the image user.
+// If user is numeric, it will be treated as uid; or else, it is treated as user name.
+func GetUserFromImageUser(imageUser string) (*int64, string) {
+	user := dockertools.GetUserFromImageUser(imageUser)
+	// return both nil if user is not specified in the image.
+	if user == "" {
+		return nil, ""
+	}
+	// user could be either uid or user name. Try to interpret as numeric uid.
+	uid, err := strconv.ParseInt(user, 10, 64)
+	if err != nil {
+		// If user is non numeric, assume it's user name.
+		return nil, user
+	}
+	// If user is a numeric uid.
+	return &uid, ""
+}
+
+// ToRuntimeAPIContainer converts the docker container to a runtime API
+// container.
+func ToRuntimeAPIContainer(c *dockertypes.Container) (*runtimeapi.Container, error) {
+	state := ToRuntimeAPIContainerState(c.Status)
+	if len(c.Names) == 0 {
+		return nil, fmt.Errorf("unexpected empty container name: %+v", c)
+	}
+	metadata, err := ParseContainerName(c.Names[0])
+	if err != nil {
+		return nil, err
+	}
+	labels, annotations := ExtractLabels(c.Labels)
+	sandboxID := c.Labels[SandboxIDLabelKey]
+	// The timestamp in dockertypes.Container is in seconds.
+	createdAt := c.Created * int64(time.Second)
+	return &runtimeapi.Container{
+		Id:           c.ID,
+		PodSandboxId: sandboxID,
+		Metadata:     metadata,
+		Image:        &runtimeapi.ImageSpec{Image: c.Image},
+		ImageRef:     c.ImageID,
+		State:        state,
+		CreatedAt:    createdAt,
+		Labels:       labels,
+		Annotations:  annotations,
+	}, nil
+}
+
+// ToDockerContainerStatus converts the runtime API container state to the
+// status string understood by docker's status filter.
+func ToDockerContainerStatus(state runtimeapi.ContainerState) string {
+	switch state {
+	case runtimeapi.ContainerState_CONTAINER_CREATED:
+		return "created"
+	case runtimeapi.ContainerState_CONTAINER_RUNNING:
+		return "running"
+	case runtimeapi.ContainerState_CONTAINER_EXITED:
+		return "exited"
+	case runtimeapi.ContainerState_CONTAINER_UNKNOWN:
+		fallthrough
+	default:
+		return "unknown"
+	}
+}
+
+// ToRuntimeAPIContainerState converts the docker container status string to
+// a runtime API container state.
+func ToRuntimeAPIContainerState(state string) runtimeapi.ContainerState {
+	// Parse the state string in dockertypes.Container. This could break when
+	// we upgrade docker.
+	switch {
+	case strings.HasPrefix(state, statusRunningPrefix):
+		return runtimeapi.ContainerState_CONTAINER_RUNNING
+	case strings.HasPrefix(state, statusExitedPrefix):
+		return runtimeapi.ContainerState_CONTAINER_EXITED
+	case strings.HasPrefix(state, statusCreatedPrefix):
+		return runtimeapi.ContainerState_CONTAINER_CREATED
+	default:
+		return runtimeapi.ContainerState_CONTAINER_UNKNOWN
+	}
+}
+
+// ToRuntimeAPISandboxState converts the docker container status string to a
+// runtime API sandbox state.
+func ToRuntimeAPISandboxState(state string) runtimeapi.PodSandboxState {
+	// Parse the state string in dockertypes.Container. This could break when
+	// we upgrade docker.
+	switch {
+	case strings.HasPrefix(state, statusRunningPrefix):
+		return runtimeapi.PodSandboxState_SANDBOX_READY
+	default:
+		return runtimeapi.PodSandboxState_SANDBOX_NOTREADY
+	}
+}
+
+// ContainerToRuntimeAPISandbox converts the docker container of a sandbox to
+// a runtime API sandbox.
+func ContainerToRuntimeAPISandbox(c *dockertypes.Container) (*runtimeapi.PodSandbox, error) {
+	state := ToRuntimeAPISandboxState(c.Status)
+	if len(c.Names) == 0 {
+		return nil, fmt.Errorf("unexpected empty sandbox name: %+v", c)
+	}
+	metadata, err := ParseSandboxName(c.Names[0])
+	if err != nil {
+		return nil, err
+	}
+	labels, annotations := ExtractLabels(c.Labels)
+	// The timestamp in dockertypes.Container is in seconds.
+	createdAt := c.Created * int64(time.Second)
+	return &runtimeapi.PodSandbox{
+		Id:          c.ID,
+		Metadata:    metadata,
+		State:       state,
+		CreatedAt:   createdAt,
+		Labels:      labels,
+		Annotations: annotations,
+	}, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/kubelet/types"
+)
+
+const (
+	// AnnotationPrefix is the prefix used to store CRI annotations as docker labels.
+	AnnotationPrefix = "annotation."
+
+	// Internal docker labels used to identify whether a container is a sandbox
+	// or a regular container.
+	ContainerTypeLabelKey       = "io.kubernetes.docker.type"
+	ContainerTypeLabelSandbox   = "podsandbox"
+	ContainerTypeLabelContainer = "container"
+	ContainerLogPathLabelKey    = "io.kubernetes.container.logpath"
+	SandboxIDLabelKey           = "io.kubernetes.sandbox.id"
+	// ImageDigestLabelKey records the repo digest the container's image
+	// resolved to when the container was created, pinning it against image
+	// removal.
+	ImageDigestLabelKey = "io.kubernetes.docker.image-digest"
+
+	// Audit labels record how and by whom a sandbox was created, so pod
+	// state can be reconstructed from docker state alone during post-mortem
+	// debugging.
+	AuditPodUIDLabelKey         = "io.kubernetes.docker.audit.pod-uid"
+	AuditAttemptLabelKey        = "io.kubernetes.docker.audit.attempt"
+	AuditKubeletIDLabelKey      = "io.kubernetes.docker.audit.kubelet-id"
+	AuditCreationReasonLabelKey = "io.kubernetes.docker.audit.creation-reason"
+)
+
+// InternalLabelKeys is the list of docker labels used internally by the shim.
+// These labels are filtered out when converting docker labels to CRI labels
+// and annotations.
+var InternalLabelKeys []string = []string{
+	ContainerTypeLabelKey,
+	ContainerLogPathLabelKey,
+	SandboxIDLabelKey,
+	ImageDigestLabelKey,
+	AuditPodUIDLabelKey,
+	AuditAttemptLabelKey,
+	AuditKubeletIDLabelKey,
+	AuditCreationReasonLabelKey,
+}
+
+// MakeLabels converts annotations to labels and merge them with the given
+// labels. This is necessary because docker does not support annotations;
+// we *fake* annotations using labels. Note that docker labels are not
+// updatable.
+func MakeLabels(labels, annotations map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range annotations {
+		// Assume there won't be conflict.
+		merged[fmt.Sprintf("%s%s", AnnotationPrefix, k)] = v
+	}
+	return merged
+}
+
+// ExtractLabels converts raw docker labels to the CRI labels and annotations.
+// It also filters out internal labels used by the shim.
+func ExtractLabels(input map[string]string) (map[string]string, map[string]string) {
+	labels := make(map[string]string)
+	annotations := make(map[string]string)
+	for k, v := range input {
+		// Check if the key is used internally by the shim.
+		internal := false
+		for _, internalKey := range InternalLabelKeys {
+			if k == internalKey {
+				internal = true
+				break
+			}
+		}
+		if internal {
+			continue
+		}
+
+		// Delete the container name label for the sandbox. It is added in the shim,
+		// should not be exposed via CRI.
+		if k == types.KubernetesContainerNameLabel &&
+			input[ContainerTypeLabelKey] == ContainerTypeLabelSandbox {
+			continue
+		}
+
+		// Check if the label should be treated as an annotation.
+		if strings.HasPrefix(k, AnnotationPrefix) {
+			annotations[strings.TrimPrefix(k, AnnotationPrefix)] = v
+			continue
+		}
+		labels[k] = v
+	}
+	return labels, annotations
+}
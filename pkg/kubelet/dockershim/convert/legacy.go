@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+	"k8s.io/kubernetes/pkg/kubelet/leaky"
+)
+
+// These are labels used by kuberuntime. Ideally, we should not rely on kuberuntime implementation
+// detail in dockershim. However, we need these labels for legacy container (containers created by
+// kubernetes 1.4 and 1.5) support.
+// TODO(random-liu): Remove this file and related code in kubernetes 1.8.
+const (
+	PodDeletionGracePeriodLabel    = "io.kubernetes.pod.deletionGracePeriod"
+	PodTerminationGracePeriodLabel = "io.kubernetes.pod.terminationGracePeriod"
+
+	ContainerHashLabel                     = "io.kubernetes.container.hash"
+	ContainerRestartCountLabel             = "io.kubernetes.container.restartCount"
+	ContainerTerminationMessagePathLabel   = "io.kubernetes.container.terminationMessagePath"
+	ContainerTerminationMessagePolicyLabel = "io.kubernetes.container.terminationMessagePolicy"
+	ContainerPreStopHandlerLabel           = "io.kubernetes.container.preStopHandler"
+	ContainerPortsLabel                    = "io.kubernetes.container.ports"
+)
+
+// ConvertLegacyNameAndLabels converts legacy name and labels into dockershim name and labels.
+// The function can be used to either legacy infra container or regular container.
+// NOTE that legacy infra container doesn't have restart count label, so the returned attempt for
+// sandbox will always be 0. The sandbox attempt is only used to generate new sandbox name, and
+// there is no naming conflict between legacy and new containers/sandboxes, so it should be fine.
+func ConvertLegacyNameAndLabels(names []string, labels map[string]string) ([]string, map[string]string, error) {
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("unexpected empty name")
+	}
+
+	// Generate new dockershim name.
+	m, _, err := dockertools.ParseDockerName(names[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	sandboxName, sandboxNamespace, err := kubecontainer.ParsePodFullName(m.PodFullName)
+	if err != nil {
+		return nil, nil, err
+	}
+	newNames := []string{strings.Join([]string{
+		KubePrefix,                         // 0
+		m.ContainerName,                    // 1: container name
+		sandboxName,                        // 2: sandbox name
+		sandboxNamespace,                   // 3: sandbox namesapce
+		string(m.PodUID),                   // 4: sandbox uid
+		labels[ContainerRestartCountLabel], // 5
+	}, nameDelimiter)}
+
+	// Generate new labels.
+	legacyAnnotations := sets.NewString(
+		ContainerHashLabel,
+		ContainerRestartCountLabel,
+		ContainerTerminationMessagePathLabel,
+		ContainerTerminationMessagePolicyLabel,
+		ContainerPreStopHandlerLabel,
+		ContainerPortsLabel,
+	)
+	newLabels := map[string]string{}
+	for k, v := range labels {
+		if legacyAnnotations.Has(k) {
+			// Add annotation prefix for all legacy labels which should be annotations in dockershim.
+			newLabels[fmt.Sprintf("%s%s", AnnotationPrefix, k)] = v
+		} else {
+			newLabels[k] = v
+		}
+	}
+	// Add ContainerTypeLabelKey indicating the container is sandbox or application container.
+	if m.ContainerName == leaky.PodInfraContainerName {
+		newLabels[ContainerTypeLabelKey] = ContainerTypeLabelSandbox
+	} else {
+		newLabels[ContainerTypeLabelKey] = ContainerTypeLabelContainer
+	}
+	return newNames, newLabels, nil
+}
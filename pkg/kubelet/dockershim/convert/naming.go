@@ -0,0 +1,150 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/leaky"
+)
+
+// Container "names" are implementation details that do not concern
+// kubelet/CRI. The docker CRI shim uses names to fulfill the CRI requirement
+// to make sandbox/container creation idempotent. CRI states that there can
+// only exist one sandbox/container with the given metadata. To enforce this,
+// the shim constructs a name using the fields in the metadata so that docker
+// will reject the creation request if the name already exists.
+//
+// The name format and the label keys in this package are part of the shim's
+// on-node state; external tooling relies on them to interpret docker state.
+// Changes here are backward incompatible and must be accompanied by
+// compatibility code in the shim.
+
+const (
+	// KubePrefix is used to identify the containers/sandboxes on the node managed by kubelet.
+	KubePrefix = "k8s"
+	// SandboxContainerName is a string to include in the docker container so
+	// that users can easily identify the sandboxes.
+	SandboxContainerName = leaky.PodInfraContainerName
+	// nameDelimiter is the delimiter used to construct docker container names.
+	nameDelimiter = "_"
+)
+
+// MakeSandboxName generates the docker container name for the sandbox of the
+// given config.
+func MakeSandboxName(s *runtimeapi.PodSandboxConfig) string {
+	return strings.Join([]string{
+		KubePrefix,                            // 0
+		SandboxContainerName,                  // 1
+		s.Metadata.Name,                       // 2
+		s.Metadata.Namespace,                  // 3
+		s.Metadata.Uid,                        // 4
+		fmt.Sprintf("%d", s.Metadata.Attempt), // 5
+	}, nameDelimiter)
+}
+
+// MakeContainerName generates the docker container name for a container in
+// the given sandbox.
+func MakeContainerName(s *runtimeapi.PodSandboxConfig, c *runtimeapi.ContainerConfig) string {
+	return strings.Join([]string{
+		KubePrefix,                            // 0
+		c.Metadata.Name,                       // 1:
+		s.Metadata.Name,                       // 2: sandbox name
+		s.Metadata.Namespace,                  // 3: sandbox namesapce
+		s.Metadata.Uid,                        // 4  sandbox uid
+		fmt.Sprintf("%d", c.Metadata.Attempt), // 5
+	}, nameDelimiter)
+}
+
+// RandomizeName randomizes the container name. This should only be used when
+// we hit the docker container name conflict bug.
+func RandomizeName(name string) string {
+	return strings.Join([]string{
+		name,
+		fmt.Sprintf("%08x", rand.Uint32()),
+	}, nameDelimiter)
+}
+
+func parseUint32(s string) (uint32, error) {
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(n), nil
+}
+
+// ParseSandboxName parses the docker container name of a sandbox into the
+// sandbox metadata.
+// TODO: Evaluate whether we should rely on labels completely.
+func ParseSandboxName(name string) (*runtimeapi.PodSandboxMetadata, error) {
+	// Docker adds a "/" prefix to names. so trim it.
+	name = strings.TrimPrefix(name, "/")
+
+	parts := strings.Split(name, nameDelimiter)
+	// Tolerate the random suffix.
+	// TODO(random-liu): Remove 7 field case when docker 1.11 is deprecated.
+	if len(parts) != 6 && len(parts) != 7 {
+		return nil, fmt.Errorf("failed to parse the sandbox name: %q", name)
+	}
+	if parts[0] != KubePrefix {
+		return nil, fmt.Errorf("container is not managed by kubernetes: %q", name)
+	}
+
+	attempt, err := parseUint32(parts[5])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the sandbox name %q: %v", name, err)
+	}
+
+	return &runtimeapi.PodSandboxMetadata{
+		Name:      parts[2],
+		Namespace: parts[3],
+		Uid:       parts[4],
+		Attempt:   attempt,
+	}, nil
+}
+
+// ParseContainerName parses the docker container name of an application
+// container into the container metadata.
+// TODO: Evaluate whether we should rely on labels completely.
+func ParseContainerName(name string) (*runtimeapi.ContainerMetadata, error) {
+	// Docker adds a "/" prefix to names. so trim it.
+	name = strings.TrimPrefix(name, "/")
+
+	parts := strings.Split(name, nameDelimiter)
+	// Tolerate the random suffix.
+	// TODO(random-liu): Remove 7 field case when docker 1.11 is deprecated.
+	if len(parts) != 6 && len(parts) != 7 {
+		return nil, fmt.Errorf("failed to parse the container name: %q", name)
+	}
+	if parts[0] != KubePrefix {
+		return nil, fmt.Errorf("container is not managed by kubernetes: %q", name)
+	}
+
+	attempt, err := parseUint32(parts[5])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the container name %q: %v", name, err)
+	}
+
+	return &runtimeapi.ContainerMetadata{
+		Name:    parts[1],
+		Attempt: attempt,
+	}, nil
+}
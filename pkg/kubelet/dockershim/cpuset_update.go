@@ -0,0 +1,135 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+)
+
+// The kubelet CPU manager pins containers to CPUs at runtime. The CRI
+// version in use has no cpuset fields, so the update arrives through the
+// DockerService extension interface and is validated against the node
+// topology before being handed to docker update.
+
+// numaNodeDir is where the kernel exposes the memory nodes of the machine.
+const numaNodeDir = "/sys/devices/system/node"
+
+// parseCPUList parses a cpuset list value ("0-3,8") into member indexes.
+func parseCPUList(value string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if bounds := strings.SplitN(part, "-", 2); len(bounds) == 2 {
+			low, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range start %q", bounds[0])
+			}
+			high, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range end %q", bounds[1])
+			}
+			if low < 0 || high < low {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+			for id := low; id <= high; id++ {
+				ids = append(ids, id)
+			}
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("invalid index %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// validateCPUList checks that the list only names indexes below limit.
+func validateCPUList(kind, value string, limit int) error {
+	ids, err := parseCPUList(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s list %q: %v", kind, value, err)
+	}
+	for _, id := range ids {
+		if id >= limit {
+			return fmt.Errorf("%s %d in %q is not present on this node (%d available)", kind, id, value, limit)
+		}
+	}
+	return nil
+}
+
+// numNUMANodes returns the number of memory nodes on the machine, assuming a
+// single node when the sysfs layout cannot be read.
+func numNUMANodes() int {
+	entries, err := ioutil.ReadDir(numaNodeDir)
+	if err != nil {
+		return 1
+	}
+	count := 0
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "node")); err == nil && strings.HasPrefix(entry.Name(), "node") {
+			count++
+		}
+	}
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+// UpdateContainerResources applies cpuset placement to a running container
+// via docker update. The requested CPUs and memory nodes are validated
+// against the node topology first, so a misprogrammed CPU manager yields a
+// clear error instead of an opaque docker failure.
+func (ds *dockerService) UpdateContainerResources(containerID, cpusetCpus, cpusetMems string) (err error) {
+	defer recordOperation(updateContainerResourcesOperation)(&err)
+	if cpusetCpus == "" && cpusetMems == "" {
+		return nil
+	}
+	if cpusetCpus != "" {
+		if err := validateCPUList("cpu", cpusetCpus, runtime.NumCPU()); err != nil {
+			return err
+		}
+	}
+	if cpusetMems != "" {
+		if err := validateCPUList("memory node", cpusetMems, numNUMANodes()); err != nil {
+			return err
+		}
+	}
+	r, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return err
+	}
+	if r.State == nil || !r.State.Running {
+		return fmt.Errorf("container %q is not running", containerID)
+	}
+	err = ds.client.UpdateContainerResources(containerID, dockercontainer.UpdateConfig{
+		Resources: dockercontainer.Resources{
+			CpusetCpus: cpusetCpus,
+			CpusetMems: cpusetMems,
+		},
+	})
+	ds.inspectCache.invalidate(containerID)
+	return err
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+func TestParseCPUList(t *testing.T) {
+	ids, err := parseCPUList("0-3,8")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 8}, ids)
+
+	ids, err = parseCPUList("2")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, ids)
+
+	for _, invalid := range []string{"", "a", "3-1", "-1", "0-", "1,,2"} {
+		_, err := parseCPUList(invalid)
+		assert.Error(t, err, "expected %q to be rejected", invalid)
+	}
+}
+
+func TestValidateCPUList(t *testing.T) {
+	assert.NoError(t, validateCPUList("cpu", "0-3", 4))
+	assert.Error(t, validateCPUList("cpu", "0-4", 4))
+	assert.Error(t, validateCPUList("cpu", "nope", 4))
+}
+
+func TestUpdateContainerResources(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	fDocker.SetFakeRunningContainers([]*dockertools.FakeContainer{{ID: "c1", Name: "container1", Running: true}})
+
+	// An empty update is a no-op.
+	assert.NoError(t, ds.UpdateContainerResources("c1", "", ""))
+
+	assert.NoError(t, ds.UpdateContainerResources("c1", "0", "0"))
+	r, err := fDocker.InspectContainer("c1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0", r.HostConfig.CpusetCpus)
+	assert.Equal(t, "0", r.HostConfig.CpusetMems)
+
+	// CPUs beyond the node topology are rejected.
+	assert.Error(t, ds.UpdateContainerResources("c1", "0-99999", ""))
+
+	// Only running containers can be updated.
+	fDocker.SetFakeContainers([]*dockertools.FakeContainer{{ID: "c2", Name: "container2", Running: false}})
+	assert.Error(t, ds.UpdateContainerResources("c2", "0", ""))
+}
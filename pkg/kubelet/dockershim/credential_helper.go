@@ -0,0 +1,173 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/golang/glog"
+
+	"k8s.io/client-go/util/clock"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+// This file invokes external credential helper binaries at image pull time,
+// so nodes can authenticate against registries without baking secrets into
+// pod specs or the kubelet configuration. Helpers speak the
+// docker-credential-* protocol: they get the registry on stdin and answer
+// with JSON credentials on stdout. The kubelet exec plugin field names
+// ("username"/"password") are accepted in the answer too.
+
+// credentialCacheTTL is how long a helper's answer is reused before the
+// helper is asked again. Registry tokens are typically valid much longer.
+const credentialCacheTTL = 10 * time.Minute
+
+// defaultRegistry is the registry implied by image names without one.
+const defaultRegistry = "index.docker.io"
+
+// credentialHelperRule maps registries to the helper binary that serves
+// them. The pattern is a registry host, or "*." followed by a domain to
+// match all its subdomains.
+type credentialHelperRule struct {
+	pattern string
+	helper  string
+}
+
+// matches returns whether the rule serves the registry.
+func (r *credentialHelperRule) matches(registry string) bool {
+	if strings.HasPrefix(r.pattern, "*.") {
+		return strings.HasSuffix(registry, r.pattern[1:])
+	}
+	return registry == r.pattern
+}
+
+// cachedCredentials is one helper answer with its expiry.
+type cachedCredentials struct {
+	auth      dockertypes.AuthConfig
+	expiresAt time.Time
+}
+
+// credentialHelpers resolves registry credentials through external helper
+// binaries, caching answers per registry.
+type credentialHelpers struct {
+	lock  sync.Mutex
+	rules []credentialHelperRule
+	exec  utilexec.Interface
+	clock clock.Clock
+	cache map[string]cachedCredentials
+}
+
+// newCredentialHelpers parses the helper configuration, comma-separated
+// "<registry>=<helper binary>" pairs (e.g.
+// "*.gcr.io=docker-credential-gcr,registry.example.com=example-helper").
+// An empty configuration yields no helpers.
+func newCredentialHelpers(config string) (*credentialHelpers, error) {
+	if config == "" {
+		return nil, nil
+	}
+	h := &credentialHelpers{
+		exec:  utilexec.New(),
+		clock: clock.RealClock{},
+		cache: make(map[string]cachedCredentials),
+	}
+	for _, entry := range strings.Split(config, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("credential helper rule %q must be \"<registry>=<helper>\"", entry)
+		}
+		h.rules = append(h.rules, credentialHelperRule{pattern: parts[0], helper: parts[1]})
+	}
+	return h, nil
+}
+
+// helperCredentials is the JSON document a helper answers with. The docker
+// credential helper protocol uses Username/Secret; the kubelet exec plugin
+// format uses username/password. ServerURL is accepted and ignored.
+type helperCredentials struct {
+	Username      string `json:"Username"`
+	Secret        string `json:"Secret"`
+	ExecUsername  string `json:"username"`
+	ExecPassword  string `json:"password"`
+	IdentityToken string `json:"IdentityToken"`
+}
+
+// get returns the credentials for the registry, invoking the matching
+// helper unless a cached answer is still fresh. Nil-safe; without helpers
+// (or when no rule matches, or the helper fails) it reports no credentials
+// and the pull proceeds anonymously.
+func (h *credentialHelpers) get(registry string) (dockertypes.AuthConfig, bool) {
+	if h == nil {
+		return dockertypes.AuthConfig{}, false
+	}
+	var helper string
+	for _, rule := range h.rules {
+		if rule.matches(registry) {
+			helper = rule.helper
+			break
+		}
+	}
+	if helper == "" {
+		return dockertypes.AuthConfig{}, false
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if cached, ok := h.cache[registry]; ok && h.clock.Now().Before(cached.expiresAt) {
+		return cached.auth, true
+	}
+	cmd := h.exec.Command(helper, "get")
+	cmd.SetStdin(strings.NewReader(registry))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		glog.Warningf("Credential helper %q failed for registry %q: %v, output: %q", helper, registry, err, string(out))
+		return dockertypes.AuthConfig{}, false
+	}
+	var creds helperCredentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		glog.Warningf("Credential helper %q answered with invalid JSON for registry %q: %v", helper, registry, err)
+		return dockertypes.AuthConfig{}, false
+	}
+	auth := dockertypes.AuthConfig{
+		Username:      creds.Username,
+		Password:      creds.Secret,
+		IdentityToken: creds.IdentityToken,
+		ServerAddress: registry,
+	}
+	if auth.Username == "" {
+		auth.Username = creds.ExecUsername
+		auth.Password = creds.ExecPassword
+	}
+	h.cache[registry] = cachedCredentials{auth: auth, expiresAt: h.clock.Now().Add(credentialCacheTTL)}
+	return auth, true
+}
+
+// imageRegistry returns the registry host an image would be pulled from.
+func imageRegistry(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0]
+	}
+	return defaultRegistry
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/util/clock"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+// TestNewCredentialHelpers tests the helper configuration parsing.
+func TestNewCredentialHelpers(t *testing.T) {
+	h, err := newCredentialHelpers("")
+	require.NoError(t, err)
+	assert.Nil(t, h)
+
+	h, err = newCredentialHelpers("*.gcr.io=docker-credential-gcr, registry.example.com=example-helper")
+	require.NoError(t, err)
+	require.Len(t, h.rules, 2)
+	assert.Equal(t, "*.gcr.io", h.rules[0].pattern)
+	assert.Equal(t, "docker-credential-gcr", h.rules[0].helper)
+
+	for _, config := range []string{"gcr.io", "=helper", "gcr.io="} {
+		_, err = newCredentialHelpers(config)
+		assert.Error(t, err, "config %q", config)
+	}
+}
+
+// TestImageRegistry tests the registry host extraction.
+func TestImageRegistry(t *testing.T) {
+	for image, registry := range map[string]string{
+		"busybox":                       defaultRegistry,
+		"library/busybox":               defaultRegistry,
+		"eu.gcr.io/proj/app:v1":         "eu.gcr.io",
+		"localhost/app":                 "localhost",
+		"registry.example.com:5000/app": "registry.example.com:5000",
+	} {
+		assert.Equal(t, registry, imageRegistry(image), "image %q", image)
+	}
+}
+
+// TestCredentialHelpersGet tests helper invocation, rule matching, and the
+// answer cache.
+func TestCredentialHelpersGet(t *testing.T) {
+	fcmd := utilexec.FakeCmd{CombinedOutputScript: []utilexec.FakeCombinedOutputAction{
+		func() ([]byte, error) { return []byte(`{"Username":"oauth2accesstoken","Secret":"token"}`), nil },
+		func() ([]byte, error) { return []byte(`{"username":"exec-user","password":"exec-pass"}`), nil },
+		func() ([]byte, error) { return nil, fmt.Errorf("helper exploded") },
+	}}
+	fexec := utilexec.FakeExec{CommandScript: []utilexec.FakeCommandAction{
+		func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(&fcmd, cmd, args...) },
+		func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(&fcmd, cmd, args...) },
+		func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(&fcmd, cmd, args...) },
+	}}
+	fakeClock := clock.NewFakeClock(time.Now())
+	h, err := newCredentialHelpers("*.gcr.io=docker-credential-gcr")
+	require.NoError(t, err)
+	h.exec = &fexec
+	h.clock = fakeClock
+
+	// Unmatched registries report no credentials without running anything.
+	_, ok := h.get("quay.io")
+	assert.False(t, ok)
+	assert.Equal(t, 0, fexec.CommandCalls)
+
+	auth, ok := h.get("eu.gcr.io")
+	require.True(t, ok)
+	assert.Equal(t, "oauth2accesstoken", auth.Username)
+	assert.Equal(t, "token", auth.Password)
+	assert.Equal(t, 1, fexec.CommandCalls)
+
+	// A fresh answer is served from the cache.
+	_, ok = h.get("eu.gcr.io")
+	assert.True(t, ok)
+	assert.Equal(t, 1, fexec.CommandCalls)
+
+	// Once the answer expires the helper is asked again; the exec plugin
+	// field names are accepted too.
+	fakeClock.Step(credentialCacheTTL + time.Minute)
+	auth, ok = h.get("eu.gcr.io")
+	require.True(t, ok)
+	assert.Equal(t, "exec-user", auth.Username)
+	assert.Equal(t, "exec-pass", auth.Password)
+	assert.Equal(t, 2, fexec.CommandCalls)
+
+	// A failing helper degrades to an anonymous pull.
+	fakeClock.Step(credentialCacheTTL + time.Minute)
+	_, ok = h.get("eu.gcr.io")
+	assert.False(t, ok)
+}
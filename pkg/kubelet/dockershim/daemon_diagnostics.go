@@ -0,0 +1,143 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/golang/glog"
+)
+
+// daemonConfigReadyCondition is reported through the runtime status and goes
+// false when the docker daemon's configuration disagrees with the shim's,
+// most notably on the cgroup driver. The kubelet surfaces it as a node
+// condition like the other runtime conditions.
+const daemonConfigReadyCondition = "DaemonConfigurationReady"
+
+// debugDaemonPath is the path of the node-local debug endpoint that dumps
+// docker daemon diagnostics.
+const debugDaemonPath = "/debug/daemon"
+
+// dockerDaemonConfigFile is where dockerd reads its configuration. The
+// docker API version in use does not report the live-restore state, so the
+// shim reads it from the daemon configuration directly.
+const dockerDaemonConfigFile = "/etc/docker/daemon.json"
+
+// daemonDiagnostics is the JSON document served on the daemon debug
+// endpoint.
+type daemonDiagnostics struct {
+	Version            string `json:"version"`
+	APIVersion         string `json:"apiVersion"`
+	StorageDriver      string `json:"storageDriver"`
+	CgroupDriver       string `json:"cgroupDriver"`
+	ShimCgroupDriver   string `json:"shimCgroupDriver"`
+	LiveRestoreEnabled bool   `json:"liveRestoreEnabled"`
+	// Degraded lists the messages of all runtime conditions that are
+	// currently false.
+	Degraded []string `json:"degraded,omitempty"`
+}
+
+// liveRestoreEnabledFromConfig reads the live-restore setting from a dockerd
+// configuration file. A missing or unreadable file counts as disabled.
+func liveRestoreEnabledFromConfig(path string) bool {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Warningf("Failed to read docker daemon config %q: %v", path, err)
+		}
+		return false
+	}
+	var config struct {
+		LiveRestore bool `json:"live-restore"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		glog.Warningf("Failed to parse docker daemon config %q: %v", path, err)
+		return false
+	}
+	return config.LiveRestore
+}
+
+// checkDaemonConfig compares the daemon's current configuration against the
+// one the shim was set up with and returns an error describing the first
+// mismatch. The cgroup driver is the one setting the daemon can change
+// underneath us (e.g. by a docker upgrade or a config edit plus live-restore)
+// that silently breaks every subsequent container start.
+func (ds *dockerService) checkDaemonConfig() error {
+	info, err := ds.client.Info()
+	if err != nil {
+		// The runtime condition already covers an unreachable daemon.
+		return nil
+	}
+	if len(info.CgroupDriver) != 0 && info.CgroupDriver != ds.cgroupDriver {
+		return fmt.Errorf("daemon reports cgroup driver %q but the shim was configured with %q", info.CgroupDriver, ds.cgroupDriver)
+	}
+	return nil
+}
+
+// collectDaemonDiagnostics gathers the daemon facts served on the debug
+// endpoint: version, storage and cgroup drivers, live-restore state, and the
+// messages of any runtime conditions that are currently false.
+func (ds *dockerService) collectDaemonDiagnostics() (*daemonDiagnostics, error) {
+	v, err := ds.getDockerVersion()
+	if err != nil {
+		return nil, err
+	}
+	info, err := ds.client.Info()
+	if err != nil {
+		return nil, err
+	}
+	d := &daemonDiagnostics{
+		Version:            v.Version,
+		APIVersion:         v.APIVersion,
+		StorageDriver:      info.Driver,
+		CgroupDriver:       info.CgroupDriver,
+		ShimCgroupDriver:   ds.cgroupDriver,
+		LiveRestoreEnabled: liveRestoreEnabledFromConfig(dockerDaemonConfigFile),
+	}
+	status, err := ds.Status()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range status.GetConditions() {
+		if !c.Status {
+			d.Degraded = append(d.Degraded, fmt.Sprintf("%s: %s", c.Type, c.Message))
+		}
+	}
+	return d, nil
+}
+
+// serveDebugDaemon dumps the docker daemon diagnostics. It is intended for
+// operators debugging a node whose runtime conditions went false.
+func (ds *dockerService) serveDebugDaemon(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	d, err := ds.collectDaemonDiagnostics()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to collect daemon diagnostics: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(d); err != nil {
+		glog.Errorf("Failed to encode daemon diagnostics: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
+)
+
+func TestLiveRestoreEnabledFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "daemon-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "daemon.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"live-restore": true}`), 0644))
+	assert.True(t, liveRestoreEnabledFromConfig(path))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"log-driver": "json-file"}`), 0644))
+	assert.False(t, liveRestoreEnabledFromConfig(path))
+
+	// Missing and malformed configs count as disabled.
+	assert.False(t, liveRestoreEnabledFromConfig(filepath.Join(dir, "missing.json")))
+	require.NoError(t, ioutil.WriteFile(path, []byte("not json"), 0644))
+	assert.False(t, liveRestoreEnabledFromConfig(path))
+}
+
+func TestCollectDaemonDiagnostics(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	ds.storageOptSupported = true
+	ds.cgroupDriver = "cgroupfs"
+	fDocker.Information = dockertypes.Info{
+		Driver:       "overlay2",
+		CgroupDriver: "systemd",
+		Runtimes:     map[string]dockertypes.Runtime{nvidiaRuntimeName: {}},
+	}
+	ds.os = &containertest.FakeOS{StatFn: func(string) (os.FileInfo, error) { return nil, nil }}
+
+	d, err := ds.collectDaemonDiagnostics()
+	require.NoError(t, err)
+	assert.Equal(t, "1.11.2", d.Version)
+	assert.Equal(t, "1.23.0", d.APIVersion)
+	assert.Equal(t, "overlay2", d.StorageDriver)
+	assert.Equal(t, "systemd", d.CgroupDriver)
+	assert.Equal(t, "cgroupfs", d.ShimCgroupDriver)
+	// The cgroup driver mismatch shows up as a degraded condition.
+	require.Len(t, d.Degraded, 1)
+	assert.Contains(t, d.Degraded[0], daemonConfigReadyCondition)
+	assert.Contains(t, d.Degraded[0], `cgroup driver "systemd"`)
+
+	// With the drivers in agreement nothing is degraded.
+	ds.cgroupDriver = "systemd"
+	d, err = ds.collectDaemonDiagnostics()
+	require.NoError(t, err)
+	assert.Empty(t, d.Degraded)
+}
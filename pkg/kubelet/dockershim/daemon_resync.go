@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+)
+
+// This file keeps the shim's bookkeeping consistent across docker daemon
+// restarts. With live-restore enabled the daemon comes back with every
+// container still running, but the shim's event stream was severed while it
+// was down: events are lost, cached pod IPs may be stale, and checkpoints
+// can be missing or orphaned. The shim re-inspects everything and patches up
+// its own state instead of restarting sandboxes, which is exactly what
+// live-restore is meant to avoid.
+
+// resyncMinInterval bounds how often a resync runs when the event stream
+// flaps, e.g. while the daemon is still coming up.
+const resyncMinInterval = 30 * time.Second
+
+// maybeResync runs a state resync unless one ran recently. It is invoked
+// whenever the docker event stream breaks, the shim's only signal that the
+// daemon restarted underneath it.
+func (ds *dockerService) maybeResync() {
+	ds.resyncLock.Lock()
+	if !ds.lastResync.IsZero() && time.Since(ds.lastResync) < resyncMinInterval {
+		ds.resyncLock.Unlock()
+		return
+	}
+	ds.lastResync = time.Now()
+	ds.resyncLock.Unlock()
+	ds.resyncState()
+}
+
+// resyncState reconciles the shim's own state with what docker reports.
+// Nothing is restarted: running sandboxes keep running, only the shim's
+// bookkeeping catches up.
+func (ds *dockerService) resyncState() {
+	glog.V(2).Infof("Resyncing dockershim state after the docker event stream broke")
+	// Pod IPs may have changed while events were lost; drop the cache so
+	// they are re-read from the network plugin.
+	ds.clearPodIPCache()
+	ds.restoreSandboxCheckpoints()
+	// Tear down the networks of sandboxes that disappeared while the daemon
+	// was down.
+	ds.reconcileNetworkResources()
+}
+
+// clearPodIPCache drops all cached pod IPs.
+func (ds *dockerService) clearPodIPCache() {
+	ds.podIPCacheLock.Lock()
+	defer ds.podIPCacheLock.Unlock()
+	ds.podIPCache = make(map[string]string)
+}
+
+// restoreSandboxCheckpoints recreates missing checkpoints for sandboxes that
+// are still alive. Only the pod's namespace and name can be recovered from
+// the container labels; the port mappings are gone, so host port bookkeeping
+// for such pods degrades to docker's own bind errors. Having the name back
+// is what matters: it is required to tear the pod's network down later.
+func (ds *dockerService) restoreSandboxCheckpoints() {
+	sandboxes, err := ds.ListPodSandbox(&runtimeapi.PodSandboxFilter{
+		State: &runtimeapi.PodSandboxStateValue{State: runtimeapi.PodSandboxState_SANDBOX_READY},
+	})
+	if err != nil {
+		glog.Errorf("Failed to list sandboxes for checkpoint restoration: %v", err)
+		return
+	}
+	for _, s := range sandboxes {
+		if _, err := ds.checkpointHandler.GetCheckpoint(s.Id); err != errors.CheckpointNotFoundError {
+			continue
+		}
+		meta := s.GetMetadata()
+		if meta == nil {
+			continue
+		}
+		if err := ds.checkpointHandler.CreateCheckpoint(s.Id, NewPodSandboxCheckpoint(meta.Namespace, meta.Name)); err != nil {
+			glog.Warningf("Failed to restore checkpoint of sandbox %q: %v", s.Id, err)
+			continue
+		}
+		glog.V(2).Infof("Restored checkpoint of sandbox %q for pod %s/%s", s.Id, meta.Namespace, meta.Name)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResyncState tests that a resync drops the pod IP cache and restores
+// missing sandbox checkpoints without touching the sandbox itself.
+func TestResyncState(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+
+	config := makeSandboxConfig("foo", "default", "1", 0)
+	id, err := ds.RunPodSandbox(config)
+	require.NoError(t, err)
+
+	// Simulate a checkpoint lost while the daemon was down.
+	require.NoError(t, ds.checkpointHandler.RemoveCheckpoint(id))
+	ds.cachePodIP(id, "10.0.0.5")
+
+	ds.resyncState()
+
+	// The IP cache is dropped and the checkpoint is back, carrying the pod's
+	// namespace and name.
+	_, cached := ds.getCachedPodIP(id)
+	assert.False(t, cached)
+	checkpoint, err := ds.checkpointHandler.GetCheckpoint(id)
+	require.NoError(t, err)
+	assert.Equal(t, "default", checkpoint.Namespace)
+	assert.Equal(t, "foo", checkpoint.Name)
+
+	// The sandbox itself was left alone.
+	r, err := fDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.True(t, r.State.Running)
+}
+
+// TestMaybeResyncRateLimit tests that back-to-back stream breaks trigger at
+// most one resync per interval.
+func TestMaybeResyncRateLimit(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.cachePodIP("sandbox", "10.0.0.5")
+
+	ds.lastResync = time.Now()
+	ds.maybeResync()
+	_, cached := ds.getCachedPodIP("sandbox")
+	assert.True(t, cached, "resync should have been skipped")
+
+	ds.lastResync = time.Now().Add(-2 * resyncMinInterval)
+	ds.maybeResync()
+	_, cached = ds.getCachedPodIP("sandbox")
+	assert.False(t, cached, "resync should have run")
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
@@ -45,11 +47,47 @@ type PortMapping struct {
 	ContainerPort *int32 `json:"container_port,omitempty"`
 	// Port number on the host.
 	HostPort *int32 `json:"host_port,omitempty"`
+	// Host IP (IPv4 or IPv6) the host port is bound to.
+	HostIp string `json:"host_ip,omitempty"`
+}
+
+// DNSConfig is the DNS resolver configuration of a sandbox.
+type DNSConfig struct {
+	// List of DNS servers of the cluster.
+	Servers []string `json:"servers,omitempty"`
+	// List of DNS search domains of the cluster.
+	Searches []string `json:"searches,omitempty"`
+	// List of DNS options.
+	Options []string `json:"options,omitempty"`
 }
 
 // CheckpointData contains all types of data that can be stored in the checkpoint.
 type CheckpointData struct {
 	PortMappings []*PortMapping `json:"port_mappings,omitempty"`
+	// DNS config of the sandbox.
+	DNSConfig *DNSConfig `json:"dns_config,omitempty"`
+	// Whether the sandbox shares the host's network namespace.
+	HostNetwork bool `json:"host_network,omitempty"`
+	// Cgroup parent of the sandbox.
+	CgroupParent string `json:"cgroup_parent,omitempty"`
+	// Annotations of the sandbox. These are needed to give the network
+	// plugin full context during teardown when the sandbox container is
+	// already gone (e.g. after a docker daemon loss).
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// RequestedIP is the static IP granted to the pod via its annotations.
+	// Recorded explicitly so the grant survives kubelet and docker restarts.
+	RequestedIP string `json:"requested_ip,omitempty"`
+	// NetNSPath is the stable path the sandbox's network namespace is
+	// bind-mounted at, so network teardown can still find the namespace
+	// after the sandbox container is gone.
+	NetNSPath string `json:"netns_path,omitempty"`
+	// Attempt is the sandbox attempt recorded at creation. It keeps the
+	// attempt reportable when the sandbox container (whose name carries the
+	// attempt) is already gone.
+	Attempt uint32 `json:"attempt,omitempty"`
+	// CreatedAt is when the checkpoint was created, recorded so checkpoints
+	// can be selected by age.
+	CreatedAt time.Time `json:"created_at,omitempty"`
 }
 
 // PodSandboxCheckpoint is the checkpoint structure for a sandbox
@@ -66,12 +104,45 @@ type PodSandboxCheckpoint struct {
 	CheckSum uint64 `json:"checksum"`
 }
 
+// CheckpointFilter selects checkpoints in GetCheckpoints. Only the set
+// fields constrain the result; a nil filter matches every checkpoint.
+type CheckpointFilter struct {
+	// Namespace matches checkpoints of pods in the namespace.
+	Namespace string
+	// Name matches checkpoints of pods with the name.
+	Name string
+	// MinAge matches checkpoints created at least this long ago. Checkpoints
+	// from before creation times were recorded always match.
+	MinAge time.Duration
+}
+
+// matches reports whether the checkpoint passes the filter.
+func (f *CheckpointFilter) matches(checkpoint *PodSandboxCheckpoint) bool {
+	if f == nil {
+		return true
+	}
+	if f.Namespace != "" && checkpoint.Namespace != f.Namespace {
+		return false
+	}
+	if f.Name != "" && checkpoint.Name != f.Name {
+		return false
+	}
+	if f.MinAge > 0 && checkpoint.Data != nil && !checkpoint.Data.CreatedAt.IsZero() &&
+		time.Since(checkpoint.Data.CreatedAt) < f.MinAge {
+		return false
+	}
+	return true
+}
+
 // CheckpointHandler provides the interface to manage PodSandbox checkpoint
 type CheckpointHandler interface {
 	// CreateCheckpoint persists sandbox checkpoint in CheckpointStore.
 	CreateCheckpoint(podSandboxID string, checkpoint *PodSandboxCheckpoint) error
 	// GetCheckpoint retrieves sandbox checkpoint from CheckpointStore.
 	GetCheckpoint(podSandboxID string) (*PodSandboxCheckpoint, error)
+	// GetCheckpoints bulk-loads the checkpoints matching the filter, keyed
+	// by pod sandbox ID.
+	GetCheckpoints(filter *CheckpointFilter) (map[string]*PodSandboxCheckpoint, error)
 	// RemoveCheckpoint removes sandbox checkpoint form CheckpointStore.
 	// WARNING: RemoveCheckpoint will not return error if checkpoint does not exist.
 	RemoveCheckpoint(podSandboxID string) error
@@ -82,10 +153,18 @@ type CheckpointHandler interface {
 // PersistentCheckpointHandler is an implementation of CheckpointHandler. It persists checkpoint in CheckpointStore
 type PersistentCheckpointHandler struct {
 	store CheckpointStore
+	// lock guards cache. Checkpoints are written once and never updated, so
+	// the decoded form can be cached until the checkpoint is removed. The
+	// store stays the source of truth for enumeration.
+	lock  sync.Mutex
+	cache map[string]*PodSandboxCheckpoint
 }
 
 func NewPersistentCheckpointHandler() CheckpointHandler {
-	return &PersistentCheckpointHandler{store: &FileStore{path: filepath.Join(dockershimRootDir, sandboxCheckpointDir)}}
+	return &PersistentCheckpointHandler{
+		store: &FileStore{path: filepath.Join(dockershimRootDir, sandboxCheckpointDir)},
+		cache: map[string]*PodSandboxCheckpoint{},
+	}
 }
 
 func (handler *PersistentCheckpointHandler) CreateCheckpoint(podSandboxID string, checkpoint *PodSandboxCheckpoint) error {
@@ -94,10 +173,22 @@ func (handler *PersistentCheckpointHandler) CreateCheckpoint(podSandboxID string
 	if err != nil {
 		return err
 	}
-	return handler.store.Write(podSandboxID, blob)
+	if err := handler.store.Write(podSandboxID, blob); err != nil {
+		return err
+	}
+	handler.lock.Lock()
+	handler.cache[podSandboxID] = checkpoint
+	handler.lock.Unlock()
+	return nil
 }
 
 func (handler *PersistentCheckpointHandler) GetCheckpoint(podSandboxID string) (*PodSandboxCheckpoint, error) {
+	handler.lock.Lock()
+	if checkpoint, ok := handler.cache[podSandboxID]; ok {
+		handler.lock.Unlock()
+		return checkpoint, nil
+	}
+	handler.lock.Unlock()
 	blob, err := handler.store.Read(podSandboxID)
 	if err != nil {
 		return nil, err
@@ -113,10 +204,47 @@ func (handler *PersistentCheckpointHandler) GetCheckpoint(podSandboxID string) (
 		glog.Errorf("Checksum of checkpoint %q is not valid", podSandboxID)
 		return &checkpoint, errors.CorruptCheckpointError
 	}
+	handler.lock.Lock()
+	handler.cache[podSandboxID] = &checkpoint
+	handler.lock.Unlock()
 	return &checkpoint, nil
 }
 
+// GetCheckpoints bulk-loads the checkpoints matching the filter. Decoded
+// checkpoints are served from memory, so list paths do not pay one disk
+// read per checkpoint on every call. Corrupt checkpoints cannot be acted on
+// and are removed along the way.
+func (handler *PersistentCheckpointHandler) GetCheckpoints(filter *CheckpointFilter) (map[string]*PodSandboxCheckpoint, error) {
+	keys, err := handler.store.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoint store: %v", err)
+	}
+	checkpoints := map[string]*PodSandboxCheckpoint{}
+	for _, id := range keys {
+		checkpoint, err := handler.GetCheckpoint(id)
+		if err == errors.CorruptCheckpointError {
+			glog.Warningf("Removing corrupt checkpoint %q", id)
+			handler.RemoveCheckpoint(id)
+			continue
+		}
+		if err != nil {
+			// The checkpoint was removed between listing and loading.
+			if err != errors.CheckpointNotFoundError {
+				glog.Warningf("Failed to load checkpoint %q: %v", id, err)
+			}
+			continue
+		}
+		if filter.matches(checkpoint) {
+			checkpoints[id] = checkpoint
+		}
+	}
+	return checkpoints, nil
+}
+
 func (handler *PersistentCheckpointHandler) RemoveCheckpoint(podSandboxID string) error {
+	handler.lock.Lock()
+	delete(handler.cache, podSandboxID)
+	handler.lock.Unlock()
 	return handler.store.Delete(podSandboxID)
 }
 
@@ -133,7 +261,7 @@ func NewPodSandboxCheckpoint(namespace, name string) *PodSandboxCheckpoint {
 		Version:   schemaVersion,
 		Namespace: namespace,
 		Name:      name,
-		Data:      &CheckpointData{},
+		Data:      &CheckpointData{CreatedAt: time.Now().UTC()},
 	}
 }
 
@@ -19,13 +19,14 @@ package dockershim
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	utilstore "k8s.io/kubernetes/pkg/kubelet/dockershim/testing"
 )
 
 func NewTestPersistentCheckpointHandler() CheckpointHandler {
-	return &PersistentCheckpointHandler{store: utilstore.NewMemStore()}
+	return &PersistentCheckpointHandler{store: utilstore.NewMemStore(), cache: map[string]*PodSandboxCheckpoint{}}
 }
 
 func TestPersistentCheckpointHandler(t *testing.T) {
@@ -38,14 +39,14 @@ func TestPersistentCheckpointHandler(t *testing.T) {
 	checkpoint1 := NewPodSandboxCheckpoint("ns1", "sandbox1")
 	checkpoint1.Data.PortMappings = []*PortMapping{
 		{
-			&proto,
-			&port80,
-			&port80,
+			Protocol:      &proto,
+			ContainerPort: &port80,
+			HostPort:      &port80,
 		},
 		{
-			&proto,
-			&port443,
-			&port443,
+			Protocol:      &proto,
+			ContainerPort: &port443,
+			HostPort:      &port443,
 		},
 	}
 
@@ -95,3 +96,59 @@ func TestPersistentCheckpointHandler(t *testing.T) {
 	_, err = handler.GetCheckpoint("id1")
 	assert.Error(t, err)
 }
+
+// TestGetCheckpoints tests bulk loading with namespace, name and age filters.
+func TestGetCheckpoints(t *testing.T) {
+	handler := NewTestPersistentCheckpointHandler()
+	assert.NoError(t, handler.CreateCheckpoint("id1", NewPodSandboxCheckpoint("ns1", "sandbox1")))
+	assert.NoError(t, handler.CreateCheckpoint("id2", NewPodSandboxCheckpoint("ns2", "sandbox2")))
+	old := NewPodSandboxCheckpoint("ns2", "old")
+	old.Data.CreatedAt = time.Now().Add(-time.Hour).UTC()
+	assert.NoError(t, handler.CreateCheckpoint("id3", old))
+
+	// A nil filter matches everything.
+	checkpoints, err := handler.GetCheckpoints(nil)
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 3)
+	assert.Equal(t, "sandbox1", checkpoints["id1"].Name)
+
+	// Namespace and name filters.
+	checkpoints, err = handler.GetCheckpoints(&CheckpointFilter{Namespace: "ns2"})
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 2)
+	checkpoints, err = handler.GetCheckpoints(&CheckpointFilter{Namespace: "ns2", Name: "old"})
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 1)
+	assert.Contains(t, checkpoints, "id3")
+
+	// The age filter only matches checkpoints old enough.
+	checkpoints, err = handler.GetCheckpoints(&CheckpointFilter{MinAge: 30 * time.Minute})
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 1)
+	assert.Contains(t, checkpoints, "id3")
+
+	// Removed checkpoints disappear from the bulk view.
+	assert.NoError(t, handler.RemoveCheckpoint("id1"))
+	checkpoints, err = handler.GetCheckpoints(nil)
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 2)
+}
+
+// TestCheckpointCache tests that decoded checkpoints are served from memory
+// once loaded, and evicted on removal.
+func TestCheckpointCache(t *testing.T) {
+	store := utilstore.NewMemStore()
+	handler := &PersistentCheckpointHandler{store: store, cache: map[string]*PodSandboxCheckpoint{}}
+	assert.NoError(t, handler.CreateCheckpoint("id1", NewPodSandboxCheckpoint("ns1", "sandbox1")))
+
+	// Removing the backing record does not invalidate the cached entry...
+	assert.NoError(t, store.Delete("id1"))
+	checkpoint, err := handler.GetCheckpoint("id1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sandbox1", checkpoint.Name)
+
+	// ...but RemoveCheckpoint does.
+	assert.NoError(t, handler.RemoveCheckpoint("id1"))
+	_, err = handler.GetCheckpoint("id1")
+	assert.Error(t, err)
+}
@@ -28,13 +28,25 @@ import (
 	dockerstrslice "github.com/docker/engine-api/types/strslice"
 	"github.com/golang/glog"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 )
 
+// ListContainersPaged is ListContainers with limit/continue pagination.
+func (ds *dockerService) ListContainersPaged(filter *runtimeapi.ContainerFilter, limit int32, continueToken string) ([]*runtimeapi.Container, string, error) {
+	containers, err := ds.ListContainers(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	page, next := paginateContainers(containers, limit, continueToken)
+	return page, next, nil
+}
+
 // ListContainers lists all containers matching the filter.
 func (ds *dockerService) ListContainers(filter *runtimeapi.ContainerFilter) ([]*runtimeapi.Container, error) {
 	opts := dockertypes.ContainerListOptions{All: true}
+	var indexedIDs sets.String
 
 	opts.Filter = dockerfilters.NewArgs()
 	f := newDockerFilter(&opts.Filter)
@@ -53,8 +65,14 @@ func (ds *dockerService) ListContainers(filter *runtimeapi.ContainerFilter) ([]*
 		}
 
 		if filter.LabelSelector != nil {
-			for k, v := range filter.LabelSelector {
-				f.AddLabel(k, v)
+			// Serve the label selector from the in-memory index when it is
+			// populated; docker's label filter scans every container.
+			if ids, ok := ds.labels.match(filter.LabelSelector); ok {
+				indexedIDs = ids
+			} else {
+				for k, v := range filter.LabelSelector {
+					f.AddLabel(k, v)
+				}
 			}
 		}
 	}
@@ -66,7 +84,9 @@ func (ds *dockerService) ListContainers(filter *runtimeapi.ContainerFilter) ([]*
 	result := []*runtimeapi.Container{}
 	for i := range containers {
 		c := containers[i]
-
+		if indexedIDs != nil && !indexedIDs.Has(c.ID) {
+			continue
+		}
 		converted, err := toRuntimeAPIContainer(&c)
 		if err != nil {
 			glog.V(4).Infof("Unable to convert docker to runtime API container: %v", err)
@@ -87,18 +107,11 @@ func (ds *dockerService) ListContainers(filter *runtimeapi.ContainerFilter) ([]*
 	return result, nil
 }
 
-// CreateContainer creates a new container in the given PodSandbox
-// Docker cannot store the log to an arbitrary location (yet), so we create an
-// symlink at LogPath, linking to the actual path of the log.
-// TODO: check if the default values returned by the runtime API are ok.
-func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) (string, error) {
-	if config == nil {
-		return "", fmt.Errorf("container config is nil")
-	}
-	if sandboxConfig == nil {
-		return "", fmt.Errorf("sandbox config is nil for container %q", config.Metadata.Name)
-	}
-
+// makeContainerDockerConfig computes the docker creation config for a
+// workload container: labels, security options, mounts, resources, and the
+// rest of the translation from the CRI container config. It performs only
+// read-only docker queries, so the dry-run path can share it.
+func (ds *dockerService) makeContainerDockerConfig(podSandboxID string, config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) (*dockertypes.ContainerCreateConfig, error) {
 	labels := makeLabels(config.GetLabels(), config.GetAnnotations())
 	// Apply a the container type label.
 	labels[containerTypeLabelKey] = containerTypeLabelContainer
@@ -109,7 +122,7 @@ func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi
 
 	apiVersion, err := ds.getDockerAPIVersion()
 	if err != nil {
-		return "", fmt.Errorf("unable to get the docker API version: %v", err)
+		return nil, fmt.Errorf("unable to get the docker API version: %v", err)
 	}
 	securityOptSep := getSecurityOptSeparator(apiVersion)
 
@@ -117,6 +130,11 @@ func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi
 	if iSpec := config.GetImage(); iSpec != nil {
 		image = iSpec.Image
 	}
+	// Record the digest the image resolves to, so the image service can tell
+	// which digests are still pinned by existing containers.
+	if digest := ds.resolveImageDigest(image); digest != "" {
+		labels[imageDigestLabelKey] = digest
+	}
 	createConfig := dockertypes.ContainerCreateConfig{
 		Name: makeContainerName(sandboxConfig, config),
 		Config: &dockercontainer.Config{
@@ -134,9 +152,26 @@ func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi
 		},
 	}
 
+	// Keep SELinux labels consistent within the pod: emptyDir-backed
+	// mounts are relabeled to the pod's label when one is pinned, and
+	// containers without their own SELinux options inherit the pod-level
+	// ones below (after the security context has been applied).
+	containerSELinux := config.GetLinux().GetSecurityContext().GetSelinuxOptions()
+	podSELinux := podSELinuxOptions(sandboxConfig)
+	if containerSELinux != nil || podSELinux != nil {
+		relabelEmptyDirMounts(config.GetMounts())
+	}
+
 	// Fill the HostConfig.
+	propagation, err := parseMountPropagation(sandboxConfig.GetAnnotations())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mount propagation for container %q: %v", config.Metadata.Name, err)
+	}
+	if err := ds.validateMountPropagation(propagation); err != nil {
+		return nil, err
+	}
 	hc := &dockercontainer.HostConfig{
-		Binds: generateMountBindings(config.GetMounts()),
+		Binds: generateMountBindings(config.GetMounts(), propagation),
 	}
 
 	// Apply Linux-specific options if applicable.
@@ -153,12 +188,39 @@ func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi
 				CPUQuota:   rOpts.CpuQuota,
 				CPUPeriod:  rOpts.CpuPeriod,
 			}
-			hc.OomScoreAdj = int(rOpts.OomScoreAdj)
 		}
+		// The OOM policy starts from the QoS-derived score carried in the
+		// container config.
+		hc.OomScoreAdj = ds.oomPolicy.containerOOMScoreAdj(config, sandboxConfig)
 		// Note: ShmSize is handled in kube_docker_client.go
 
 		// Apply security context.
 		applyContainerSecurityContext(lc, podSandboxID, createConfig.Config, hc, securityOptSep)
+
+		// Join the sandbox's PID namespace when the pod asked for a shared
+		// PID namespace, so processes in the pod can see and signal each
+		// other. Explicit host PID requests take precedence. When the node
+		// cannot satisfy the request it is rejected outright, so the kubelet
+		// reports a clear event instead of silently giving the container a
+		// private PID namespace.
+		if nsOpts := lc.GetSecurityContext().GetNamespaceOptions(); nsOpts != nil && nsOpts.SharePid && !nsOpts.HostPid {
+			if err := ds.verifySharedPIDSupport(apiVersion); err != nil {
+				return nil, err
+			}
+			hc.PidMode = dockercontainer.PidMode(fmt.Sprintf("container:%v", podSandboxID))
+		}
+	}
+
+	// Containers without their own SELinux options run with the pod-level
+	// label, so every container in the pod (and the sandbox) shares the
+	// same MCS label.
+	if containerSELinux == nil {
+		applySELinuxOptions(hc, podSELinux, securityOptSep)
+	}
+
+	// Apply the pod's swap policy on top of the default swap setting.
+	if err := applySwapPolicy(sandboxConfig.GetAnnotations(), &hc.Resources); err != nil {
+		return nil, fmt.Errorf("failed to apply swap policy for container %q: %v", config.Metadata.Name, err)
 	}
 
 	// Apply cgroupsParent derived from the sandbox config.
@@ -166,36 +228,140 @@ func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi
 		// Apply Cgroup options.
 		cgroupParent, err := ds.GenerateExpectedCgroupParent(lc.CgroupParent)
 		if err != nil {
-			return "", fmt.Errorf("failed to generate cgroup parent in expected syntax for container %q: %v", config.Metadata.Name, err)
+			return nil, fmt.Errorf("failed to generate cgroup parent in expected syntax for container %q: %v", config.Metadata.Name, err)
 		}
 		hc.CgroupParent = cgroupParent
 	}
 
 	// Set devices for container.
-	devices := make([]dockercontainer.DeviceMapping, len(config.Devices))
-	for i, device := range config.Devices {
-		devices[i] = dockercontainer.DeviceMapping{
-			PathOnHost:        device.HostPath,
-			PathInContainer:   device.ContainerPath,
-			CgroupPermissions: device.Permissions,
-		}
+	devices, err := generateDeviceMappings(config.Devices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate device mappings for container %q: %v", config.Metadata.Name, err)
 	}
 	hc.Resources.Devices = devices
 
-	// Apply appArmor and seccomp options.
-	securityOpts, err := getContainerSecurityOpts(config.Metadata.Name, sandboxConfig, ds.seccompProfileRoot, securityOptSep)
+	// Apply the user namespace mode and, when the container stays in the
+	// remapped namespace, translate volume ownership for the remapped root.
+	ds.applyUserNamespaceMode(sandboxConfig.GetLinux().GetSecurityContext().GetNamespaceOptions(), hc)
+	ds.translateHostPathOwnership(config.GetMounts(), hc)
+
+	// Apply the default security profile and the appArmor and seccomp
+	// options. Privileged containers are exempt from the profile.
+	profile := ds.securityProfile
+	if hc.Privileged {
+		profile = nil
+	}
+	defaultSeccomp := ds.namespacePolicies.seccompProfile(sandboxConfig.GetMetadata().Namespace)
+	securityOpts, err := getContainerSecurityOpts(config.Metadata.Name, sandboxConfig, ds.seccompProfiles, securityOptSep, profile, defaultSeccomp)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate container security options for container %q: %v", config.Metadata.Name, err)
+		return nil, fmt.Errorf("failed to generate container security options for container %q: %v", config.Metadata.Name, err)
 	}
 	hc.SecurityOpt = append(hc.SecurityOpt, securityOpts...)
+	profile.applyHostConfig(hc)
+
+	// Run the container with the same OCI runtime as its sandbox. The
+	// runtime was already validated when the sandbox was created.
+	hc.Runtime = ds.sandboxRuntimeFor(sandboxConfig)
+
+	// Run the container under docker's init process when the pod or the
+	// node asks for it, so zombies are reaped for images without one.
+	initProcess, err := ds.containerInitFor(sandboxConfig.GetAnnotations())
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine init process for container %q: %v", config.Metadata.Name, err)
+	}
+	hc.Init = initProcess
+
+	// Enforce the log rotation policy via docker's json-file driver.
+	if ds.featureGate.Enabled(containerLogRotationFeature) {
+		logConfig, err := containerLogConfig(sandboxConfig.GetAnnotations())
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate log config for container %q: %v", config.Metadata.Name, err)
+		}
+		hc.LogConfig = logConfig
+	}
+
+	// Translate the pod's ephemeral storage limit into a per-container
+	// storage size constraint, where the graph driver can enforce it.
+	storageOpts, err := ds.containerStorageOpts(sandboxConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate storage options for container %q: %v", config.Metadata.Name, err)
+	}
+	if len(storageOpts) > 0 {
+		hc.StorageOpt = storageOpts
+	}
+
+	// Apply the allowlisted hostconfig extension annotations.
+	if err := ds.hostConfigExtensions.applyContainer(sandboxConfig, hc); err != nil {
+		return nil, fmt.Errorf("failed to apply hostconfig extensions for container %q: %v", config.Metadata.Name, err)
+	}
+
+	// A ulimits annotation on the container itself overrides the pod-level
+	// one, and the node caps bound both.
+	if value, ok := config.GetAnnotations()[ulimitsAnnotationKey]; ok {
+		if !ds.hostConfigExtensions.enabled(ulimitsExtension) {
+			return nil, fmt.Errorf("annotation %q requires the %q dockershim hostconfig extension, which is not enabled on this node", ulimitsAnnotationKey, ulimitsExtension)
+		}
+		ulimits, err := parseUlimits(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for annotation %q: %v", value, ulimitsAnnotationKey, err)
+		}
+		hc.Ulimits = ulimits
+	}
+	if err := ds.ulimitCaps.enforce(hc.Ulimits); err != nil {
+		return nil, fmt.Errorf("rejecting container %q: %v", config.Metadata.Name, err)
+	}
+
+	// Validate the lifecycle hook annotations up front, so a malformed hook
+	// fails the container at creation instead of at start or stop time.
+	for _, key := range []string{postStartExecAnnotationKey, preStopExecAnnotationKey} {
+		if _, err := hookCommand(config.GetAnnotations(), key); err != nil {
+			return nil, fmt.Errorf("rejecting container %q: %v", config.Metadata.Name, err)
+		}
+	}
 
 	createConfig.HostConfig = hc
-	createResp, err := ds.client.CreateContainer(createConfig)
+	return &createConfig, nil
+}
+
+// CreateContainer creates a new container in the given PodSandbox
+// Docker cannot store the log to an arbitrary location (yet), so we create an
+// symlink at LogPath, linking to the actual path of the log.
+// TODO: check if the default values returned by the runtime API are ok.
+func (ds *dockerService) CreateContainer(podSandboxID string, config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) (id string, err error) {
+	defer recordOperation(createContainerOperation)(&err)
+
+	if config == nil {
+		return "", fmt.Errorf("container config is nil")
+	}
+	if sandboxConfig == nil {
+		return "", fmt.Errorf("sandbox config is nil for container %q", config.Metadata.Name)
+	}
+	trace := ds.newOpTrace("CreateContainer",
+		"pod", fmt.Sprintf("%s/%s", sandboxConfig.GetMetadata().Namespace, sandboxConfig.GetMetadata().Name),
+		"container", config.Metadata.Name,
+		"sandbox", podSandboxID)
+
+	createConfig, err := ds.makeContainerDockerConfig(podSandboxID, config, sandboxConfig)
+	if err != nil {
+		return "", err
+	}
+
+	// The image policy gets the last word before the container exists.
+	if err := ds.verifyContainerImage(createConfig.Config.Image); err != nil {
+		return "", err
+	}
+
+	createResp, err := ds.client.CreateContainer(*createConfig)
 	if err != nil {
-		createResp, err = recoverFromCreationConflictIfNeeded(ds.client, createConfig, err)
+		createResp, err = recoverFromCreationConflictIfNeeded(ds.client, *createConfig, err)
 	}
 
 	if createResp != nil {
+		if err == nil {
+			trace.step("created container", "id", createResp.ID)
+			// The image was just used; image GC keys off this.
+			ds.imageUsage.recordUse(createConfig.Config.Image)
+		}
 		return createResp.ID, err
 	}
 	return "", err
@@ -218,6 +384,11 @@ func (ds *dockerService) createContainerLogSymlink(containerID string) error {
 		return fmt.Errorf("failed to get container %q log path: %v", containerID, err)
 	}
 	if path != "" {
+		if realPath == "" {
+			// Docker only reports a log path for the json-file log driver.
+			glog.Warningf("Container %q has no json log file; the CRI log path %q will not be created", containerID, path)
+			return nil
+		}
 		// Only create the symlink when container log path is specified.
 		if err = ds.os.Symlink(realPath, path); err != nil {
 			return fmt.Errorf("failed to create symbolic link %q to the container log file %q for container %q: %v",
@@ -246,9 +417,29 @@ func (ds *dockerService) removeContainerLogSymlink(containerID string) error {
 // StartContainer starts the container.
 func (ds *dockerService) StartContainer(containerID string) error {
 	err := ds.client.StartContainer(containerID)
+	ds.inspectCache.invalidate(containerID)
 	if err != nil {
 		return fmt.Errorf("failed to start container %q: %v", containerID, err)
 	}
+	// Apply the pod's hugetlb limits now that the container cgroup exists.
+	if err := ds.setUpContainerHugetlbLimits(containerID); err != nil {
+		return fmt.Errorf("failed to set hugetlb limits for container %q: %v", containerID, err)
+	}
+	// On cgroup v2 nodes docker cannot enforce the v1-style resource
+	// settings; translate and apply them to the container cgroup directly.
+	if err := ds.setUpContainerCgroupV2Resources(containerID); err != nil {
+		return fmt.Errorf("failed to apply cgroup v2 resources for container %q: %v", containerID, err)
+	}
+	// Run the container's postStart hook before reporting the start as
+	// successful. A failed hook stops the container, matching the pod
+	// lifecycle contract.
+	if err := ds.runPostStartHook(containerID); err != nil {
+		if stopErr := ds.client.StopContainer(containerID, 0); stopErr != nil {
+			glog.Errorf("Failed to stop container %q after its postStart hook failed: %v", containerID, stopErr)
+		}
+		ds.inspectCache.invalidate(containerID)
+		return fmt.Errorf("failed to run postStart hook for container %q: %v", containerID, err)
+	}
 	// Create container log symlink.
 	if err := ds.createContainerLogSymlink(containerID); err != nil {
 		// Do not stop the container if we failed to create symlink because:
@@ -262,7 +453,22 @@ func (ds *dockerService) StartContainer(containerID string) error {
 
 // StopContainer stops a running container with a grace period (i.e., timeout).
 func (ds *dockerService) StopContainer(containerID string, timeout int64) error {
-	return ds.client.StopContainer(containerID, int(timeout))
+	// The preStop hook runs before the stop signal is sent, bounded by the
+	// grace period; its failure never blocks the stop. The hook spends from
+	// the same grace budget as the stop itself, so subtract its elapsed time
+	// from the docker stop timeout — otherwise a slow hook doubles the
+	// worst-case stop duration.
+	start := time.Now()
+	ds.runPreStopHook(containerID, timeout)
+	if remaining := timeout - int64(time.Since(start).Seconds()); remaining < timeout {
+		timeout = remaining
+		if timeout < 0 {
+			timeout = 0
+		}
+	}
+	err := ds.client.StopContainer(containerID, int(timeout))
+	ds.inspectCache.invalidate(containerID)
+	return err
 }
 
 // RemoveContainer removes the container.
@@ -276,9 +482,11 @@ func (ds *dockerService) RemoveContainer(containerID string) error {
 		return err
 	}
 	err = ds.client.RemoveContainer(containerID, dockertypes.ContainerRemoveOptions{RemoveVolumes: true})
+	ds.inspectCache.invalidate(containerID)
 	if err != nil {
 		return fmt.Errorf("failed to remove container %q: %v", containerID, err)
 	}
+	ds.lifecycleHooks.remove(containerID)
 	return nil
 }
 
@@ -371,6 +579,19 @@ func (ds *dockerService) ContainerStatus(containerID string) (*runtimeapi.Contai
 		message = r.State.Error
 	}
 
+	// Surface a failed lifecycle hook through the status, so the outcome is
+	// visible without digging through the kubelet log.
+	if result := ds.lifecycleHooks.result(containerID); result != nil && result.Error != "" {
+		if reason == "" {
+			reason = fmt.Sprintf("Failed%sHook", result.Hook)
+		}
+		if message == "" {
+			message = result.Error
+		} else {
+			message = fmt.Sprintf("%s; %s", message, result.Error)
+		}
+	}
+
 	// Convert to unix timestamps.
 	ct, st, ft := createdAt.UnixNano(), startedAt.UnixNano(), finishedAt.UnixNano()
 	exitCode := int32(r.State.ExitCode)
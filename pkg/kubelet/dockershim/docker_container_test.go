@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
 	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
@@ -217,6 +218,52 @@ func TestContainerLogPath(t *testing.T) {
 	assert.Equal(t, fakeOS.Removes, []string{kubeletContainerLogPath})
 }
 
+// TestCreateContainerSharedPID verifies that containers join the sandbox's
+// PID namespace when the pod requests it and the feature gate is enabled.
+func TestCreateContainerSharedPID(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	fDocker.WithVersion("1.12.1", "1.24")
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c", "busybox", 0, nil, nil)
+	config.Linux = &runtimeapi.LinuxContainerConfig{
+		SecurityContext: &runtimeapi.LinuxContainerSecurityContext{
+			NamespaceOptions: &runtimeapi.NamespaceOption{SharePid: true},
+		},
+	}
+
+	// With the feature gate off the request is rejected with a typed error,
+	// so the kubelet can emit a clear pod event.
+	_, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.True(t, IsUnsupportedNamespaceError(err))
+	require.NoError(t, ds.featureGate.set(fmt.Sprintf("%s=true", sharedPIDNamespaceFeature)))
+
+	// The same holds on a docker API version that cannot join another
+	// container's PID namespace.
+	fDocker.WithVersion("1.11.2", "1.23")
+	_, err = ds.CreateContainer("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.True(t, IsUnsupportedNamespaceError(err))
+	fDocker.WithVersion("1.12.1", "1.24")
+
+	// With the gate on the container joins the sandbox's PID namespace.
+	config.Metadata.Attempt = 1
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	c, err := fDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.Equal(t, "container:sandboxid", string(c.HostConfig.PidMode))
+
+	// An explicit host PID request takes precedence over sharing.
+	config.Linux.SecurityContext.NamespaceOptions.HostPid = true
+	config.Metadata.Attempt = 2
+	id, err = ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	c, err = fDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.NotEqual(t, "container:sandboxid", string(c.HostConfig.PidMode))
+}
+
 // TestContainerCreationConflict tests the logic to work around docker container
 // creation naming conflict bug.
 func TestContainerCreationConflict(t *testing.T) {
@@ -238,32 +285,32 @@ func TestContainerCreationConflict(t *testing.T) {
 		expectFields int
 	}{
 		"no create error": {
-			expectCalls:  []string{"create"},
+			expectCalls:  []string{"inspect_image", "create"},
 			expectFields: 6,
 		},
 		"random create error": {
 			createError:  randomError,
 			expectError:  randomError,
-			expectCalls:  []string{"create"},
+			expectCalls:  []string{"inspect_image", "create"},
 			expectFields: 1,
 		},
 		"conflict create error with successful remove": {
 			createError:  conflictError,
 			expectError:  conflictError,
-			expectCalls:  []string{"create", "remove"},
+			expectCalls:  []string{"inspect_image", "create", "remove"},
 			expectFields: 1,
 		},
 		"conflict create error with random remove error": {
 			createError:  conflictError,
 			removeError:  randomError,
 			expectError:  conflictError,
-			expectCalls:  []string{"create", "remove"},
+			expectCalls:  []string{"inspect_image", "create", "remove"},
 			expectFields: 1,
 		},
 		"conflict create error with no such container remove error": {
 			createError:  conflictError,
 			removeError:  noContainerError,
-			expectCalls:  []string{"create", "remove", "create"},
+			expectCalls:  []string{"inspect_image", "create", "remove", "create"},
 			expectFields: 7,
 		},
 	} {
@@ -17,19 +17,112 @@ limitations under the License.
 package dockershim
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
 	dockertypes "github.com/docker/engine-api/types"
+	"github.com/golang/glog"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/clock"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
 )
 
 // This file implements methods in ImageManagerService.
 
-// ListImages lists existing images.
+// ImagePullLimits configures how the shim throttles and routes image pulls.
+type ImagePullLimits struct {
+	// QPS limits the rate of image pulls. Zero disables rate limiting.
+	QPS float32
+	// Burst is the largest burst of pulls allowed on top of QPS.
+	Burst int
+	// Serialized allows at most one image pull at a time when true.
+	Serialized bool
+	// Mirrors lists registry mirror hosts that are tried in order before the
+	// image's canonical registry.
+	Mirrors []string
+}
+
+// mirrorBackoffPeriod is how long a registry mirror is skipped after a failed
+// pull before it is tried again.
+const mirrorBackoffPeriod = 5 * time.Minute
+
+// registryMirrors tracks a prioritized list of registry mirrors and the
+// health of each, so PullImage can skip mirrors that recently failed.
+type registryMirrors struct {
+	lock  sync.Mutex
+	clock clock.Clock
+	hosts []string
+	// failedAt records the time of the last pull failure per mirror host.
+	failedAt map[string]time.Time
+}
+
+func newRegistryMirrors(hosts []string) *registryMirrors {
+	return &registryMirrors{
+		clock:    clock.RealClock{},
+		hosts:    hosts,
+		failedAt: make(map[string]time.Time),
+	}
+}
+
+// healthy returns the mirrors that are not in their failure backoff window,
+// in configuration order.
+func (m *registryMirrors) healthy() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	hosts := []string{}
+	for _, host := range m.hosts {
+		if failed, ok := m.failedAt[host]; ok && m.clock.Since(failed) < mirrorBackoffPeriod {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// observe records the outcome of a pull against the mirror.
+func (m *registryMirrors) observe(host string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err != nil {
+		m.failedAt[host] = m.clock.Now()
+		return
+	}
+	delete(m.failedAt, host)
+}
+
+// mirrorImageName rewrites the image's registry to the mirror host. Images
+// without an explicit registry (docker hub images) keep their namespace;
+// bare official images get the standard v2 "library" namespace, matching
+// how registry mirrors lay them out.
+func mirrorImageName(host, image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 1 {
+		return host + "/library/" + image
+	}
+	if strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost" {
+		return host + "/" + parts[1]
+	}
+	return host + "/" + image
+}
+
+// ListImages lists existing images. The filter's image string also accepts
+// the rich filter terms documented in image_filter.go, which are pushed down
+// to the daemon where its API supports them.
 func (ds *dockerService) ListImages(filter *runtimeapi.ImageFilter) ([]*runtimeapi.Image, error) {
 	opts := dockertypes.ImageListOptions{}
+	digest := ""
 	if filter != nil {
 		if imgSpec := filter.GetImage(); imgSpec != nil {
-			opts.MatchName = imgSpec.Image
+			var err error
+			opts, digest, err = parseImageFilter(imgSpec.Image)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -40,6 +133,9 @@ func (ds *dockerService) ListImages(filter *runtimeapi.ImageFilter) ([]*runtimea
 
 	result := []*runtimeapi.Image{}
 	for _, i := range images {
+		if digest != "" && !imageMatchesDigest(&i, digest) {
+			continue
+		}
 		apiImage, err := imageToRuntimeAPIImage(&i)
 		if err != nil {
 			// TODO: log an error message?
@@ -54,7 +150,7 @@ func (ds *dockerService) ListImages(filter *runtimeapi.ImageFilter) ([]*runtimea
 func (ds *dockerService) ImageStatus(image *runtimeapi.ImageSpec) (*runtimeapi.Image, error) {
 	imageInspect, err := ds.client.InspectImageByRef(image.Image)
 	if err != nil {
-		if dockertools.IsImageNotFoundError(err) {
+		if isImageNotFound(err) {
 			return nil, nil
 		}
 		return nil, err
@@ -63,7 +159,52 @@ func (ds *dockerService) ImageStatus(image *runtimeapi.ImageSpec) (*runtimeapi.I
 }
 
 // PullImage pulls an image with authentication config.
-func (ds *dockerService) PullImage(image *runtimeapi.ImageSpec, auth *runtimeapi.AuthConfig) (string, error) {
+func (ds *dockerService) PullImage(image *runtimeapi.ImageSpec, auth *runtimeapi.AuthConfig) (imageRef string, err error) {
+	defer recordOperation(pullImageOperation)(&err)
+
+	// Concurrent pulls of the same image are coalesced onto the first one.
+	// The pull limits only apply to the leading caller, since coalesced pulls
+	// never reach the registry themselves.
+	return ds.pullCoalescer.pull(image.Image, func() (string, error) {
+		// Enforce the configured pull limits before talking to the registry,
+		// so many pods starting at once don't saturate node bandwidth or
+		// registry rate limits.
+		if ds.pullLimiter != nil && !ds.pullLimiter.TryAccept() {
+			return "", errors.NewImagePullBackoff(fmt.Errorf("pull QPS exceeded."))
+		}
+		if ds.serializePulls {
+			ds.pullLock.Lock()
+			defer ds.pullLock.Unlock()
+		}
+		return ds.pull(image.Image, auth)
+	})
+}
+
+// pull fetches the image from a healthy mirror or its canonical registry and
+// returns the resulting image ref.
+func (ds *dockerService) pull(image string, auth *runtimeapi.AuthConfig) (string, error) {
+	// Try the configured mirrors before the canonical registry so a single
+	// registry outage doesn't keep pods from starting. Credentials are only
+	// valid for the canonical registry and are never forwarded to mirrors.
+	if ds.pullMirrors != nil && auth == nil {
+		for _, host := range ds.pullMirrors.healthy() {
+			mirrored := mirrorImageName(host, image)
+			err := ds.client.PullImage(mirrored, dockertypes.AuthConfig{}, dockertypes.ImagePullOptions{})
+			ds.pullMirrors.observe(host, err)
+			if err != nil {
+				glog.Warningf("Failed to pull image %q from mirror %q: %v", image, host, err)
+				continue
+			}
+			// Tag the image under its canonical name, so image lookups by
+			// the spec's name (and IfNotPresent restarts during a registry
+			// outage) find the mirrored image.
+			if err := ds.client.TagImage(mirrored, image); err != nil {
+				glog.Warningf("Failed to tag image %q pulled from mirror %q as %q: %v", mirrored, host, image, err)
+			}
+			return dockertools.GetImageRef(ds.client, mirrored)
+		}
+	}
+
 	authConfig := dockertypes.AuthConfig{}
 	if auth != nil {
 		authConfig.Username = auth.Username
@@ -71,16 +212,79 @@ func (ds *dockerService) PullImage(image *runtimeapi.ImageSpec, auth *runtimeapi
 		authConfig.ServerAddress = auth.ServerAddress
 		authConfig.IdentityToken = auth.IdentityToken
 		authConfig.RegistryToken = auth.RegistryToken
+	} else if creds, ok := ds.credentialHelpers.get(imageRegistry(image)); ok {
+		// Pulls without pod-level credentials still get the node-level ones
+		// from the configured credential helper.
+		authConfig = creds
 	}
-	err := ds.client.PullImage(image.Image,
+	if err := ds.client.PullImage(image,
 		authConfig,
 		dockertypes.ImagePullOptions{},
-	)
-	if err != nil {
+	); err != nil {
 		return "", err
 	}
 
-	return dockertools.GetImageRef(ds.client, image.Image)
+	// The returned ref is the image's repo digest when the registry provided
+	// one, so callers record pulled images content-addressably.
+	return dockertools.GetImageRef(ds.client, image)
+}
+
+// PrePullImages pulls the given images ahead of need, skipping images that
+// are already present. Pre-pulls bypass the pull QPS limiter so critical
+// images (pause, CNI, node agents) are fetched with priority on a cold node;
+// they still respect pull serialization to protect the daemon.
+func (ds *dockerService) PrePullImages(images []string) error {
+	errs := []error{}
+	for _, image := range images {
+		if inspect, err := ds.client.InspectImageByRef(image); err == nil && inspect != nil {
+			continue
+		}
+		err := func() error {
+			if ds.serializePulls {
+				ds.pullLock.Lock()
+				defer ds.pullLock.Unlock()
+			}
+			_, err := ds.pull(image, nil)
+			return err
+		}()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to pre-pull %q: %v", image, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// resolveImageDigest returns the repo digest of the image, or "" when the
+// image has none (e.g. it was loaded into docker rather than pulled).
+func (ds *dockerService) resolveImageDigest(image string) string {
+	if image == "" {
+		return ""
+	}
+	imageInspect, err := ds.client.InspectImageByRef(image)
+	if err != nil || imageInspect == nil || len(imageInspect.RepoDigests) == 0 {
+		return ""
+	}
+	return imageInspect.RepoDigests[0]
+}
+
+// imagePinnedBy returns the ID of an existing container whose recorded image
+// digest is one of the given digests, if there is one.
+func (ds *dockerService) imagePinnedBy(digests []string) (string, bool) {
+	if len(digests) == 0 {
+		return "", false
+	}
+	containers, err := ds.client.ListContainers(dockertypes.ContainerListOptions{})
+	if err != nil {
+		return "", false
+	}
+	for _, c := range containers {
+		for _, digest := range digests {
+			if c.Labels[imageDigestLabelKey] == digest {
+				return c.ID, true
+			}
+		}
+	}
+	return "", false
 }
 
 // RemoveImage removes the image.
@@ -89,6 +293,13 @@ func (ds *dockerService) RemoveImage(image *runtimeapi.ImageSpec) error {
 	// TODO: We assume image.Image is image ID here, which is true in the current implementation
 	// of kubelet, but we should still clarify this in CRI.
 	imageInspect, err := ds.client.InspectImageByID(image.Image)
+	// Never remove a digest that is still pinned by a running container; the
+	// container was started from exactly this content.
+	if err == nil && imageInspect != nil {
+		if cid, pinned := ds.imagePinnedBy(imageInspect.RepoDigests); pinned {
+			return fmt.Errorf("image %q is pinned by digest to container %q", image.Image, cid)
+		}
+	}
 	if err == nil && imageInspect != nil && len(imageInspect.RepoTags) > 1 {
 		for _, tag := range imageInspect.RepoTags {
 			if _, err := ds.client.RemoveImage(tag, dockertypes.ImageRemoveOptions{PruneChildren: true}); err != nil {
@@ -101,3 +312,25 @@ func (ds *dockerService) RemoveImage(image *runtimeapi.ImageSpec) error {
 	_, err = ds.client.RemoveImage(image.Image, dockertypes.ImageRemoveOptions{PruneChildren: true})
 	return err
 }
+
+// ImageFsInfo returns information about the filesystem that is used to store
+// images. The stats are taken from the filesystem backing the docker root
+// directory, which holds the graph driver's storage.
+func (ds *dockerService) ImageFsInfo() (*runtimeapi.FilesystemUsage, error) {
+	info, err := ds.client.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get docker info: %v", err)
+	}
+	available, capacity, usage, inodes, inodesFree, _, err := volumeutil.FsInfo(info.DockerRootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filesystem info for %q: %v", info.DockerRootDir, err)
+	}
+	return &runtimeapi.FilesystemUsage{
+		StoragePath:    info.DockerRootDir,
+		CapacityBytes:  capacity,
+		AvailableBytes: available,
+		UsedBytes:      usage,
+		Inodes:         inodes,
+		InodesFree:     inodesFree,
+	}, nil
+}
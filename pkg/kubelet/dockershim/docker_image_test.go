@@ -17,10 +17,16 @@ limitations under the License.
 package dockershim
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"k8s.io/client-go/util/flowcontrol"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 )
@@ -43,3 +49,151 @@ func TestRemoveImageWithMultipleTags(t *testing.T) {
 		dockertools.NewCalledDetail("remove_image", []interface{}{"foo", dockertypes.ImageRemoveOptions{PruneChildren: true}}),
 		dockertools.NewCalledDetail("remove_image", []interface{}{"bar", dockertypes.ImageRemoveOptions{PruneChildren: true}}))
 }
+
+func TestImageFsInfo(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	dir, err := ioutil.TempDir("", "dockerroot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fakeDocker.Information = dockertypes.Info{DockerRootDir: dir}
+
+	usage, err := ds.ImageFsInfo()
+	require.NoError(t, err)
+	assert.Equal(t, dir, usage.StoragePath)
+	assert.True(t, usage.CapacityBytes > 0)
+	assert.True(t, usage.Inodes > 0)
+}
+
+func TestPullImageThrottled(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	// With rate limiting disabled, pulls go through.
+	ref, err := ds.PullImage(&runtimeapi.ImageSpec{Image: "foo"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", ref)
+
+	// Once the limiter rejects, pulls fail without reaching docker.
+	ds.pullLimiter = flowcontrol.NewFakeNeverRateLimiter()
+	_, err = ds.PullImage(&runtimeapi.ImageSpec{Image: "bar"}, nil)
+	assert.Error(t, err)
+
+	ds.pullLimiter = flowcontrol.NewFakeAlwaysRateLimiter()
+	ref, err = ds.PullImage(&runtimeapi.ImageSpec{Image: "bar"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", ref)
+}
+
+// TestPrePullImages verifies that pre-pulls fetch missing images, bypass the
+// pull QPS limiter, and skip images that are already present.
+func TestPrePullImages(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+
+	// Pre-pulls are not subject to the pull QPS limit. The fake always
+	// reports an image as present, so force a miss explicitly.
+	ds.pullLimiter = flowcontrol.NewFakeNeverRateLimiter()
+	fakeDocker.InjectError("inspect_image", fmt.Errorf("no such image"))
+	require.NoError(t, ds.PrePullImages([]string{"busybox"}))
+	require.NotNil(t, fakeDocker.Image)
+	assert.Equal(t, "busybox", fakeDocker.Image.ID)
+
+	// The image is now present, so no further pull happens even though the
+	// injected error would fail one.
+	fakeDocker.InjectError("pull", fmt.Errorf("should not pull"))
+	require.NoError(t, ds.PrePullImages([]string{"busybox"}))
+
+	// A failed pull is reported, naming the image.
+	fakeDocker.InjectError("inspect_image", fmt.Errorf("no such image"))
+	err := ds.PrePullImages([]string{"ubuntu"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ubuntu")
+}
+
+func TestMirrorImageName(t *testing.T) {
+	for _, test := range []struct {
+		image, expected string
+	}{
+		{"busybox", "mirror.example.com/library/busybox"},
+		{"busybox:1.25", "mirror.example.com/library/busybox:1.25"},
+		{"library/busybox:latest", "mirror.example.com/library/busybox:latest"},
+		{"gcr.io/foo/bar:v1", "mirror.example.com/foo/bar:v1"},
+		{"localhost:5000/foo", "mirror.example.com/foo"},
+		{"localhost/foo", "mirror.example.com/foo"},
+	} {
+		assert.Equal(t, test.expected, mirrorImageName("mirror.example.com", test.image), "image %q", test.image)
+	}
+}
+
+// TestPullImageMirrorFallback verifies that mirrors are tried in order, that
+// a failed mirror is put in backoff, and that pulls fall through to the
+// canonical registry when no mirror is healthy.
+func TestPullImageMirrorFallback(t *testing.T) {
+	ds, fakeDocker, fakeClock := newTestDockerService()
+	ds.pullMirrors = newRegistryMirrors([]string{"m1.example.com", "m2.example.com"})
+	ds.pullMirrors.clock = fakeClock
+
+	// The first mirror fails; the pull succeeds from the second one.
+	fakeDocker.InjectError("pull", fmt.Errorf("connection refused"))
+	ref, err := ds.PullImage(&runtimeapi.ImageSpec{Image: "busybox"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "m2.example.com/library/busybox", ref)
+	// The mirrored image is also tagged under its canonical name.
+	assert.Contains(t, fakeDocker.Image.RepoTags, "busybox")
+
+	// The failed mirror is skipped until its backoff period expires.
+	assert.Equal(t, []string{"m2.example.com"}, ds.pullMirrors.healthy())
+	fakeClock.Step(mirrorBackoffPeriod)
+	assert.Equal(t, []string{"m1.example.com", "m2.example.com"}, ds.pullMirrors.healthy())
+
+	// With all mirrors in backoff the canonical registry is used.
+	ds.pullMirrors.observe("m1.example.com", fmt.Errorf("connection refused"))
+	ds.pullMirrors.observe("m2.example.com", fmt.Errorf("connection refused"))
+	ref, err = ds.PullImage(&runtimeapi.ImageSpec{Image: "busybox"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "busybox", ref)
+
+	// Credentials are never forwarded to mirrors; authenticated pulls go
+	// straight to the canonical registry.
+	fakeClock.Step(mirrorBackoffPeriod)
+	ref, err = ds.PullImage(&runtimeapi.ImageSpec{Image: "busybox"}, &runtimeapi.AuthConfig{Username: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, "busybox", ref)
+}
+
+// TestCreateContainerRecordsImageDigest verifies that containers are labeled
+// with the repo digest their image resolved to at creation time.
+func TestCreateContainerRecordsImageDigest(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	digest := "busybox@sha256:1111"
+	fakeDocker.Image = &dockertypes.ImageInspect{ID: "busybox", RepoTags: []string{"busybox"}, RepoDigests: []string{digest}}
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c", "busybox", 0, nil, nil)
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+
+	c, err := fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.Equal(t, digest, c.Config.Labels[imageDigestLabelKey])
+}
+
+// TestRemoveImagePinnedByDigest verifies that an image whose digest is still
+// recorded on a running container cannot be removed.
+func TestRemoveImagePinnedByDigest(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	digest := "busybox@sha256:1111"
+	fakeDocker.Image = &dockertypes.ImageInspect{ID: "busybox", RepoTags: []string{"busybox"}, RepoDigests: []string{digest}}
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c", "busybox", 0, nil, nil)
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.NoError(t, ds.StartContainer(id))
+
+	// The running container pins the digest.
+	err = ds.RemoveImage(&runtimeapi.ImageSpec{Image: "busybox"})
+	assert.Error(t, err)
+
+	// Once the container is stopped the image can be removed again.
+	require.NoError(t, ds.StopContainer(id, 0))
+	assert.NoError(t, ds.RemoveImage(&runtimeapi.ImageSpec{Image: "busybox"}))
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package dockershim
 
 import (
-	"fmt"
-	"strings"
 	"sync/atomic"
 	"time"
 
@@ -26,12 +24,9 @@ import (
 	dockerfilters "github.com/docker/engine-api/types/filters"
 	"github.com/golang/glog"
 
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
-	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
-	"k8s.io/kubernetes/pkg/kubelet/dockertools"
-	"k8s.io/kubernetes/pkg/kubelet/leaky"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/convert"
 )
 
 // These are labels used by kuberuntime. Ideally, we should not rely on kuberuntime implementation
@@ -39,15 +34,15 @@ import (
 // kubernetes 1.4 and 1.5) support.
 // TODO(random-liu): Remove this file and related code in kubernetes 1.8.
 const (
-	podDeletionGracePeriodLabel    = "io.kubernetes.pod.deletionGracePeriod"
-	podTerminationGracePeriodLabel = "io.kubernetes.pod.terminationGracePeriod"
-
-	containerHashLabel                     = "io.kubernetes.container.hash"
-	containerRestartCountLabel             = "io.kubernetes.container.restartCount"
-	containerTerminationMessagePathLabel   = "io.kubernetes.container.terminationMessagePath"
-	containerTerminationMessagePolicyLabel = "io.kubernetes.container.terminationMessagePolicy"
-	containerPreStopHandlerLabel           = "io.kubernetes.container.preStopHandler"
-	containerPortsLabel                    = "io.kubernetes.container.ports"
+	podDeletionGracePeriodLabel    = convert.PodDeletionGracePeriodLabel
+	podTerminationGracePeriodLabel = convert.PodTerminationGracePeriodLabel
+
+	containerHashLabel                     = convert.ContainerHashLabel
+	containerRestartCountLabel             = convert.ContainerRestartCountLabel
+	containerTerminationMessagePathLabel   = convert.ContainerTerminationMessagePathLabel
+	containerTerminationMessagePolicyLabel = convert.ContainerTerminationMessagePolicyLabel
+	containerPreStopHandlerLabel           = convert.ContainerPreStopHandlerLabel
+	containerPortsLabel                    = convert.ContainerPortsLabel
 )
 
 // NOTE that we can't handle the following dockershim internal labels, so they will be empty:
@@ -65,91 +60,29 @@ const (
 // sandbox will always be 0. The sandbox attempt is only used to generate new sandbox name, and
 // there is no naming conflict between legacy and new containers/sandboxes, so it should be fine.
 func convertLegacyNameAndLabels(names []string, labels map[string]string) ([]string, map[string]string, error) {
-	if len(names) == 0 {
-		return nil, nil, fmt.Errorf("unexpected empty name")
-	}
-
-	// Generate new dockershim name.
-	m, _, err := dockertools.ParseDockerName(names[0])
-	if err != nil {
-		return nil, nil, err
-	}
-	sandboxName, sandboxNamespace, err := kubecontainer.ParsePodFullName(m.PodFullName)
-	if err != nil {
-		return nil, nil, err
-	}
-	newNames := []string{strings.Join([]string{
-		kubePrefix,                         // 0
-		m.ContainerName,                    // 1: container name
-		sandboxName,                        // 2: sandbox name
-		sandboxNamespace,                   // 3: sandbox namesapce
-		string(m.PodUID),                   // 4: sandbox uid
-		labels[containerRestartCountLabel], // 5
-	}, nameDelimiter)}
-
-	// Generate new labels.
-	legacyAnnotations := sets.NewString(
-		containerHashLabel,
-		containerRestartCountLabel,
-		containerTerminationMessagePathLabel,
-		containerTerminationMessagePolicyLabel,
-		containerPreStopHandlerLabel,
-		containerPortsLabel,
-	)
-	newLabels := map[string]string{}
-	for k, v := range labels {
-		if legacyAnnotations.Has(k) {
-			// Add annotation prefix for all legacy labels which should be annotations in dockershim.
-			newLabels[fmt.Sprintf("%s%s", annotationPrefix, k)] = v
-		} else {
-			newLabels[k] = v
-		}
-	}
-	// Add containerTypeLabelKey indicating the container is sandbox or application container.
-	if m.ContainerName == leaky.PodInfraContainerName {
-		newLabels[containerTypeLabelKey] = containerTypeLabelSandbox
-	} else {
-		newLabels[containerTypeLabelKey] = containerTypeLabelContainer
-	}
-	return newNames, newLabels, nil
+	return convert.ConvertLegacyNameAndLabels(names, labels)
 }
 
 // legacyCleanupCheckInterval is the interval legacyCleanupCheck is performed.
 const legacyCleanupCheckInterval = 10 * time.Second
 
 // LegacyCleanupInit initializes the legacy cleanup flag. If necessary, it will starts a goroutine
-// which periodically checks legacy cleanup until it finishes.
+// which periodically runs legacy migration rounds until nothing remains.
 func (ds *dockerService) LegacyCleanupInit() {
-	// If there is no legacy container/sandbox, just return.
-	if clean, _ := ds.checkLegacyCleanup(); clean {
+	// A persisted completion record means an earlier run already verified
+	// that no legacy container remains; skip the legacy paths without
+	// listing containers at all.
+	ds.legacyMigration.load()
+	if ds.legacyMigration.done() {
+		ds.legacyCleanup.MarkDone()
 		return
 	}
-	// Or else start the cleanup routine.
-	go wait.PollInfinite(legacyCleanupCheckInterval, ds.checkLegacyCleanup)
-}
-
-// checkLegacyCleanup lists legacy containers/sandboxes, if no legacy containers/sandboxes are found,
-// mark the legacy cleanup flag as done.
-func (ds *dockerService) checkLegacyCleanup() (bool, error) {
-	// Always do legacy cleanup when list fails.
-	sandboxes, err := ds.ListLegacyPodSandbox(nil)
-	if err != nil {
-		glog.Errorf("Failed to list legacy pod sandboxes: %v", err)
-		return false, nil
-	}
-	containers, err := ds.ListLegacyContainers(nil)
-	if err != nil {
-		glog.Errorf("Failed to list legacy containers: %v", err)
-		return false, nil
-	}
-	if len(sandboxes) != 0 || len(containers) != 0 {
-		glog.V(4).Infof("Found legacy sandboxes %+v, legacy containers %+v, continue legacy cleanup",
-			sandboxes, containers)
-		return false, nil
+	// If there is no legacy container/sandbox, just return.
+	if clean, _ := ds.migrateLegacyContainers(); clean {
+		return
 	}
-	ds.legacyCleanup.MarkDone()
-	glog.V(2).Infof("No legacy containers found, stop performing legacy cleanup.")
-	return true, nil
+	// Or else start the migration routine.
+	go wait.PollInfinite(legacyCleanupCheckInterval, ds.migrateLegacyContainers)
 }
 
 // ListLegacyPodSandbox only lists all legacy pod sandboxes.
@@ -258,7 +258,7 @@ func TestCheckLegacyCleanup(t *testing.T) {
 		fDocker.SetFakeContainers(test.containers)
 		ds.legacyCleanup.done = 0
 
-		clean, err := ds.checkLegacyCleanup()
+		clean, err := ds.migrateLegacyContainers()
 		assert.NoError(t, err)
 		assert.Equal(t, test.done, clean)
 		assert.Equal(t, test.done, ds.legacyCleanup.Done())
@@ -18,6 +18,9 @@ package dockershim
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"sync"
 
 	dockertypes "github.com/docker/engine-api/types"
 	dockercontainer "github.com/docker/engine-api/types/container"
@@ -25,23 +28,32 @@ import (
 	"github.com/golang/glog"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+	"k8s.io/kubernetes/pkg/kubelet/network"
 	"k8s.io/kubernetes/pkg/kubelet/qos"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 )
 
 const (
-	defaultSandboxImage = "gcr.io/google_containers/pause-amd64:3.0"
-
 	// Various default sandbox resources requests/limits.
 	defaultSandboxCPUshares int64 = 2
 
 	// Termination grace period
 	defaultSandboxGracePeriod int = 10
 
+	// Grace period given to each workload container when its sandbox is
+	// stopped.
+	defaultContainerGracePeriod int = 10
+
+	// Bounds how many workload containers are stopped concurrently while a
+	// sandbox is being drained.
+	maxParallelContainerStops = 4
+
 	// Name of the underlying container runtime
 	runtimeName = "docker"
 )
@@ -50,20 +62,53 @@ const (
 // the sandbox is in ready state.
 // For docker, PodSandbox is implemented by a container holding the network
 // namespace for the pod.
-// Note: docker doesn't use LogDirectory (yet).
-func (ds *dockerService) RunPodSandbox(config *runtimeapi.PodSandboxConfig) (string, error) {
-	// Step 1: Pull the image for the sandbox.
-	image := defaultSandboxImage
+// Note: docker cannot be told to write logs to LogDirectory directly; the
+// shim creates the directory and symlinks the container logs into it.
+func (ds *dockerService) RunPodSandbox(config *runtimeapi.PodSandboxConfig) (id string, err error) {
+	defer recordOperation(runPodSandboxOperation)(&err)
+	trace := ds.newOpTrace("RunPodSandbox", "pod", fmt.Sprintf("%s/%s", config.GetMetadata().Namespace, config.GetMetadata().Name))
+
+	// A non-zero attempt means the previous sandbox died or was evicted;
+	// count the recreation so per-pod sandbox churn shows up in the shim's
+	// metrics and crash loops are flagged on the sandbox status.
+	if config.GetMetadata().Attempt > 0 {
+		podFullName := fmt.Sprintf("%s/%s", config.GetMetadata().Namespace, config.GetMetadata().Name)
+		metrics.SandboxChurn.WithLabelValues(podFullName).Inc()
+		if n := ds.sandboxChurn.recordRecreation(podFullName); n >= sandboxCrashLoopThreshold {
+			glog.Warningf("Sandbox for pod %q recreated %d times in the last %v, likely crash looping", podFullName, n, sandboxCrashLoopWindow)
+		}
+	}
+
+	// Refuse the sandbox up front when one of its host ports is already
+	// claimed by another pod; docker would otherwise fail the start with an
+	// opaque bind error.
+	if err := ds.checkSandboxHostPortConflicts(config); err != nil {
+		return "", err
+	}
+
+	// Run the node-local admission plugins, which may mutate the config or
+	// reject the sandbox before anything is created.
+	if err := ds.admitSandbox(config); err != nil {
+		return "", err
+	}
+
+	// Step 1: Pull the image for the sandbox. The namespace policy's sandbox
+	// image takes precedence over the node-wide one.
+	image := defaultSandboxImage()
 	podSandboxImage := ds.podSandboxImage
 	if len(podSandboxImage) != 0 {
 		image = podSandboxImage
 	}
+	if namespaceImage := ds.namespacePolicies.sandboxImage(config.GetMetadata().Namespace); namespaceImage != "" {
+		image = namespaceImage
+	}
 
 	// NOTE: To use a custom sandbox image in a private repository, users need to configure the nodes with credentials properly.
 	// see: http://kubernetes.io/docs/user-guide/images/#configuring-nodes-to-authenticate-to-a-private-repository
 	if err := ds.client.PullImage(image, dockertypes.AuthConfig{}, dockertypes.ImagePullOptions{}); err != nil {
 		return "", fmt.Errorf("unable to pull image for the sandbox container: %v", err)
 	}
+	trace.step("pulled sandbox image", "image", image)
 
 	// Step 2: Create the sandbox container.
 	createConfig, err := ds.makeSandboxDockerConfig(config, image)
@@ -78,24 +123,63 @@ func (ds *dockerService) RunPodSandbox(config *runtimeapi.PodSandboxConfig) (str
 	if err != nil || createResp == nil {
 		return "", fmt.Errorf("failed to create a sandbox for pod %q: %v", config.Metadata.Name, err)
 	}
+	trace.with("sandbox", createResp.ID)
+	trace.step("created sandbox container")
 
 	// Step 3: Create Sandbox Checkpoint.
-	if err = ds.checkpointHandler.CreateCheckpoint(createResp.ID, constructPodSandboxCheckpoint(config)); err != nil {
+	checkpoint := constructPodSandboxCheckpoint(config)
+	if !checkpoint.Data.HostNetwork {
+		// Record where the network namespace will be bind-mounted, so
+		// teardown can find it after both the sandbox container and the
+		// docker state are gone.
+		checkpoint.Data.NetNSPath = netnsPath(createResp.ID)
+	}
+	if err = ds.checkpointHandler.CreateCheckpoint(createResp.ID, checkpoint); err != nil {
 		return createResp.ID, err
 	}
 
-	// Step 4: Start the sandbox container.
+	// Step 4: Create the sandbox log directory. Container log symlinks are
+	// created into it when the containers are started.
+	if logDir := config.LogDirectory; logDir != "" {
+		if err := ds.os.MkdirAll(logDir, 0755); err != nil {
+			return createResp.ID, fmt.Errorf("failed to create log directory %q for pod %q: %v", logDir, config.Metadata.Name, err)
+		}
+	}
+
+	// Step 5: Start the sandbox container.
 	// Assume kubelet's garbage collector would remove the sandbox later, if
 	// startContainer failed.
 	err = ds.client.StartContainer(createResp.ID)
 	if err != nil {
 		return createResp.ID, fmt.Errorf("failed to start sandbox container for pod %q: %v", config.Metadata.Name, err)
 	}
+	trace.step("started sandbox container")
+
+	// Write the pod's resolv.conf instead of passing the DNS config to
+	// docker. Workload containers join the sandbox's network namespace and
+	// get the same file mounted at /etc/resolv.conf by docker, so the file
+	// is written only once per pod.
+	if dnsConfig := config.GetDnsConfig(); dnsConfig != nil && !sandboxPlatformWindows() {
+		if err := ds.applySandboxDNS(createResp.ID, dnsConfig); err != nil {
+			return createResp.ID, fmt.Errorf("failed to apply DNS config to sandbox for pod %q: %v", config.Metadata.Name, err)
+		}
+		trace.step("wrote sandbox resolv.conf")
+	}
 	if nsOptions := config.GetLinux().GetSecurityContext().GetNamespaceOptions(); nsOptions != nil && nsOptions.HostNetwork {
 		return createResp.ID, nil
 	}
 
-	// Step 5: Setup networking for the sandbox.
+	// Bind-mount the network namespace at its stable path before the network
+	// plugin runs, so the namespace outlives the pause container and teardown
+	// always has a valid netns to enter (see GetNetNS). Failures degrade to
+	// the old pid-based path and best-effort cleanup.
+	if r, inspectErr := ds.client.InspectContainer(createResp.ID); inspectErr != nil {
+		glog.Warningf("Failed to inspect sandbox %q for netns mount: %v", createResp.ID, inspectErr)
+	} else if mountErr := ds.netns.bindMount(createResp.ID, getNetworkNamespace(r)); mountErr != nil {
+		glog.Warningf("Failed to bind mount network namespace of sandbox %q: %v", createResp.ID, mountErr)
+	}
+
+	// Step 6: Setup networking for the sandbox.
 	// All pod networking is setup by a CNI plugin discovered at startup time.
 	// This plugin assigns the pod ip, sets up routes inside the sandbox,
 	// creates interfaces etc. In theory, its jurisdiction ends with pod
@@ -103,26 +187,73 @@ func (ds *dockerService) RunPodSandbox(config *runtimeapi.PodSandboxConfig) (str
 	// on the host as well, to satisfy parts of the pod spec that aren't
 	// recognized by the CNI standard yet.
 	cID := kubecontainer.BuildContainerID(runtimeName, createResp.ID)
-	err = ds.networkPlugin.SetUpPod(config.GetMetadata().Namespace, config.GetMetadata().Name, cID)
+	err = ds.networkSetUpPod(config.GetMetadata().Namespace, config.GetMetadata().Name, cID)
+	if err != nil {
+		trace.stepFailed(err, "pod network setup failed")
+	} else {
+		trace.step("set up pod network")
+		// Write the pod's hosts file now that the pod IP is known. Best
+		// effort: docker's default hosts file stays in place on failure.
+		if hostsErr := ds.applySandboxHosts(createResp.ID); hostsErr != nil {
+			glog.Warningf("Failed to write hosts file for sandbox %q: %v", createResp.ID, hostsErr)
+		}
+		// Invoke the network policy hook now that the pod is attached, so
+		// policy is in place before any workload container starts. In
+		// fail-closed mode a hook failure fails the sandbox.
+		if hookErr := ds.networkPolicyHook.setup(config, createResp.ID); hookErr != nil {
+			err = hookErr
+			trace.stepFailed(err, "network policy hook failed")
+		}
+	}
 	// TODO: Do we need to teardown on failure or can we rely on a StopPodSandbox call with the given ID?
+
+	// Step 7: If requested, apply best-effort basic isolation on top of the
+	// plugin's setup. Failures are logged instead of failing the sandbox,
+	// since the mode is documented as best-effort.
+	if err == nil && sandboxNeedsIsolation(config.GetAnnotations()) {
+		if isoErr := ds.setUpSandboxIsolation(createResp.ID, config.GetAnnotations()); isoErr != nil {
+			glog.Warningf("Failed to set up basic isolation for sandbox %q: %v", createResp.ID, isoErr)
+		}
+	}
+
+	// Step 8: With the shim-managed hostport manager enabled, program DNAT
+	// rules for the port mappings instead of docker's publish mechanism.
+	if err == nil && ds.featureGate.Enabled(hostportManagerFeature) {
+		if hpErr := ds.setUpSandboxHostPorts(createResp.ID, config.GetPortMappings()); hpErr != nil {
+			err = fmt.Errorf("failed to set up hostports for sandbox %q: %v", createResp.ID, hpErr)
+		}
+	}
+
+	// Step 9: Apply the pod's bandwidth annotations via tc, unless the
+	// network plugin shapes traffic itself.
+	if err == nil && ds.featureGate.Enabled(bandwidthShapingFeature) {
+		if shapeErr := ds.setUpSandboxBandwidth(createResp.ID, config.GetAnnotations()); shapeErr != nil {
+			err = fmt.Errorf("failed to set up bandwidth limits for sandbox %q: %v", createResp.ID, shapeErr)
+		}
+	}
 	return createResp.ID, err
 }
 
 // StopPodSandbox stops the sandbox. If there are any running containers in the
 // sandbox, they should be force terminated.
-// TODO: This function blocks sandbox teardown on networking teardown. Is it
-// better to cut our losses assuming an out of band GC routine will cleanup
-// after us?
-func (ds *dockerService) StopPodSandbox(podSandboxID string) error {
-	var namespace, name string
+func (ds *dockerService) StopPodSandbox(podSandboxID string) (err error) {
+	defer recordOperation(stopPodSandboxOperation)(&err)
+	defer ds.inspectCache.invalidate(podSandboxID)
+
+	var namespace, name, podIP string
 	var checkpointErr, statusErr error
 	needNetworkTearDown := false
+	needIsolationTearDown := false
 
 	// Try to retrieve sandbox information from docker daemon or sandbox checkpoint
 	status, statusErr := ds.PodSandboxStatus(podSandboxID)
 	if statusErr == nil {
 		nsOpts := status.GetLinux().GetNamespaces().GetOptions()
 		needNetworkTearDown = nsOpts != nil && !nsOpts.HostNetwork
+		needIsolationTearDown = sandboxNeedsIsolation(status.GetAnnotations())
+		if netStatus := status.GetNetwork(); netStatus != nil {
+			podIP = netStatus.Ip
+		}
 		m := status.GetMetadata()
 		namespace = m.Namespace
 		name = m.Name
@@ -134,7 +265,7 @@ func (ds *dockerService) StopPodSandbox(podSandboxID string) error {
 		// actions will only have sandbox ID and not have pod namespace and name information.
 		// Return error if encounter any unexpected error.
 		if checkpointErr != nil {
-			if dockertools.IsContainerNotFoundError(statusErr) && checkpointErr == errors.CheckpointNotFoundError {
+			if isContainerNotFound(statusErr) && checkpointErr == errors.CheckpointNotFoundError {
 				glog.Warningf("Both sandbox container and checkpoint for id %q could not be found. "+
 					"Proceed without further sandbox information.", podSandboxID)
 			} else {
@@ -145,58 +276,208 @@ func (ds *dockerService) StopPodSandbox(podSandboxID string) error {
 		} else {
 			namespace = checkpoint.Namespace
 			name = checkpoint.Name
+			if checkpoint.Data != nil {
+				needIsolationTearDown = sandboxNeedsIsolation(checkpoint.Data.Annotations)
+			}
 		}
 
-		// Always trigger network plugin to tear down
-		needNetworkTearDown = true
+		// Trigger network plugin to tear down unless the checkpoint tells us
+		// the sandbox was sharing the host's network namespace. Without a
+		// checkpoint, always tear down so the plugin gets a chance to clean up.
+		needNetworkTearDown = checkpointErr != nil || checkpoint.Data == nil || !checkpoint.Data.HostNetwork
 	}
 
 	// WARNING: The following operations made the following assumption:
-	// 1. kubelet will retry on any error returned by StopPodSandbox.
-	// 2. tearing down network and stopping sandbox container can succeed in any sequence.
+	// 1. tearing down network and stopping sandbox container can succeed in any sequence.
 	// This depends on the implementation detail of network plugin and proper error handling.
-	// For kubenet, if tearing down network failed and sandbox container is stopped, kubelet
-	// will retry. On retry, kubenet will not be able to retrieve network namespace of the sandbox
-	// since it is stopped. With empty network namespcae, CNI bridge plugin will conduct best
-	// effort clean up and will not return error.
+	// Failed network teardowns are retried asynchronously with backoff instead of failing
+	// the stop. On retry, kubenet will not be able to retrieve network namespace of the
+	// sandbox since it is stopped. With empty network namespcae, CNI bridge plugin will
+	// conduct best effort clean up and will not return error.
 	errList := []error{}
+	// Drain the workload containers before tearing down the network and
+	// stopping the sandbox container, so running processes never see their
+	// network namespace destroyed underneath them.
+	if err := ds.stopSandboxContainers(podSandboxID); err != nil {
+		errList = append(errList, err)
+	}
+	if needIsolationTearDown {
+		// Basic isolation is best-effort; log instead of failing the stop.
+		if err := ds.tearDownSandboxIsolation(podSandboxID); err != nil {
+			glog.Warningf("Failed to tear down basic isolation for sandbox %q: %v", podSandboxID, err)
+		}
+	}
+	// Remove any shim-managed hostport rules, using the checkpointed port
+	// mappings to decide whether the sandbox had host ports. This also
+	// cleans up rules left over from before the feature gate was flipped.
+	if mappings, err := ds.GetPodPortMappings(podSandboxID); err == nil {
+		for _, pm := range mappings {
+			if pm.HostPort > 0 {
+				if err := ds.tearDownSandboxHostPorts(podSandboxID); err != nil {
+					glog.Warningf("Failed to tear down hostport rules for sandbox %q: %v", podSandboxID, err)
+				}
+				break
+			}
+		}
+	}
 	if needNetworkTearDown {
+		if ds.featureGate.Enabled(bandwidthShapingFeature) {
+			ds.tearDownSandboxBandwidth(podSandboxID, podIP)
+		}
+		ds.forgetPodIP(podSandboxID)
 		cID := kubecontainer.BuildContainerID(runtimeName, podSandboxID)
-		if err := ds.networkPlugin.TearDownPod(namespace, name, cID); err != nil {
-			errList = append(errList, fmt.Errorf("failed to teardown sandbox %q for pod %s/%s: %v", podSandboxID, namespace, name, err))
+		if err := ds.teardownDispatcher.dispatch(namespace, name, cID); err != nil {
+			// Retry asynchronously with backoff rather than failing the stop, so a
+			// transient plugin failure neither blocks sandbox stop latency nor
+			// leaks the pod's IP allocation.
+			glog.Warningf("Failed to teardown sandbox %q for pod %s/%s, will retry: %v", podSandboxID, namespace, name, err)
+			ds.teardownQueue.enqueue(namespace, name, podSandboxID)
+		} else {
+			// The plugin is done with the namespace; release the bind mount
+			// that kept it alive. On the retry path the mount stays in place
+			// so the retried teardown still has the namespace, and it is
+			// released when the sandbox is removed.
+			ds.netns.unmount(podSandboxID)
 		}
 	}
 	if err := ds.client.StopContainer(podSandboxID, defaultSandboxGracePeriod); err != nil {
 		glog.Errorf("Failed to stop sandbox %q: %v", podSandboxID, err)
 		// Do not return error if the container does not exist
-		if !dockertools.IsContainerNotFoundError(err) {
+		if !isContainerNotFound(err) {
 			errList = append(errList, err)
 		}
 	}
 	return utilerrors.NewAggregate(errList)
-	// TODO: Stop all running containers in the sandbox.
+}
+
+// stopSandboxContainers stops all running workload containers in the sandbox.
+// Containers are stopped in parallel, bounded by maxParallelContainerStops,
+// and each container gets its own grace period.
+func (ds *dockerService) stopSandboxContainers(podSandboxID string) error {
+	containers, err := ds.ListContainers(&runtimeapi.ContainerFilter{PodSandboxId: podSandboxID})
+	if err != nil {
+		return fmt.Errorf("failed to list containers for sandbox %q: %v", podSandboxID, err)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		errLock sync.Mutex
+		errs    []error
+	)
+	tickets := make(chan struct{}, maxParallelContainerStops)
+	for _, c := range containers {
+		if c.State != runtimeapi.ContainerState_CONTAINER_RUNNING {
+			continue
+		}
+		wg.Add(1)
+		go func(containerID string) {
+			defer wg.Done()
+			tickets <- struct{}{}
+			defer func() { <-tickets }()
+			if err := ds.client.StopContainer(containerID, defaultContainerGracePeriod); err != nil && !isContainerNotFound(err) {
+				errLock.Lock()
+				errs = append(errs, fmt.Errorf("failed to stop container %q in sandbox %q: %v", containerID, podSandboxID, err))
+				errLock.Unlock()
+			}
+		}(c.Id)
+	}
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
 }
 
 // RemovePodSandbox removes the sandbox. If there are running containers in the
 // sandbox, they should be forcibly removed.
 func (ds *dockerService) RemovePodSandbox(podSandboxID string) error {
+	defer ds.inspectCache.invalidate(podSandboxID)
 	var errs []error
-	if err := ds.client.RemoveContainer(podSandboxID, dockertypes.ContainerRemoveOptions{RemoveVolumes: true}); err != nil && !dockertools.IsContainerNotFoundError(err) {
+	if err := ds.client.RemoveContainer(podSandboxID, dockertypes.ContainerRemoveOptions{RemoveVolumes: true}); err != nil && !isContainerNotFound(err) {
 		errs = append(errs, err)
 	}
 	if err := ds.checkpointHandler.RemoveCheckpoint(podSandboxID); err != nil {
 		errs = append(errs, err)
 	}
+	ds.netns.unmount(podSandboxID)
+	ds.forgetPodIP(podSandboxID)
 	return utilerrors.NewAggregate(errs)
 	// TODO: remove all containers in the sandbox.
 }
 
-// getIPFromPlugin interrogates the network plugin for an IP.
+// UpdatePodSandboxNetwork re-invokes the network plugin's setup against a
+// live sandbox, so that plugin configuration changes (e.g. an MTU update)
+// take effect without the sandbox being recreated. Only plugins advertising
+// the live-reconfigure capability are invoked; for CNI, a repeated ADD
+// updates the existing attachment in place.
+func (ds *dockerService) UpdatePodSandboxNetwork(podSandboxID string) (err error) {
+	defer recordOperation(updatePodSandboxNetworkOperation)(&err)
+
+	if !ds.networkPlugin.Capabilities().Has(network.NET_PLUGIN_CAPABILITY_LIVE_RECONFIGURE) {
+		return fmt.Errorf("network plugin %q does not support live reconfiguration", ds.networkPlugin.Name())
+	}
+	r, err := ds.client.InspectContainer(podSandboxID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect sandbox %q: %v", podSandboxID, err)
+	}
+	if !r.State.Running {
+		return fmt.Errorf("cannot update network of sandbox %q: sandbox is not running", podSandboxID)
+	}
+	if sharesHostNetwork(r) {
+		// The sandbox lives in the host's network namespace; there is no
+		// plugin-managed attachment to update.
+		return nil
+	}
+	metadata, err := parseSandboxName(r.Name)
+	if err != nil {
+		return err
+	}
+	cID := kubecontainer.BuildContainerID(runtimeName, r.ID)
+	if err := ds.networkSetUpPod(metadata.Namespace, metadata.Name, cID); err != nil {
+		return fmt.Errorf("failed to update network for sandbox %q: %v", podSandboxID, err)
+	}
+	// The plugin may have assigned a new IP; drop the cached one so the next
+	// status query interrogates the plugin again, and refresh the pod IP
+	// entry in the managed hosts file.
+	ds.forgetPodIP(podSandboxID)
+	if err := ds.applySandboxHosts(podSandboxID); err != nil {
+		glog.Warningf("Failed to refresh hosts file for sandbox %q: %v", podSandboxID, err)
+	}
+	return nil
+}
+
+// getCachedPodIP returns the cached plugin-reported IP for the sandbox.
+func (ds *dockerService) getCachedPodIP(podSandboxID string) (string, bool) {
+	ds.podIPCacheLock.Lock()
+	defer ds.podIPCacheLock.Unlock()
+	ip, ok := ds.podIPCache[podSandboxID]
+	return ip, ok
+}
+
+// cachePodIP remembers the plugin-reported IP for the sandbox.
+func (ds *dockerService) cachePodIP(podSandboxID, ip string) {
+	ds.podIPCacheLock.Lock()
+	defer ds.podIPCacheLock.Unlock()
+	ds.podIPCache[podSandboxID] = ip
+}
+
+// forgetPodIP invalidates the cached IP of the sandbox. It must be called
+// whenever the sandbox's networking is torn down.
+func (ds *dockerService) forgetPodIP(podSandboxID string) {
+	ds.podIPCacheLock.Lock()
+	defer ds.podIPCacheLock.Unlock()
+	delete(ds.podIPCache, podSandboxID)
+}
+
+// getIPFromPlugin interrogates the network plugin for an IP. The result is
+// cached per sandbox, since some plugins enter the network namespace and
+// scan interfaces on every call, which is too expensive for high-frequency
+// status polling. The cache is invalidated on network teardown.
 func (ds *dockerService) getIPFromPlugin(sandbox *dockertypes.ContainerJSON) (string, error) {
 	metadata, err := parseSandboxName(sandbox.Name)
 	if err != nil {
 		return "", err
 	}
+	if ip, ok := ds.getCachedPodIP(sandbox.ID); ok {
+		return ip, nil
+	}
 	msg := fmt.Sprintf("Couldn't find network status for %s/%s through plugin", metadata.Namespace, metadata.Name)
 	cID := kubecontainer.BuildContainerID(runtimeName, sandbox.ID)
 	networkStatus, err := ds.networkPlugin.GetPodNetworkStatus(metadata.Namespace, metadata.Name, cID)
@@ -209,12 +490,15 @@ func (ds *dockerService) getIPFromPlugin(sandbox *dockertypes.ContainerJSON) (st
 	if networkStatus == nil {
 		return "", fmt.Errorf("%v: invalid network status for", msg)
 	}
+	ds.cachePodIP(sandbox.ID, networkStatus.IP.String())
 	return networkStatus.IP.String(), nil
 }
 
 // getIP returns the ip given the output of `docker inspect` on a pod sandbox,
 // first interrogating any registered plugins, then simply trusting the ip
-// in the sandbox itself. We look for an ipv4 address before ipv6.
+// in the sandbox itself. We look for an ipv4 address before ipv6; in an
+// IPv6-only cluster docker only assigns a GlobalIPv6Address, which then
+// becomes the primary pod IP.
 func (ds *dockerService) getIP(sandbox *dockertypes.ContainerJSON) (string, error) {
 	if sandbox.NetworkSettings == nil {
 		return "", nil
@@ -242,8 +526,8 @@ func (ds *dockerService) getIP(sandbox *dockertypes.ContainerJSON) (string, erro
 
 // PodSandboxStatus returns the status of the PodSandbox.
 func (ds *dockerService) PodSandboxStatus(podSandboxID string) (*runtimeapi.PodSandboxStatus, error) {
-	// Inspect the container.
-	r, err := ds.client.InspectContainer(podSandboxID)
+	// Inspect the container, via the event-invalidated cache.
+	r, err := ds.inspectContainerCached(podSandboxID)
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +568,14 @@ func (ds *dockerService) PodSandboxStatus(podSandboxID string) (*runtimeapi.PodS
 		return nil, err
 	}
 	labels, annotations := extractLabels(r.Config.Labels)
+	// Surface the attempt and the churn verdict on recreated sandboxes, so
+	// the kubelet can back off pods whose sandboxes crash loop.
+	if metadata.Attempt > 0 {
+		annotations[sandboxAttemptAnnotationKey] = strconv.FormatUint(uint64(metadata.Attempt), 10)
+		if ds.sandboxChurn.inCrashLoop(fmt.Sprintf("%s/%s", metadata.Namespace, metadata.Name)) {
+			annotations[sandboxCrashLoopAnnotationKey] = "true"
+		}
+	}
 	return &runtimeapi.PodSandboxStatus{
 		Id:          r.ID,
 		State:       state,
@@ -303,11 +595,24 @@ func (ds *dockerService) PodSandboxStatus(podSandboxID string) (*runtimeapi.PodS
 	}, nil
 }
 
+// ListPodSandboxPaged is ListPodSandbox with limit/continue pagination, so
+// nodes with thousands of exited sandboxes do not have to return everything
+// in one shot.
+func (ds *dockerService) ListPodSandboxPaged(filter *runtimeapi.PodSandboxFilter, limit int32, continueToken string) ([]*runtimeapi.PodSandbox, string, error) {
+	items, err := ds.ListPodSandbox(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	page, next := paginateSandboxes(items, limit, continueToken)
+	return page, next, nil
+}
+
 // ListPodSandbox returns a list of Sandbox.
 func (ds *dockerService) ListPodSandbox(filter *runtimeapi.PodSandboxFilter) ([]*runtimeapi.PodSandbox, error) {
 	// By default, list all containers whether they are running or not.
 	opts := dockertypes.ContainerListOptions{All: true}
 	filterOutReadySandboxes := false
+	var indexedIDs sets.String
 
 	opts.Filter = dockerfilters.NewArgs()
 	f := newDockerFilter(&opts.Filter)
@@ -333,8 +638,14 @@ func (ds *dockerService) ListPodSandbox(filter *runtimeapi.PodSandboxFilter) ([]
 		}
 
 		if filter.LabelSelector != nil {
-			for k, v := range filter.LabelSelector {
-				f.AddLabel(k, v)
+			// Serve the label selector from the in-memory index when it is
+			// populated; docker's label filter scans every container.
+			if ids, ok := ds.labels.match(filter.LabelSelector); ok {
+				indexedIDs = ids
+			} else {
+				for k, v := range filter.LabelSelector {
+					f.AddLabel(k, v)
+				}
 			}
 		}
 	}
@@ -349,6 +660,9 @@ func (ds *dockerService) ListPodSandbox(filter *runtimeapi.PodSandboxFilter) ([]
 	sandboxIDs := make(map[string]bool)
 	for i := range containers {
 		c := containers[i]
+		if indexedIDs != nil && !indexedIDs.Has(c.ID) {
+			continue
+		}
 		converted, err := containerToRuntimeAPISandbox(&c)
 		if err != nil {
 			glog.V(4).Infof("Unable to convert docker to runtime API sandbox %+v: %v", c, err)
@@ -365,24 +679,16 @@ func (ds *dockerService) ListPodSandbox(filter *runtimeapi.PodSandboxFilter) ([]
 	// These PodSandbox will only include PodSandboxID, Name, Namespace.
 	// These PodSandbox will be in PodSandboxState_SANDBOX_NOTREADY state.
 	if filter == nil {
-		checkpoints, err := ds.checkpointHandler.ListCheckpoints()
+		// Bulk-load via the checkpoint cache; corrupt checkpoints are removed
+		// by the handler along the way.
+		checkpoints, err := ds.checkpointHandler.GetCheckpoints(nil)
 		if err != nil {
 			glog.Errorf("Failed to list checkpoints: %v", err)
 		}
-		for _, id := range checkpoints {
+		for id, checkpoint := range checkpoints {
 			if _, ok := sandboxIDs[id]; ok {
 				continue
 			}
-			checkpoint, err := ds.checkpointHandler.GetCheckpoint(id)
-			if err != nil {
-				glog.Errorf("Failed to retrieve checkpoint for sandbox %q: %v", id, err)
-
-				if err == errors.CorruptCheckpointError {
-					glog.V(2).Info("Removing corrupted checkpoint %q: %+v", id, *checkpoint)
-					ds.checkpointHandler.RemoveCheckpoint(id)
-				}
-				continue
-			}
 			result = append(result, checkpointToRuntimeAPISandbox(id, checkpoint))
 		}
 	}
@@ -423,17 +729,34 @@ func (ds *dockerService) makeSandboxDockerConfig(c *runtimeapi.PodSandboxConfig,
 	// TODO(random-liu): Deprecate this label once container metrics is directly got from CRI.
 	labels[types.KubernetesContainerNameLabel] = sandboxContainerName
 
-	apiVersion, err := ds.getDockerAPIVersion()
+	// Record an audit trail of how the sandbox came to be, so pod state can
+	// be reconstructed from docker state alone during post-mortems.
+	labels[auditPodUIDLabelKey] = c.GetMetadata().Uid
+	labels[auditAttemptLabelKey] = strconv.FormatUint(uint64(c.GetMetadata().Attempt), 10)
+	labels[auditKubeletIDLabelKey] = ds.kubeletInstanceID
+	if c.GetMetadata().Attempt == 0 {
+		labels[auditCreationReasonLabelKey] = "create"
+	} else {
+		// A non-zero attempt means the kubelet is recreating the sandbox,
+		// e.g. after the previous one died or was evicted.
+		labels[auditCreationReasonLabelKey] = "recreate"
+	}
+
+	securityOptSep, err := ds.securityOptSeparator()
 	if err != nil {
 		return nil, fmt.Errorf("unable to get the docker API version: %v", err)
 	}
-	securityOptSep := getSecurityOptSeparator(apiVersion)
+
+	hostname, err := ds.sandboxHostname(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine hostname for sandbox %q: %v", c.Metadata.Name, err)
+	}
 
 	hc := &dockercontainer.HostConfig{}
 	createConfig := &dockertypes.ContainerCreateConfig{
 		Name: makeSandboxName(c),
 		Config: &dockercontainer.Config{
-			Hostname: c.Hostname,
+			Hostname: hostname,
 			// TODO: Handle environment variables.
 			Image:  image,
 			Labels: labels,
@@ -441,10 +764,44 @@ func (ds *dockerService) makeSandboxDockerConfig(c *runtimeapi.PodSandboxConfig,
 		HostConfig: hc,
 	}
 
+	// Set port mappings. With the shim-managed hostport manager enabled, the
+	// mappings are programmed via iptables after network setup instead of
+	// docker's publish mechanism.
+	if !ds.featureGate.Enabled(hostportManagerFeature) {
+		exposedPorts, portBindings := makePortsAndBindings(c.GetPortMappings())
+		createConfig.Config.ExposedPorts = exposedPorts
+		hc.PortBindings = portBindings
+	}
+
+	// DNS is deliberately not passed to docker: docker caps search domains
+	// at six, which is too few for the cluster suffix list of some pods. The
+	// shim writes the sandbox's resolv.conf itself after creation instead
+	// (see applySandboxDNS).
+
+	// On Windows none of the Linux cgroup and security machinery below
+	// exists; the sandbox is configured with an isolation technology and a
+	// Windows network mode instead.
+	if sandboxPlatformWindows() {
+		// There is no resolv.conf to manage on Windows; the DNS config is
+		// passed to docker directly.
+		if dnsConfig := c.GetDnsConfig(); dnsConfig != nil {
+			hc.DNS = dnsConfig.Servers
+			hc.DNSSearch = dnsConfig.Searches
+			hc.DNSOptions = dnsConfig.Options
+		}
+		if err := ds.applySandboxWindowsOptions(hc, c); err != nil {
+			return nil, err
+		}
+		return createConfig, nil
+	}
+
 	// Set sysctls if requested
-	sysctls, err := getSysctlsFromAnnotations(c.Annotations)
+	sysctls, err := getSandboxSysctls(c)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get sysctls from annotations %v for sandbox %q: %v", c.Annotations, c.Metadata.Name, err)
+		return nil, fmt.Errorf("failed to get sysctls for sandbox %q: %v", c.Metadata.Name, err)
+	}
+	if err := ds.namespacePolicies.validateSysctls(c.GetMetadata().Namespace, sysctls); err != nil {
+		return nil, fmt.Errorf("failed to validate sysctls for sandbox %q: %v", c.Metadata.Name, err)
 	}
 	hc.Sysctls = sysctls
 
@@ -455,27 +812,69 @@ func (ds *dockerService) makeSandboxDockerConfig(c *runtimeapi.PodSandboxConfig,
 		}
 	}
 
-	// Set port mappings.
-	exposedPorts, portBindings := makePortsAndBindings(c.GetPortMappings())
-	createConfig.Config.ExposedPorts = exposedPorts
-	hc.PortBindings = portBindings
-
-	// Set DNS options.
-	if dnsConfig := c.GetDnsConfig(); dnsConfig != nil {
-		hc.DNS = dnsConfig.Servers
-		hc.DNSSearch = dnsConfig.Searches
-		hc.DNSOptions = dnsConfig.Options
-	}
+	// Apply resource options. When the sandbox is placed directly in the
+	// pod-level cgroup, leave the resources unset so the pause overhead is
+	// charged against the pod's own limits.
+	podCgroup := ds.featureGate.Enabled(sandboxPodCgroupFeature) && c.GetLinux() != nil && c.GetLinux().CgroupParent != ""
+	setSandboxResources(hc, podCgroup)
+	// Let the configured OOM policy override the default sandbox score.
+	hc.OomScoreAdj = ds.oomPolicy.sandboxOOMScoreAdj(c)
 
-	// Apply resource options.
-	setSandboxResources(hc)
+	// Apply the user namespace mode if the daemon runs with userns-remap.
+	ds.applyUserNamespaceMode(c.GetLinux().GetSecurityContext().GetNamespaceOptions(), hc)
 
-	// Set security options.
-	securityOpts, err := getSandboxSecurityOpts(c, ds.seccompProfileRoot, securityOptSep)
+	// Set security options, including the defaults from the configured
+	// security profile.
+	securityOpts, err := getSandboxSecurityOpts(c, ds.seccompProfiles, securityOptSep, ds.securityProfile, ds.namespacePolicies.seccompProfile(c.GetMetadata().Namespace))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate sandbox security options for sandbox %q: %v", c.Metadata.Name, err)
 	}
 	hc.SecurityOpt = append(hc.SecurityOpt, securityOpts...)
+	ds.securityProfile.applyHostConfig(hc)
+
+	// Harden the pause container with a read-only root filesystem when
+	// enabled cluster-wide; /tmp and /run stay writable as tmpfs so the
+	// pause binary and debugging exec sessions keep working.
+	if ds.featureGate.Enabled(sandboxReadOnlyRootfsFeature) {
+		hc.ReadonlyRootfs = true
+		hc.Tmpfs = map[string]string{
+			"/tmp": "rw,noexec,nosuid,size=65536k",
+			"/run": "rw,noexec,nosuid,size=65536k",
+		}
+	}
+
+	// Grant the requested network capabilities to the pause container, so
+	// CNI plugins that configure routes from inside the netns do not need a
+	// privileged infrastructure container.
+	capabilities, err := sandboxCapabilities(c.GetAnnotations())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities for sandbox %q: %v", c.Metadata.Name, err)
+	}
+	hc.CapAdd = append(hc.CapAdd, capabilities...)
+
+	// Select an alternative OCI runtime if the pod requests one, falling
+	// back to the namespace's default runtime.
+	if runtime := ds.sandboxRuntimeFor(c); runtime != "" {
+		if err := ds.validateSandboxRuntime(runtime); err != nil {
+			return nil, fmt.Errorf("failed to validate OCI runtime for sandbox %q: %v", c.Metadata.Name, err)
+		}
+		hc.Runtime = runtime
+	}
+
+	// Size the pod's /dev/shm as requested; the workload containers join
+	// the sandbox's IPC namespace and share it.
+	shmSize, err := sandboxShmSize(c.GetAnnotations())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shm size for sandbox %q: %v", c.Metadata.Name, err)
+	}
+	if shmSize > 0 {
+		hc.ShmSize = shmSize
+	}
+
+	// Apply the allowlisted hostconfig extension annotations.
+	if err := ds.hostConfigExtensions.applySandbox(c, hc); err != nil {
+		return nil, fmt.Errorf("failed to apply hostconfig extensions for sandbox %q: %v", c.Metadata.Name, err)
+	}
 	return createConfig, nil
 }
 
@@ -488,11 +887,17 @@ func sharesHostNetwork(container *dockertypes.ContainerJSON) bool {
 	return false
 }
 
-func setSandboxResources(hc *dockercontainer.HostConfig) {
-	hc.Resources = dockercontainer.Resources{
-		MemorySwap: dockertools.DefaultMemorySwap(),
-		CPUShares:  defaultSandboxCPUshares,
-		// Use docker's default cpu quota/period.
+// setSandboxResources sets the sandbox resource options. With podCgroup set,
+// the sandbox runs directly inside the pod-level cgroup and no dedicated CPU
+// shares or swap settings are applied, so node allocatable accounting sees
+// the real pause overhead.
+func setSandboxResources(hc *dockercontainer.HostConfig, podCgroup bool) {
+	if !podCgroup {
+		hc.Resources = dockercontainer.Resources{
+			MemorySwap: dockertools.DefaultMemorySwap(),
+			CPUShares:  defaultSandboxCPUshares,
+			// Use docker's default cpu quota/period.
+		}
 	}
 	// TODO: Get rid of the dependency on kubelet internal package.
 	hc.OomScoreAdj = qos.PodInfraOOMAdj
@@ -500,14 +905,37 @@ func setSandboxResources(hc *dockercontainer.HostConfig) {
 
 func constructPodSandboxCheckpoint(config *runtimeapi.PodSandboxConfig) *PodSandboxCheckpoint {
 	checkpoint := NewPodSandboxCheckpoint(config.Metadata.Namespace, config.Metadata.Name)
+	checkpoint.Data.Attempt = config.GetMetadata().Attempt
 	for _, pm := range config.GetPortMappings() {
 		proto := toCheckpointProtocol(pm.Protocol)
 		checkpoint.Data.PortMappings = append(checkpoint.Data.PortMappings, &PortMapping{
 			HostPort:      &pm.HostPort,
 			ContainerPort: &pm.ContainerPort,
 			Protocol:      &proto,
+			HostIp:        pm.HostIp,
 		})
 	}
+	if dnsConfig := config.GetDnsConfig(); dnsConfig != nil {
+		checkpoint.Data.DNSConfig = &DNSConfig{
+			Servers:  dnsConfig.Servers,
+			Searches: dnsConfig.Searches,
+			Options:  dnsConfig.Options,
+		}
+	}
+	if lc := config.GetLinux(); lc != nil {
+		checkpoint.Data.CgroupParent = lc.CgroupParent
+		if nsOptions := lc.GetSecurityContext().GetNamespaceOptions(); nsOptions != nil {
+			checkpoint.Data.HostNetwork = nsOptions.HostNetwork
+		}
+	}
+	if annotations := config.GetAnnotations(); len(annotations) > 0 {
+		checkpoint.Data.Annotations = annotations
+	}
+	// Only valid static IP requests become part of the checkpoint; invalid
+	// values are dropped by the network plugin anyway.
+	if requested := config.GetAnnotations()[network.PodRequestedIPAnnotation]; net.ParseIP(requested) != nil {
+		checkpoint.Data.RequestedIP = requested
+	}
 	return checkpoint
 }
 
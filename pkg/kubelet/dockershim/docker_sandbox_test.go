@@ -22,10 +22,16 @@ import (
 	"testing"
 	"time"
 
+	dockercontainer "github.com/docker/engine-api/types/container"
 	"github.com/stretchr/testify/assert"
 
+	utilsets "k8s.io/apimachinery/pkg/util/sets"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 )
 
@@ -141,6 +147,40 @@ func TestSandboxStatus(t *testing.T) {
 	assert.Error(t, err, fmt.Sprintf("status of sandbox: %+v", status))
 }
 
+// TestConstructPodSandboxCheckpoint checks that the checkpoint preserves the
+// DNS config, host network flag, cgroup parent, annotations and attempt of
+// the sandbox.
+func TestConstructPodSandboxCheckpoint(t *testing.T) {
+	config := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 3,
+		map[string]string{"label": "foobar"},
+		map[string]string{"annotation": "abc"},
+	)
+	config.DnsConfig = &runtimeapi.DNSConfig{
+		Servers:  []string{"10.0.0.10"},
+		Searches: []string{"cluster.local"},
+		Options:  []string{"ndots:5"},
+	}
+	config.Linux = &runtimeapi.LinuxPodSandboxConfig{
+		CgroupParent: "/kubepods/burstable",
+		SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+			NamespaceOptions: &runtimeapi.NamespaceOption{
+				HostNetwork: true,
+			},
+		},
+	}
+
+	checkpoint := constructPodSandboxCheckpoint(config)
+	assert.Equal(t, &DNSConfig{
+		Servers:  []string{"10.0.0.10"},
+		Searches: []string{"cluster.local"},
+		Options:  []string{"ndots:5"},
+	}, checkpoint.Data.DNSConfig)
+	assert.True(t, checkpoint.Data.HostNetwork)
+	assert.Equal(t, "/kubepods/burstable", checkpoint.Data.CgroupParent)
+	assert.Equal(t, config.Annotations, checkpoint.Data.Annotations)
+	assert.Equal(t, uint32(3), checkpoint.Data.Attempt)
+}
+
 // TestNetworkPluginInvocation checks that the right SetUpPod and TearDownPod
 // calls are made when we run/stop a sandbox.
 func TestNetworkPluginInvocation(t *testing.T) {
@@ -200,3 +240,163 @@ func TestHostNetworkPluginInvocation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, ds.StopPodSandbox(cID.ID))
 }
+
+// TestUpdatePodSandboxNetwork checks that a live network update re-invokes
+// the plugin's SetUpPod against the existing sandbox, and only when the
+// plugin advertises support for it.
+func TestUpdatePodSandboxNetwork(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	mockPlugin := newTestNetworkPlugin(t)
+	ds.networkPlugin = mockPlugin
+	defer mockPlugin.Finish()
+
+	name := "foo0"
+	ns := "bar0"
+	c := makeSandboxConfig(name, ns, "0", 0)
+	cID := kubecontainer.ContainerID{Type: runtimeName, ID: fmt.Sprintf("/%v", makeSandboxName(c))}
+
+	// One SetUpPod for the sandbox creation, a second one for the update.
+	mockPlugin.EXPECT().SetUpPod(ns, name, cID).Times(2)
+	mockPlugin.EXPECT().Capabilities().Return(utilsets.NewInt(network.NET_PLUGIN_CAPABILITY_LIVE_RECONFIGURE))
+
+	_, err := ds.RunPodSandbox(c)
+	assert.NoError(t, err)
+	assert.NoError(t, ds.UpdatePodSandboxNetwork(cID.ID))
+
+	// Plugins that do not advertise the capability are never invoked.
+	mockPlugin.EXPECT().Capabilities().Return(utilsets.NewInt())
+	mockPlugin.EXPECT().Name().Return("mockNetworkPlugin")
+	assert.Error(t, ds.UpdatePodSandboxNetwork(cID.ID))
+}
+
+// TestSandboxCheckpointRequestedIP verifies that a valid static IP request
+// is recorded in the sandbox checkpoint, and invalid requests are dropped.
+func TestSandboxCheckpointRequestedIP(t *testing.T) {
+	c := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{}, map[string]string{network.PodRequestedIPAnnotation: "10.1.2.3"})
+	assert.Equal(t, "10.1.2.3", constructPodSandboxCheckpoint(c).Data.RequestedIP)
+
+	c.Annotations[network.PodRequestedIPAnnotation] = "not-an-ip"
+	assert.Equal(t, "", constructPodSandboxCheckpoint(c).Data.RequestedIP)
+}
+
+// TestSetSandboxResources tests that the default sandbox resource allotment
+// is skipped when the sandbox is placed directly in the pod-level cgroup.
+func TestSetSandboxResources(t *testing.T) {
+	hc := &dockercontainer.HostConfig{}
+	setSandboxResources(hc, false)
+	assert.Equal(t, defaultSandboxCPUshares, hc.Resources.CPUShares)
+	assert.Equal(t, dockertools.DefaultMemorySwap(), hc.Resources.MemorySwap)
+
+	hc = &dockercontainer.HostConfig{}
+	setSandboxResources(hc, true)
+	assert.Equal(t, dockercontainer.Resources{}, hc.Resources)
+	assert.Equal(t, qos.PodInfraOOMAdj, hc.OomScoreAdj)
+}
+
+// TestPodIPCache tests that plugin-reported pod IPs are cached per sandbox
+// and invalidated on teardown.
+func TestPodIPCache(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	_, ok := ds.getCachedPodIP("foo")
+	assert.False(t, ok)
+
+	ds.cachePodIP("foo", "10.1.2.3")
+	ip, ok := ds.getCachedPodIP("foo")
+	assert.True(t, ok)
+	assert.Equal(t, "10.1.2.3", ip)
+
+	ds.forgetPodIP("foo")
+	_, ok = ds.getCachedPodIP("foo")
+	assert.False(t, ok)
+}
+
+// TestRunPodSandboxLogDirectory checks that the pod log directory is created
+// when the sandbox is started.
+func TestRunPodSandboxLogDirectory(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	created := []string{}
+	ds.os.(*containertest.FakeOS).MkdirAllFn = func(path string, perm os.FileMode) error {
+		created = append(created, path)
+		return nil
+	}
+
+	c := makeSandboxConfig("foo", "bar", "1", 0)
+	c.LogDirectory = "/var/log/pods/1"
+	_, err := ds.RunPodSandbox(c)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/var/log/pods/1"}, created)
+}
+
+// TestStopPodSandboxStopsContainers checks that stopping a sandbox stops the
+// workload containers running in it.
+func TestStopPodSandboxStopsContainers(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+	sandboxID, err := ds.RunPodSandbox(config)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		cConfig := makeContainerConfig(config, fmt.Sprintf("c%d", i), "iamimage", uint32(i), nil, nil)
+		id, err := ds.CreateContainer(sandboxID, cConfig, config)
+		assert.NoError(t, err)
+		assert.NoError(t, ds.StartContainer(id))
+	}
+
+	assert.NoError(t, ds.StopPodSandbox(sandboxID))
+
+	containers, err := ds.ListContainers(&runtimeapi.ContainerFilter{PodSandboxId: sandboxID})
+	assert.NoError(t, err)
+	assert.Len(t, containers, 2)
+	for _, c := range containers {
+		assert.Equal(t, runtimeapi.ContainerState_CONTAINER_EXITED, c.State)
+	}
+}
+
+// TestSandboxAuditLabels verifies the audit trail recorded on sandbox
+// containers at creation time.
+func TestSandboxAuditLabels(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	ds.kubeletInstanceID = "kubelet-1"
+
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "uid1", 0))
+	assert.NoError(t, err)
+	c, err := fDocker.InspectContainer(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "uid1", c.Config.Labels[auditPodUIDLabelKey])
+	assert.Equal(t, "0", c.Config.Labels[auditAttemptLabelKey])
+	assert.Equal(t, "kubelet-1", c.Config.Labels[auditKubeletIDLabelKey])
+	assert.Equal(t, "create", c.Config.Labels[auditCreationReasonLabelKey])
+
+	id, err = ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "uid1", 1))
+	assert.NoError(t, err)
+	c, err = fDocker.InspectContainer(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "recreate", c.Config.Labels[auditCreationReasonLabelKey])
+
+	// Audit labels are internal to the shim and must not leak into the CRI
+	// view of the sandbox.
+	status, err := ds.PodSandboxStatus(id)
+	assert.NoError(t, err)
+	assert.NotContains(t, status.Labels, auditPodUIDLabelKey)
+}
+
+// TestSandboxReadOnlyRootfs tests that the read-only rootfs feature gate
+// hardens the pause container while keeping /tmp and /run writable.
+func TestSandboxReadOnlyRootfs(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+
+	createConfig, err := ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	assert.NoError(t, err)
+	assert.False(t, createConfig.HostConfig.ReadonlyRootfs)
+	assert.Empty(t, createConfig.HostConfig.Tmpfs)
+
+	assert.NoError(t, ds.featureGate.set(fmt.Sprintf("%s=true", sandboxReadOnlyRootfsFeature)))
+	createConfig, err = ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	assert.NoError(t, err)
+	assert.True(t, createConfig.HostConfig.ReadonlyRootfs)
+	assert.Contains(t, createConfig.HostConfig.Tmpfs, "/tmp")
+	assert.Contains(t, createConfig.HostConfig.Tmpfs, "/run")
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package dockershim
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
 	dockertypes "github.com/docker/engine-api/types"
+	dockerfilters "github.com/docker/engine-api/types/filters"
 	"github.com/golang/glog"
 
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/clock"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/kubernetes/pkg/api/v1"
 	"k8s.io/kubernetes/pkg/apis/componentconfig"
 	internalapi "k8s.io/kubernetes/pkg/kubelet/api"
@@ -32,14 +38,21 @@ import (
 	kubecm "k8s.io/kubernetes/pkg/kubelet/cm"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/cm"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/convert"
 	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/kubelet/network"
 	"k8s.io/kubernetes/pkg/kubelet/network/cni"
 	"k8s.io/kubernetes/pkg/kubelet/network/hostport"
 	"k8s.io/kubernetes/pkg/kubelet/network/kubenet"
 	"k8s.io/kubernetes/pkg/kubelet/server/streaming"
+	"k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/kubelet/util/cache"
+	"k8s.io/kubernetes/pkg/util/bandwidth"
+	utildbus "k8s.io/kubernetes/pkg/util/dbus"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
 )
 
 const (
@@ -59,14 +72,20 @@ const (
 	dockershimRootDir = "/var/lib/dockershim"
 
 	// Internal docker labels used to identify whether a container is a sandbox
-	// or a regular container.
+	// or a regular container. The canonical definitions live in the convert
+	// sub-package.
 	// TODO: This is not backward compatible with older containers. We will
 	// need to add filtering based on names.
-	containerTypeLabelKey       = "io.kubernetes.docker.type"
-	containerTypeLabelSandbox   = "podsandbox"
-	containerTypeLabelContainer = "container"
-	containerLogPathLabelKey    = "io.kubernetes.container.logpath"
-	sandboxIDLabelKey           = "io.kubernetes.sandbox.id"
+	containerTypeLabelKey       = convert.ContainerTypeLabelKey
+	containerTypeLabelSandbox   = convert.ContainerTypeLabelSandbox
+	containerTypeLabelContainer = convert.ContainerTypeLabelContainer
+	containerLogPathLabelKey    = convert.ContainerLogPathLabelKey
+	sandboxIDLabelKey           = convert.SandboxIDLabelKey
+	imageDigestLabelKey         = convert.ImageDigestLabelKey
+	auditPodUIDLabelKey         = convert.AuditPodUIDLabelKey
+	auditAttemptLabelKey        = convert.AuditAttemptLabelKey
+	auditKubeletIDLabelKey      = convert.AuditKubeletIDLabelKey
+	auditCreationReasonLabelKey = convert.AuditCreationReasonLabelKey
 
 	// The expiration time of version cache.
 	versionCacheTTL = 60 * time.Second
@@ -103,6 +122,10 @@ type NetworkPluginSettings struct {
 	PluginConfDir string
 	// MTU is the desired MTU for network devices created by the plugin.
 	MTU int
+	// CallTimeout bounds each call into the network plugin so a hung plugin
+	// cannot block sandbox creation forever. Zero means the shim default;
+	// a negative value disables the bound.
+	CallTimeout time.Duration
 
 	// RuntimeHost is an interface that serves as a trap-door from plugin back
 	// into the kubelet.
@@ -141,44 +164,193 @@ type dockerNetworkHost struct {
 	*portMappingGetter
 }
 
-var internalLabelKeys []string = []string{containerTypeLabelKey, containerLogPathLabelKey, sandboxIDLabelKey}
+var internalLabelKeys []string = convert.InternalLabelKeys
+
+// Config collects the settings for NewDockerService.
+type Config struct {
+	// Client talks to the docker daemon.
+	Client dockertools.DockerInterface
+	// SeccompProfileRoot is the directory localhost/ seccomp profiles are
+	// resolved against.
+	SeccompProfileRoot string
+	// PodSandboxImage is the image used for the sandbox (infra) container.
+	PodSandboxImage string
+	// StreamingConfig configures the exec/attach/port-forward streaming
+	// server; nil disables streaming.
+	StreamingConfig *streaming.Config
+	// PluginSettings configures the network plugin.
+	PluginSettings *NetworkPluginSettings
+	// CgroupsName is the cgroup the docker daemon's containers run under.
+	CgroupsName string
+	// CgroupDriver is the cgroup driver the kubelet expects; it must match
+	// the daemon's driver when set.
+	CgroupDriver string
+	// ExecHandler runs exec requests in containers.
+	ExecHandler dockertools.ExecHandler
+	// FeatureGates is the comma-separated shim feature gate assignment, e.g.
+	// "CheckpointRestore=true".
+	FeatureGates string
+	// OOMPolicy names the policy applied to containers' oom_score_adj.
+	OOMPolicy string
+	// SecurityProfile names the baseline security profile for containers.
+	SecurityProfile string
+	// HostConfigExtensions is the allowlist of annotation-driven HostConfig
+	// extensions.
+	HostConfigExtensions string
+	// UlimitCaps is the configuration bounding annotation-requested ulimits.
+	UlimitCaps string
+	// ImagePolicyEndpoint is the URL of the image admission webhook; empty
+	// disables image policy checks.
+	ImagePolicyEndpoint string
+	// CredentialHelpers is the configuration mapping registries to
+	// credential helper binaries.
+	CredentialHelpers string
+	// NetworkPolicyHook is the configuration for the sandbox network policy
+	// hook binary.
+	NetworkPolicyHook string
+	// NamespacePolicyFile is the path of the namespace sharing policy file;
+	// empty applies the defaults.
+	NamespacePolicyFile string
+	// ClusterDomain is the cluster's DNS domain.
+	ClusterDomain string
+	// MinimumDockerAPIVersion is the oldest daemon API version the shim will
+	// run against; empty applies the shim default.
+	MinimumDockerAPIVersion string
+	// SandboxGCPolicy configures garbage collection of dead sandboxes; nil
+	// disables it.
+	SandboxGCPolicy *SandboxGCPolicy
+	// PullLimits throttles image pulls; nil leaves pulls unthrottled.
+	PullLimits *ImagePullLimits
+}
 
 // NOTE: Anything passed to DockerService should be eventually handled in another way when we switch to running the shim as a different process.
-func NewDockerService(client dockertools.DockerInterface, seccompProfileRoot string, podSandboxImage string, streamingConfig *streaming.Config,
-	pluginSettings *NetworkPluginSettings, cgroupsName string, kubeCgroupDriver string, execHandler dockertools.ExecHandler) (DockerService, error) {
-	c := dockertools.NewInstrumentedDockerInterface(client)
+func NewDockerService(config *Config) (DockerService, error) {
+	// Register the shim's prometheus metrics; the kubelet serves them on
+	// its metrics endpoint.
+	metrics.Register()
+	// The circuit breaker fails operations fast while the daemon is hung, so
+	// the kubelet's node status loop notices an unhealthy runtime quickly.
+	c := newClassifiedDockerInterface(dockertools.NewInstrumentedDockerInterface(dockertools.NewCircuitBreakerDockerInterface(config.Client)))
+	fg := newShimFeatureGate()
+	if err := fg.set(config.FeatureGates); err != nil {
+		return nil, err
+	}
+	glog.Infof("Dockershim feature gates: %s", fg)
+	oomPolicy, err := newOOMPolicy(config.OOMPolicy)
+	if err != nil {
+		return nil, err
+	}
+	securityProfile, err := newSecurityProfile(config.SecurityProfile)
+	if err != nil {
+		return nil, err
+	}
+	hostConfigExtensions, err := newHostConfigExtensions(config.HostConfigExtensions)
+	if err != nil {
+		return nil, err
+	}
+	ulimitCaps, err := newUlimitCaps(config.UlimitCaps)
+	if err != nil {
+		return nil, err
+	}
+	var imgPolicy imagePolicy
+	if config.ImagePolicyEndpoint != "" {
+		imgPolicy = newWebhookImagePolicy(config.ImagePolicyEndpoint)
+	}
+	credHelpers, err := newCredentialHelpers(config.CredentialHelpers)
+	if err != nil {
+		return nil, err
+	}
+	npHook, err := newNetworkPolicyHook(config.NetworkPolicyHook)
+	if err != nil {
+		return nil, err
+	}
+	nsPolicies, err := newNamespacePolicies(config.NamespacePolicyFile)
+	if err != nil {
+		return nil, err
+	}
 	ds := &dockerService{
-		seccompProfileRoot: seccompProfileRoot,
-		client:             c,
-		os:                 kubecontainer.RealOS{},
-		podSandboxImage:    podSandboxImage,
+		seccompProfiles:      newSeccompProfileCache(config.SeccompProfileRoot),
+		featureGate:          fg,
+		oomPolicy:            oomPolicy,
+		securityProfile:      securityProfile,
+		hostConfigExtensions: hostConfigExtensions,
+		ulimitCaps:           ulimitCaps,
+		client:               c,
+		os:                   kubecontainer.RealOS{},
+		podSandboxImage:      config.PodSandboxImage,
+		clusterDomain:        config.ClusterDomain,
+		// A fresh ID per shim instance; sandboxes created before the latest
+		// kubelet restart are distinguishable by their audit labels.
+		kubeletInstanceID: string(uuid.NewUUID()),
 		streamingRuntime: &streamingRuntime{
-			client:      client,
-			execHandler: execHandler,
+			client:         config.Client,
+			execHandler:    config.ExecHandler,
+			execSupervisor: newExecSupervisor(defaultMaxExecsPerContainer),
 		},
-		containerManager:  cm.NewContainerManager(cgroupsName, client),
+		containerManager:  cm.NewContainerManager(config.CgroupsName, config.Client),
 		checkpointHandler: NewPersistentCheckpointHandler(),
+		legacyMigration:   newLegacyMigration(),
+		podIPCache:        make(map[string]string),
+		sandboxChurn:      newSandboxChurn(clock.RealClock{}),
+		imageUsage:        newImageUsageTracker(clock.RealClock{}),
+		pullCoalescer:     newPullCoalescer(),
+		imagePolicy:       imgPolicy,
+		credentialHelpers: credHelpers,
+		networkPolicyHook: npHook,
+		namespacePolicies: nsPolicies,
+		sandboxAdmission:  defaultSandboxAdmissionPlugins,
+		events:            newEventStream(),
+		labels:            newLabelIndex(),
+		iptables:          utiliptables.New(utilexec.New(), utildbus.New(), utiliptables.ProtocolIpv4),
 	}
-	if streamingConfig != nil {
+	ds.netns = newNetnsManager(ds.os)
+	ds.lifecycleHooks = newLifecycleHookRunner(ds.ExecSync)
+	if config.StreamingConfig != nil {
 		var err error
-		ds.streamingServer, err = streaming.NewServer(*streamingConfig, ds.streamingRuntime)
+		ds.streamingServer, err = streaming.NewServer(*config.StreamingConfig, ds.streamingRuntime)
 		if err != nil {
 			return nil, err
 		}
+		// When the config carries its own listen address the shim serves the
+		// exec/attach/port-forward streams itself (with the TLS settings and
+		// single-use tokens from the config); otherwise the kubelet is
+		// expected to proxy the requests to ds.ServeHTTP.
+		ds.startLocalStreamingServer = config.StreamingConfig.Addr != ""
+	}
+	if config.SandboxGCPolicy != nil {
+		ds.sandboxGC = newSandboxGC(ds, *config.SandboxGCPolicy)
+	}
+	if config.PullLimits != nil {
+		ds.serializePulls = config.PullLimits.Serialized
+		if config.PullLimits.QPS > 0 {
+			ds.pullLimiter = flowcontrol.NewTokenBucketRateLimiter(config.PullLimits.QPS, config.PullLimits.Burst)
+		}
+		if len(config.PullLimits.Mirrors) > 0 {
+			ds.pullMirrors = newRegistryMirrors(config.PullLimits.Mirrors)
+		}
 	}
 	// dockershim currently only supports CNI plugins.
-	cniPlugins := cni.ProbeNetworkPlugins(pluginSettings.PluginConfDir, pluginSettings.PluginBinDir)
-	cniPlugins = append(cniPlugins, kubenet.NewPlugin(pluginSettings.PluginBinDir))
+	cniPlugins := cni.ProbeNetworkPlugins(config.PluginSettings.PluginConfDir, config.PluginSettings.PluginBinDir)
+	cniPlugins = append(cniPlugins, kubenet.NewPlugin(config.PluginSettings.PluginBinDir))
 	netHost := &dockerNetworkHost{
-		pluginSettings.LegacyRuntimeHost,
+		config.PluginSettings.LegacyRuntimeHost,
 		&namespaceGetter{ds},
 		&portMappingGetter{ds},
 	}
-	plug, err := network.InitNetworkPlugin(cniPlugins, pluginSettings.PluginName, netHost, pluginSettings.HairpinMode, pluginSettings.NonMasqueradeCIDR, pluginSettings.MTU)
+	plug, err := network.InitNetworkPlugin(cniPlugins, config.PluginSettings.PluginName, netHost, config.PluginSettings.HairpinMode, config.PluginSettings.NonMasqueradeCIDR, config.PluginSettings.MTU)
 	if err != nil {
-		return nil, fmt.Errorf("didn't find compatible CNI plugin with given settings %+v: %v", pluginSettings, err)
+		return nil, fmt.Errorf("didn't find compatible CNI plugin with given settings %+v: %v", config.PluginSettings, err)
 	}
 	ds.networkPlugin = plug
+	ds.pluginBinDir = config.PluginSettings.PluginBinDir
+	ds.networkCallTimeout = config.PluginSettings.CallTimeout
+	if ds.networkCallTimeout == 0 {
+		ds.networkCallTimeout = defaultNetworkCallTimeout
+	}
+	ds.teardownDispatcher = newTeardownDispatcher(func(namespace, name string, id kubecontainer.ContainerID) error {
+		return ds.networkTearDownPod(namespace, name, id)
+	}, defaultTeardownWorkers)
+	ds.teardownQueue = newTeardownRetryQueue(ds.teardownDispatcher.dispatch)
 	glog.Infof("Docker cri networking managed by %v", plug.Name())
 
 	// NOTE: cgroup driver is only detectable in docker 1.11+
@@ -193,17 +365,59 @@ func NewDockerService(client dockertools.DockerInterface, seccompProfileRoot str
 	} else {
 		cgroupDriver = dockerInfo.CgroupDriver
 	}
-	if len(kubeCgroupDriver) != 0 && kubeCgroupDriver != cgroupDriver {
-		return nil, fmt.Errorf("misconfiguration: kubelet cgroup driver: %q is different from docker cgroup driver: %q", kubeCgroupDriver, cgroupDriver)
+	if len(config.CgroupDriver) != 0 && config.CgroupDriver != cgroupDriver {
+		return nil, fmt.Errorf("misconfiguration: kubelet cgroup driver: %q is different from docker cgroup driver: %q", config.CgroupDriver, cgroupDriver)
 	}
 	glog.Infof("Setting cgroupDriver to %s", cgroupDriver)
 	ds.cgroupDriver = cgroupDriver
+	ds.cgroupV2 = isCgroupV2UnifiedMode()
+	if ds.cgroupV2 {
+		glog.Infof("Node is running with the unified cgroup hierarchy (cgroup v2)")
+	}
+	// Detect userns-remap mode so containers can be placed in (or opted out
+	// of) the remapped user namespace.
+	if err == nil && usernsRemapEnabled(dockerInfo) {
+		ds.usernsRemapped = true
+		uid, gid, idErr := parseRemappedRootIDs(dockerInfo.DockerRootDir)
+		if idErr != nil {
+			glog.Warningf("Docker userns-remap is enabled but the remapped root could not be determined: %v", idErr)
+		} else {
+			ds.remappedRootUID, ds.remappedRootGID = uid, gid
+		}
+		glog.Infof("Docker daemon is running with userns-remap enabled (remapped root %d:%d)", ds.remappedRootUID, ds.remappedRootGID)
+	}
+	// Detect whether the graph driver can enforce per-container storage
+	// limits; the result is reported through the runtime status.
+	if err == nil {
+		ds.storageOptSupported, ds.storageOptReason = storageOptSupport(dockerInfo.Driver, dockerInfo.DriverStatus)
+	} else {
+		ds.storageOptReason = "docker info unavailable, assuming storage limits are not enforced"
+	}
+	if !ds.storageOptSupported {
+		glog.Infof("Storage limits are not enforced: %s", ds.storageOptReason)
+	}
+	// Detect whether the docker root lives on a shared mount; bidirectional
+	// mount propagation is rejected when it does not.
+	if err == nil {
+		ds.sharedMountSupported, ds.sharedMountReason = sharedMountSupport(dockerInfo.DockerRootDir)
+	} else {
+		ds.sharedMountReason = "docker info unavailable, assuming shared mounts are not supported"
+	}
+	if !ds.sharedMountSupported {
+		glog.Infof("Bidirectional mount propagation is not available: %s", ds.sharedMountReason)
+	}
 	ds.versionCache = cache.NewObjectCache(
 		func() (interface{}, error) {
 			return ds.getDockerVersion()
 		},
 		versionCacheTTL,
 	)
+	// Negotiate the docker API version once so per-operation paths do not
+	// have to, and refuse to run against a daemon older than the minimum.
+	if err := ds.negotiateDockerVersion(config.MinimumDockerAPIVersion); err != nil {
+		return nil, err
+	}
+	ds.inspectCache = newInspectCache(inspectCacheTTL, clock.RealClock{})
 	return ds, nil
 }
 
@@ -212,30 +426,236 @@ func NewDockerService(client dockertools.DockerInterface, seccompProfileRoot str
 type DockerService interface {
 	internalapi.RuntimeService
 	internalapi.ImageManagerService
+	ContainerEventService
+	PaginatedListService
+	ImageFsInfoService
+	ContainerStatsService
+	ImagePrePullService
+	ImageGCService
+	PodSandboxNetworkUpdateService
+	ContainerResourceUpdateService
 	Start() error
 	// For serving streaming calls.
 	http.Handler
 }
 
+// ContainerEventService is implemented by runtimes that publish a structured
+// container event stream.
+type ContainerEventService interface {
+	// SubscribeContainerEvents subscribes to the container event stream. The
+	// returned function cancels the subscription and closes the channel.
+	SubscribeContainerEvents() (<-chan *runtimeapi.ContainerEvent, func())
+}
+
+// PaginatedListService is implemented by runtimes that support limit/continue
+// pagination on the CRI list calls.
+type PaginatedListService interface {
+	// ListPodSandboxPaged returns one page of sandboxes and the continuation
+	// token for the next page.
+	ListPodSandboxPaged(filter *runtimeapi.PodSandboxFilter, limit int32, continueToken string) ([]*runtimeapi.PodSandbox, string, error)
+	// ListContainersPaged returns one page of containers and the continuation
+	// token for the next page.
+	ListContainersPaged(filter *runtimeapi.ContainerFilter, limit int32, continueToken string) ([]*runtimeapi.Container, string, error)
+}
+
+// ImageFsInfoService is implemented by runtimes that report the capacity and
+// usage of the filesystem that stores images.
+type ImageFsInfoService interface {
+	// ImageFsInfo returns information about the filesystem that is used to
+	// store images.
+	ImageFsInfo() (*runtimeapi.FilesystemUsage, error)
+}
+
+// ImagePrePullService is implemented by runtimes that can pull a set of
+// images ahead of need, e.g. to warm a cold node with critical images.
+type ImagePrePullService interface {
+	// PrePullImages pulls the given images, skipping those already present.
+	PrePullImages(images []string) error
+}
+
+// ImageGCService is implemented by runtimes that can nominate images for
+// garbage collection based on their own usage tracking.
+type ImageGCService interface {
+	// ImageGCCandidates returns the IDs of images eligible for removal,
+	// least recently used first, honoring the pinned list and minimum age.
+	ImageGCCandidates(pinned []string, minAge time.Duration) ([]string, error)
+}
+
+// PodSandboxNetworkUpdateService is implemented by runtimes that can re-apply
+// the network plugin's configuration to a live sandbox, e.g. after the plugin
+// config changed, instead of requiring the sandbox to be recreated.
+type PodSandboxNetworkUpdateService interface {
+	// UpdatePodSandboxNetwork re-invokes the network plugin's setup against
+	// the given sandbox.
+	UpdatePodSandboxNetwork(podSandboxID string) error
+}
+
+// ContainerResourceUpdateService is implemented by runtimes that can update
+// the resources of running containers beyond what the CRI version in use can
+// express, e.g. the cpuset placement chosen by the kubelet CPU manager.
+type ContainerResourceUpdateService interface {
+	// UpdateContainerResources applies cpuset placement to a running
+	// container.
+	UpdateContainerResources(containerID, cpusetCpus, cpusetMems string) error
+}
+
+// ContainerStatsService is implemented by runtimes that report per-container
+// resource usage statistics.
+type ContainerStatsService interface {
+	// ContainerStats returns stats for the container with the given ID.
+	ContainerStats(containerID string) (*runtimeapi.ContainerStats, error)
+	// ListContainerStats returns stats for all containers matching the filter.
+	ListContainerStats(filter *runtimeapi.ContainerStatsFilter) ([]*runtimeapi.ContainerStats, error)
+}
+
 type dockerService struct {
-	seccompProfileRoot string
-	client             dockertools.DockerInterface
-	os                 kubecontainer.OSInterface
-	podSandboxImage    string
-	streamingRuntime   *streamingRuntime
-	streamingServer    streaming.Server
-	networkPlugin      network.NetworkPlugin
-	containerManager   cm.ContainerManager
+	// seccompProfiles loads and caches localhost/ seccomp profiles from the
+	// seccomp profile root.
+	seccompProfiles *seccompProfileCache
+	// featureGate holds the dockershim-local feature gates.
+	featureGate *shimFeatureGate
+	// oomPolicy decides the oom_score_adj assigned to sandboxes and
+	// containers.
+	oomPolicy oomPolicy
+	// securityProfile holds the hardening defaults applied to sandboxes
+	// and containers. Nil in most tests.
+	securityProfile *securityProfile
+	// hostConfigExtensions holds the allowlisted annotation-driven docker
+	// HostConfig extensions. Nil in most tests.
+	hostConfigExtensions *hostConfigExtensions
+	// ulimitCaps bounds the ulimits pods may request. Nil in most tests.
+	ulimitCaps ulimitCaps
+	// imageUsage tracks when images were last used by a container creation,
+	// feeding the image GC candidate selection. Nil in most tests.
+	imageUsage *imageUsageTracker
+	// imagePolicy verifies images before containers are created from them.
+	// Nil means every image is allowed.
+	imagePolicy imagePolicy
+	// credentialHelpers resolves registry credentials through external
+	// helper binaries. Nil in most tests.
+	credentialHelpers *credentialHelpers
+	// networkPolicyHook invokes the configured NetworkPolicy agent during
+	// sandbox setup. Nil in most tests.
+	networkPolicyHook *networkPolicyHook
+	// namespacePolicies carries per-namespace runtime defaults for
+	// multi-tenant nodes. Nil in most tests.
+	namespacePolicies namespacePolicies
+	// sandboxAdmission holds the compiled-in node-local admission plugins
+	// run over every sandbox config. Nil in most tests.
+	sandboxAdmission []sandboxAdmissionPlugin
+	// lifecycleHooks runs postStart/preStop exec hooks in containers and
+	// keeps their results for status reporting. Nil in most tests.
+	lifecycleHooks  *lifecycleHookRunner
+	client          dockertools.DockerInterface
+	os              kubecontainer.OSInterface
+	podSandboxImage string
+	// clusterDomain is the cluster's DNS domain, used to expand pod FQDNs.
+	// Empty falls back to the conventional "cluster.local".
+	clusterDomain    string
+	streamingRuntime *streamingRuntime
+	streamingServer  streaming.Server
+	// startLocalStreamingServer indicates whether dockershim should serve the
+	// streaming requests on the streaming config's address itself, rather
+	// than relying on another server to dispatch them to ds.ServeHTTP.
+	startLocalStreamingServer bool
+	networkPlugin             network.NetworkPlugin
+	containerManager          cm.ContainerManager
 	// cgroup driver used by Docker runtime.
-	cgroupDriver      string
+	cgroupDriver string
+	// cgroupV2 indicates the node mounts the unified cgroup hierarchy, on
+	// which the shim translates resource settings itself.
+	cgroupV2          bool
 	checkpointHandler CheckpointHandler
+	// iptables is used by the best-effort basic isolation mode.
+	iptables utiliptables.Interface
+	// bandwidthShaper applies the pod bandwidth annotations via tc. It is
+	// created lazily on first use.
+	bandwidthShaper bandwidth.BandwidthShaper
+	// networkCallTimeout bounds each call into the network plugin; zero or
+	// negative disables the bound. pluginBinDir is used to identify and kill
+	// plugin processes hung past the timeout.
+	networkCallTimeout time.Duration
+	pluginBinDir       string
+	// netMonitor feeds recent network setup outcomes into the NetworkReady
+	// runtime condition.
+	netMonitor networkSetupMonitor
+	// storageOptSupported indicates the docker graph driver enforces
+	// per-container storage limits; storageOptReason says why when it
+	// does not.
+	storageOptSupported bool
+	storageOptReason    string
+	// sharedMountSupported indicates the docker root directory lives on a
+	// shared mount, which bidirectional mount propagation requires;
+	// sharedMountReason says why when it does not.
+	sharedMountSupported bool
+	sharedMountReason    string
+	// usernsRemapped indicates the docker daemon is running with
+	// userns-remap enabled; remappedRootUID/GID hold the remapped root
+	// user, when known.
+	usernsRemapped  bool
+	remappedRootUID int
+	remappedRootGID int
+	// events fans structured container events out to subscribers.
+	events *eventStream
+	// labels indexes container labels to serve list label selectors.
+	labels *labelIndex
+	// lastResync records when the shim last resynced its bookkeeping after
+	// losing the docker event stream, guarded by resyncLock.
+	resyncLock sync.Mutex
+	lastResync time.Time
+	// teardownQueue retries failed network plugin teardowns asynchronously.
+	teardownQueue *teardownRetryQueue
+	// teardownDispatcher runs network teardowns on a bounded worker pool.
+	teardownDispatcher *teardownDispatcher
+	// sandboxGC removes exited sandboxes and their leftovers periodically.
+	// It is nil when no GC policy was configured.
+	sandboxGC *sandboxGC
+	// pullLimiter throttles image pulls when a pull QPS is configured. It is
+	// nil when rate limiting is disabled.
+	pullLimiter flowcontrol.RateLimiter
+	// serializePulls allows at most one image pull at a time, guarded by
+	// pullLock.
+	serializePulls bool
+	pullLock       sync.Mutex
+	// pullMirrors holds the registry mirrors tried before the canonical
+	// registry. It is nil when no mirrors are configured.
+	pullMirrors *registryMirrors
+	// pullCoalescer deduplicates concurrent pulls of the same image. Nil in
+	// most tests, which makes every pull run on its own.
+	pullCoalescer *pullCoalescer
+	// netns maintains the bind mounts that keep sandbox network namespaces
+	// alive after their pause containers exit. Nil in most tests.
+	netns *netnsManager
+	// kubeletInstanceID identifies this shim instance in the audit labels
+	// recorded on the sandboxes it creates.
+	kubeletInstanceID string
+	// podIPCache caches the plugin-reported pod IP per sandbox ID, guarded
+	// by podIPCacheLock. It is invalidated on network teardown.
+	podIPCacheLock sync.Mutex
+	podIPCache     map[string]string
+	// sandboxChurn counts sandbox recreations per pod to detect crash
+	// loops. Nil in most tests, in which case no crash loop is ever
+	// reported.
+	sandboxChurn *sandboxChurn
 	// legacyCleanup indicates whether legacy cleanup has finished or not.
 	legacyCleanup legacyCleanupFlag
+	// legacyMigration tracks legacy container migration progress across
+	// kubelet restarts.
+	legacyMigration *legacyMigration
 	// caches the version of the runtime.
 	// To be compatible with multiple docker versions, we need to perform
 	// version checking for some operations. Use this cache to avoid querying
 	// the docker daemon every time we need to do such checks.
 	versionCache *cache.ObjectCache
+	// negotiatedVersion is the API version negotiated at startup together
+	// with the behaviors derived from it. It is nil when the daemon was
+	// unreachable during startup (and in most tests), in which case the
+	// version is resolved lazily through versionCache.
+	negotiatedVersion *negotiatedDockerVersion
+	// inspectCache caches inspect results for status read paths. It is
+	// invalidated by docker events and the shim's own mutations. Nil in
+	// most tests, in which case every status call inspects the daemon.
+	inspectCache *inspectCache
 }
 
 // Version returns the runtime name, runtime version and runtime API version
@@ -282,8 +702,20 @@ func (ds *dockerService) UpdateRuntimeConfig(runtimeConfig *runtimeapi.RuntimeCo
 // supplied is typically the ID of a pod sandbox. This getter doesn't try
 // to map non-sandbox IDs to their respective sandboxes.
 func (ds *dockerService) GetNetNS(podSandboxID string) (string, error) {
+	// Prefer the bind-mounted namespace: it stays valid after the pause
+	// container exits, so network teardown always has a namespace to enter.
+	if path := netnsPath(podSandboxID); ds.netns != nil {
+		if _, err := ds.os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
 	r, err := ds.client.InspectContainer(podSandboxID)
 	if err != nil {
+		// The sandbox container may already be gone entirely; fall back to
+		// the path recorded in the checkpoint at creation.
+		if checkpoint, cerr := ds.checkpointHandler.GetCheckpoint(podSandboxID); cerr == nil && checkpoint.Data != nil && checkpoint.Data.NetNSPath != "" {
+			return checkpoint.Data.NetNSPath, nil
+		}
 		return "", err
 	}
 	return getNetworkNamespace(r), nil
@@ -310,6 +742,7 @@ func (ds *dockerService) GetPodPortMappings(podSandboxID string) ([]*hostport.Po
 			HostPort:      *pm.HostPort,
 			ContainerPort: *pm.ContainerPort,
 			Protocol:      proto,
+			HostIP:        pm.HostIp,
 		})
 	}
 	return portMappings, nil
@@ -319,11 +752,35 @@ func (ds *dockerService) GetPodPortMappings(podSandboxID string) ([]*hostport.Po
 func (ds *dockerService) Start() error {
 	// Initialize the legacy cleanup flag.
 	ds.LegacyCleanupInit()
+	// Best-effort; without the watcher seccomp profiles are read from disk
+	// on every container creation.
+	if err := ds.seccompProfiles.start(); err != nil {
+		glog.Warningf("Failed to start seccomp profile watcher, profiles will not be cached: %v", err)
+	}
+	ds.startEventWatcher()
+	// Periodically verify live pod network attachments when the plugin can
+	// check them, so silently broken pod networking is detected.
+	ds.startNetworkChecker()
+	// Periodically aggregate the resource usage of the pause containers into
+	// the sandbox overhead metrics.
+	ds.startSandboxOverheadCollector()
+	// Clean up network resources leaked by sandboxes that disappeared while
+	// the shim was down, e.g. across a node crash.
+	go ds.reconcileNetworkResources()
+	if ds.sandboxGC != nil {
+		go ds.sandboxGC.run()
+	}
+	if ds.startLocalStreamingServer {
+		go func() {
+			if err := ds.streamingServer.Start(true); err != nil {
+				glog.Fatalf("Streaming server stopped unexpectedly: %v", err)
+			}
+		}()
+	}
 	return ds.containerManager.Start()
 }
 
 // Status returns the status of the runtime.
-// TODO(random-liu): Set network condition accordingly here.
 func (ds *dockerService) Status() (*runtimeapi.RuntimeStatus, error) {
 	runtimeReady := &runtimeapi.RuntimeCondition{
 		Type:   runtimeapi.RuntimeReady,
@@ -333,7 +790,31 @@ func (ds *dockerService) Status() (*runtimeapi.RuntimeStatus, error) {
 		Type:   runtimeapi.NetworkReady,
 		Status: true,
 	}
-	conditions := []*runtimeapi.RuntimeCondition{runtimeReady, networkReady}
+	storageReady := &runtimeapi.RuntimeCondition{
+		Type:   storageLimitsReadyCondition,
+		Status: ds.storageOptSupported,
+	}
+	if !ds.storageOptSupported {
+		storageReady.Reason = "StorageDriverUnsupported"
+		storageReady.Message = fmt.Sprintf("docker: %s", ds.storageOptReason)
+	}
+	daemonConfigReady := &runtimeapi.RuntimeCondition{
+		Type:   daemonConfigReadyCondition,
+		Status: true,
+	}
+	acceleratorOK, acceleratorReason := ds.acceleratorStatus()
+	acceleratorReady := &runtimeapi.RuntimeCondition{
+		Type:   acceleratorReadyCondition,
+		Status: acceleratorOK,
+	}
+	if !acceleratorOK {
+		acceleratorReady.Reason = "AcceleratorRuntimeUnavailable"
+		acceleratorReady.Message = fmt.Sprintf("docker: %s", acceleratorReason)
+	}
+	conditions := []*runtimeapi.RuntimeCondition{runtimeReady, networkReady, storageReady, daemonConfigReady, acceleratorReady}
+	if glog.V(4) {
+		glog.Infof("Dockershim feature gates: %s", ds.featureGate)
+	}
 	if _, err := ds.client.Version(); err != nil {
 		runtimeReady.Status = false
 		runtimeReady.Reason = "DockerDaemonNotReady"
@@ -343,11 +824,48 @@ func (ds *dockerService) Status() (*runtimeapi.RuntimeStatus, error) {
 		networkReady.Status = false
 		networkReady.Reason = "NetworkPluginNotReady"
 		networkReady.Message = fmt.Sprintf("docker: network plugin is not ready: %v", err)
+	} else if err := ds.netMonitor.status(); err != nil {
+		networkReady.Status = false
+		networkReady.Reason = "NetworkSetupFailing"
+		networkReady.Message = fmt.Sprintf("docker: %v", err)
+	}
+	if err := ds.checkDaemonConfig(); err != nil {
+		daemonConfigReady.Status = false
+		daemonConfigReady.Reason = "CgroupDriverMismatch"
+		daemonConfigReady.Message = fmt.Sprintf("docker: %v", err)
 	}
 	return &runtimeapi.RuntimeStatus{Conditions: conditions}, nil
 }
 
+// prePullPath is the path of the node-local admin endpoint that triggers
+// image pre-pulls.
+const prePullPath = "/prepull"
+
+// debugSandboxesPath is the path of the node-local debug endpoint that dumps
+// pod state reconstructed from the audit labels on sandbox containers.
+const debugSandboxesPath = "/debug/sandboxes"
+
 func (ds *dockerService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == prePullPath {
+		ds.servePrePull(w, r)
+		return
+	}
+	if r.URL.Path == debugSandboxesPath {
+		ds.serveDebugSandboxes(w, r)
+		return
+	}
+	if r.URL.Path == debugDaemonPath {
+		ds.serveDebugDaemon(w, r)
+		return
+	}
+	if r.URL.Path == execSandboxPath {
+		ds.serveExecSandbox(w, r)
+		return
+	}
+	if r.URL.Path == capturePacketsPath {
+		ds.serveCapturePackets(w, r)
+		return
+	}
 	if ds.streamingServer != nil {
 		ds.streamingServer.ServeHTTP(w, r)
 	} else {
@@ -355,6 +873,75 @@ func (ds *dockerService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// servePrePull handles POST requests with a JSON body of the form
+// {"images": ["..."]}. It lets an operator warm critical images on the node
+// ahead of pod scheduling.
+func (ds *dockerService) servePrePull(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		Images []string `json:"images"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := ds.PrePullImages(req.Images); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// sandboxDebugInfo is the pod state reconstructed from the labels of a
+// single sandbox container.
+type sandboxDebugInfo struct {
+	ID             string `json:"id"`
+	PodName        string `json:"podName"`
+	PodNamespace   string `json:"podNamespace"`
+	PodUID         string `json:"podUID"`
+	Attempt        string `json:"attempt"`
+	KubeletID      string `json:"kubeletID"`
+	CreationReason string `json:"creationReason"`
+}
+
+// serveDebugSandboxes dumps the state of all sandbox containers, in any
+// state, reconstructed from their docker labels alone. It is intended for
+// post-mortem debugging when the kubelet's own view of the pods is gone.
+func (ds *dockerService) serveDebugSandboxes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	opts := dockertypes.ContainerListOptions{All: true}
+	opts.Filter = dockerfilters.NewArgs()
+	f := newDockerFilter(&opts.Filter)
+	f.AddLabel(containerTypeLabelKey, containerTypeLabelSandbox)
+	containers, err := ds.client.ListContainers(opts)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list sandbox containers: %v", err), http.StatusInternalServerError)
+		return
+	}
+	infos := make([]sandboxDebugInfo, 0, len(containers))
+	for _, c := range containers {
+		infos = append(infos, sandboxDebugInfo{
+			ID:             c.ID,
+			PodName:        c.Labels[types.KubernetesPodNameLabel],
+			PodNamespace:   c.Labels[types.KubernetesPodNamespaceLabel],
+			PodUID:         c.Labels[auditPodUIDLabelKey],
+			Attempt:        c.Labels[auditAttemptLabelKey],
+			KubeletID:      c.Labels[auditKubeletIDLabelKey],
+			CreationReason: c.Labels[auditCreationReasonLabelKey],
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(infos); err != nil {
+		glog.Errorf("Failed to encode sandbox debug info: %v", err)
+	}
+}
+
 // GenerateExpectedCgroupParent returns cgroup parent in syntax expected by cgroup driver
 func (ds *dockerService) GenerateExpectedCgroupParent(cgroupParent string) (string, error) {
 	if len(cgroupParent) > 0 {
@@ -362,7 +949,9 @@ func (ds *dockerService) GenerateExpectedCgroupParent(cgroupParent string) (stri
 		// if we configured kubelet to use --cgroup-driver=cgroupfs, and docker is configured to use systemd driver
 		// docker will fail to launch the container because the name we provide will not be a valid slice.
 		// this is a very good thing.
-		if ds.cgroupDriver == "systemd" {
+		// On the unified hierarchy systemd owns the cgroup tree, so slice
+		// names are expected regardless of the configured driver.
+		if ds.cgroupDriver == "systemd" || ds.cgroupV2 {
 			systemdCgroupParent, err := kubecm.ConvertCgroupFsNameToSystemd(cgroupParent)
 			if err != nil {
 				return "", err
@@ -376,6 +965,10 @@ func (ds *dockerService) GenerateExpectedCgroupParent(cgroupParent string) (stri
 
 // getDockerAPIVersion gets the semver-compatible docker api version.
 func (ds *dockerService) getDockerAPIVersion() (*semver.Version, error) {
+	if ds.negotiatedVersion != nil {
+		v := ds.negotiatedVersion.apiVersion
+		return &v, nil
+	}
 	var dv *dockertypes.Version
 	var err error
 	if ds.versionCache != nil {
@@ -17,7 +17,11 @@ limitations under the License.
 package dockershim
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,11 +33,14 @@ import (
 
 	"k8s.io/client-go/util/clock"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/kubelet/network"
 	"k8s.io/kubernetes/pkg/kubelet/network/mock_network"
+	kubelettypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/kubelet/util/cache"
+	iptablestesting "k8s.io/kubernetes/pkg/util/iptables/testing"
 )
 
 // newTestNetworkPlugin returns a mock plugin that implements network.NetworkPlugin
@@ -45,8 +52,20 @@ func newTestNetworkPlugin(t *testing.T) *mock_network.MockNetworkPlugin {
 func newTestDockerService() (*dockerService, *dockertools.FakeDockerClient, *clock.FakeClock) {
 	fakeClock := clock.NewFakeClock(time.Time{})
 	c := dockertools.NewFakeDockerClient().WithClock(fakeClock).WithVersion("1.11.2", "1.23")
-	return &dockerService{client: c, os: &containertest.FakeOS{}, networkPlugin: &network.NoopNetworkPlugin{},
-		legacyCleanup: legacyCleanupFlag{done: 1}, checkpointHandler: NewTestPersistentCheckpointHandler()}, c, fakeClock
+	ds := &dockerService{client: c, os: &containertest.FakeOS{}, networkPlugin: &network.NoopNetworkPlugin{},
+		legacyCleanup: legacyCleanupFlag{done: 1}, checkpointHandler: NewTestPersistentCheckpointHandler(),
+		iptables:        iptablestesting.NewFake(),
+		podIPCache:      make(map[string]string),
+		events:          newEventStream(),
+		labels:          newLabelIndex(),
+		seccompProfiles: newSeccompProfileCache("test/seccomp/profile/root"),
+		featureGate:     newShimFeatureGate(),
+		oomPolicy:       defaultOOMPolicy{}}
+	ds.teardownDispatcher = newTeardownDispatcher(func(namespace, name string, id kubecontainer.ContainerID) error {
+		return ds.networkPlugin.TearDownPod(namespace, name, id)
+	}, defaultTeardownWorkers)
+	ds.teardownQueue = newTeardownRetryQueue(ds.teardownDispatcher.dispatch)
+	return ds, c, fakeClock
 }
 
 func newTestDockerServiceWithVersionCache() (*dockerService, *dockertools.FakeDockerClient, *clock.FakeClock) {
@@ -80,17 +99,51 @@ func TestStatus(t *testing.T) {
 	status, err := ds.Status()
 	assert.NoError(t, err)
 	assertStatus(map[string]bool{
-		runtimeapi.RuntimeReady: true,
-		runtimeapi.NetworkReady: true,
+		runtimeapi.RuntimeReady:     true,
+		runtimeapi.NetworkReady:     true,
+		storageLimitsReadyCondition: false,
+		daemonConfigReadyCondition:  true,
+		acceleratorReadyCondition:   false,
+	}, status)
+
+	// Should report enforced storage limits where the graph driver supports
+	// them.
+	ds.storageOptSupported = true
+	status, err = ds.Status()
+	assert.NoError(t, err)
+	assertStatus(map[string]bool{
+		runtimeapi.RuntimeReady:     true,
+		runtimeapi.NetworkReady:     true,
+		storageLimitsReadyCondition: true,
+		daemonConfigReadyCondition:  true,
+		acceleratorReadyCondition:   false,
+	}, status)
+
+	// Should report a configuration mismatch when the daemon's cgroup driver
+	// changed underneath the shim.
+	fDocker.Information.CgroupDriver = "systemd"
+	ds.cgroupDriver = "cgroupfs"
+	status, err = ds.Status()
+	assert.NoError(t, err)
+	assertStatus(map[string]bool{
+		runtimeapi.RuntimeReady:     true,
+		runtimeapi.NetworkReady:     true,
+		storageLimitsReadyCondition: true,
+		daemonConfigReadyCondition:  false,
+		acceleratorReadyCondition:   false,
 	}, status)
+	ds.cgroupDriver = "systemd"
 
 	// Should not report ready status if version returns error.
 	fDocker.InjectError("version", errors.New("test error"))
 	status, err = ds.Status()
 	assert.NoError(t, err)
 	assertStatus(map[string]bool{
-		runtimeapi.RuntimeReady: false,
-		runtimeapi.NetworkReady: true,
+		runtimeapi.RuntimeReady:     false,
+		runtimeapi.NetworkReady:     true,
+		storageLimitsReadyCondition: true,
+		daemonConfigReadyCondition:  true,
+		acceleratorReadyCondition:   false,
 	}, status)
 
 	// Should not report ready status is network plugin returns error.
@@ -101,8 +154,11 @@ func TestStatus(t *testing.T) {
 	status, err = ds.Status()
 	assert.NoError(t, err)
 	assertStatus(map[string]bool{
-		runtimeapi.RuntimeReady: true,
-		runtimeapi.NetworkReady: false,
+		runtimeapi.RuntimeReady:     true,
+		runtimeapi.NetworkReady:     false,
+		storageLimitsReadyCondition: true,
+		daemonConfigReadyCondition:  true,
+		acceleratorReadyCondition:   false,
 	}, status)
 }
 
@@ -128,3 +184,54 @@ func TestAPIVersionWithCache(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, version)
 }
+
+// TestServePrePull tests the admin endpoint that triggers image pre-pulls.
+func TestServePrePull(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+
+	// Only POST is accepted.
+	recorder := httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("GET", prePullPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	// A valid request pulls the images. The fake always reports an image as
+	// present, so force a miss explicitly.
+	fDocker.InjectError("inspect_image", errors.New("no such image"))
+	body := strings.NewReader(`{"images": ["busybox"]}`)
+	recorder = httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("POST", prePullPath, body))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, fDocker.Image)
+	assert.Equal(t, "busybox", fDocker.Image.ID)
+
+	// Malformed bodies are rejected.
+	recorder = httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("POST", prePullPath, strings.NewReader("not json")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+// TestServeDebugSandboxes tests the debug endpoint that reconstructs pod
+// state from the labels on sandbox containers.
+func TestServeDebugSandboxes(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.kubeletInstanceID = "kubelet-1"
+	_, err := ds.RunPodSandbox(makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "uid1", 0,
+		map[string]string{
+			kubelettypes.KubernetesPodNameLabel:      "foo",
+			kubelettypes.KubernetesPodNamespaceLabel: "bar",
+		}, map[string]string{}))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("GET", debugSandboxesPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	infos := []sandboxDebugInfo{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	assert.Equal(t, "foo", infos[0].PodName)
+	assert.Equal(t, "bar", infos[0].PodNamespace)
+	assert.Equal(t, "uid1", infos[0].PodUID)
+	assert.Equal(t, "0", infos[0].Attempt)
+	assert.Equal(t, "kubelet-1", infos[0].KubeletID)
+	assert.Equal(t, "create", infos[0].CreationReason)
+}
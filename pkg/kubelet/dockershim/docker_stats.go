@@ -0,0 +1,162 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// cgroupRoot is the mount point of the cgroup hierarchies.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// ContainerStats returns stats for the container with the given ID.
+func (ds *dockerService) ContainerStats(containerID string) (*runtimeapi.ContainerStats, error) {
+	return ds.getContainerStats(containerID)
+}
+
+// ListContainerStats returns stats for all containers matching the filter.
+// Containers that disappear while the stats are collected are skipped.
+func (ds *dockerService) ListContainerStats(filter *runtimeapi.ContainerStatsFilter) ([]*runtimeapi.ContainerStats, error) {
+	containerFilter := &runtimeapi.ContainerFilter{}
+	if filter != nil {
+		containerFilter.Id = filter.Id
+		containerFilter.PodSandboxId = filter.PodSandboxId
+	}
+	containers, err := ds.ListContainers(containerFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []*runtimeapi.ContainerStats
+	for _, c := range containers {
+		containerStats, err := ds.getContainerStats(c.Id)
+		if err != nil {
+			glog.V(4).Infof("Failed to get stats for container %q: %v", c.Id, err)
+			continue
+		}
+		stats = append(stats, containerStats)
+	}
+	return stats, nil
+}
+
+// getContainerStats collects the stats for a single container. The writable
+// layer size comes from docker inspect, the log size from the log file on
+// disk, and the cpu and memory usage from the container's cgroups.
+func (ds *dockerService) getContainerStats(containerID string) (*runtimeapi.ContainerStats, error) {
+	info, err := ds.client.InspectContainerWithSize(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &runtimeapi.ContainerStats{
+		ContainerId: containerID,
+		Timestamp:   time.Now().UnixNano(),
+	}
+	if info.SizeRw != nil {
+		stats.WritableLayerUsedBytes = *info.SizeRw
+	}
+	if info.LogPath != "" {
+		if fi, err := ds.os.Stat(info.LogPath); err == nil {
+			stats.LogUsedBytes = fi.Size()
+		}
+	}
+
+	cgroupParent := ""
+	if info.HostConfig != nil {
+		cgroupParent = info.HostConfig.CgroupParent
+	}
+	stats.CpuUsageCoreNanoseconds = readCgroupValue("cpuacct", cgroupParent, info.ID, "cpuacct.usage")
+	stats.MemoryWorkingSetBytes = readMemoryWorkingSet(cgroupParent, info.ID)
+	return stats, nil
+}
+
+// containerCgroupDirs returns the candidate cgroup directories for the
+// container in the given subsystem, covering both the cgroupfs and the
+// systemd cgroup driver layouts.
+func containerCgroupDirs(subsystem, cgroupParent, containerID string) []string {
+	if cgroupParent == "" {
+		// "docker" is the default cgroup parent used by the daemon with the
+		// cgroupfs driver; the systemd driver places containers in scopes
+		// under the system slice.
+		return []string{
+			filepath.Join(cgroupRoot, subsystem, "docker", containerID),
+			filepath.Join(cgroupRoot, subsystem, "system.slice", "docker-"+containerID+".scope"),
+		}
+	}
+	return []string{
+		filepath.Join(cgroupRoot, subsystem, cgroupParent, containerID),
+		filepath.Join(cgroupRoot, subsystem, cgroupParent, "docker-"+containerID+".scope"),
+	}
+}
+
+// readCgroupValue reads a single integer value from the container's cgroup in
+// the given subsystem. It returns 0 if the value cannot be read; stats
+// collection should not fail outright because one reading is unavailable.
+func readCgroupValue(subsystem, cgroupParent, containerID, file string) int64 {
+	for _, dir := range containerCgroupDirs(subsystem, cgroupParent, containerID) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			glog.V(4).Infof("Failed to parse %s for container %q: %v", file, containerID, err)
+			return 0
+		}
+		return value
+	}
+	return 0
+}
+
+// readMemoryWorkingSet returns the container's working set memory, defined as
+// the total memory usage minus the inactive file-backed memory.
+func readMemoryWorkingSet(cgroupParent, containerID string) int64 {
+	usage := readCgroupValue("memory", cgroupParent, containerID, "memory.usage_in_bytes")
+	if usage == 0 {
+		return 0
+	}
+	for _, dir := range containerCgroupDirs("memory", cgroupParent, containerID) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "memory.stat"))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 || fields[0] != "total_inactive_file" {
+				continue
+			}
+			inactive, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				break
+			}
+			if inactive > usage {
+				return 0
+			}
+			return usage - inactive
+		}
+		break
+	}
+	return usage
+}
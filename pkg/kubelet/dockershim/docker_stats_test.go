@@ -0,0 +1,85 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
+)
+
+func TestContainerStats(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	ds.os.(*containertest.FakeOS).StatFn = os.Stat
+
+	logFile, err := ioutil.TempFile("", "container-log")
+	require.NoError(t, err)
+	defer os.Remove(logFile.Name())
+	_, err = logFile.WriteString("stats")
+	require.NoError(t, err)
+	require.NoError(t, logFile.Close())
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "pause", "iamimage", 0, nil, nil)
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+
+	// The fake client does not compute sizes; inject them on the inspect
+	// result together with the log path.
+	sizeRw := int64(2048)
+	fDocker.ContainerMap[id].SizeRw = &sizeRw
+	fDocker.ContainerMap[id].LogPath = logFile.Name()
+
+	stats, err := ds.ContainerStats(id)
+	require.NoError(t, err)
+	assert.Equal(t, id, stats.ContainerId)
+	assert.Equal(t, int64(2048), stats.WritableLayerUsedBytes)
+	assert.Equal(t, int64(len("stats")), stats.LogUsedBytes)
+	assert.True(t, stats.Timestamp > 0)
+
+	// Unknown containers yield an error.
+	_, err = ds.ContainerStats("nosuchcontainer")
+	assert.Error(t, err)
+}
+
+func TestListContainerStats(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	for i := 0; i < 3; i++ {
+		sConfig := makeSandboxConfig(fmt.Sprintf("foo%d", i), "bar", fmt.Sprintf("%d", i), 0)
+		config := makeContainerConfig(sConfig, fmt.Sprintf("c%d", i), "iamimage", 0, nil, nil)
+		id, err := ds.CreateContainer(fmt.Sprintf("sandboxid%d", i), config, sConfig)
+		require.NoError(t, err)
+		require.NoError(t, ds.StartContainer(id))
+	}
+
+	stats, err := ds.ListContainerStats(nil)
+	require.NoError(t, err)
+	assert.Len(t, stats, 3)
+
+	// The filter narrows the list down to a single sandbox.
+	stats, err = ds.ListContainerStats(&runtimeapi.ContainerStatsFilter{PodSandboxId: "sandboxid1"})
+	require.NoError(t, err)
+	assert.Len(t, stats, 1)
+}
@@ -18,12 +18,17 @@ package dockershim
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"time"
 
 	dockertypes "github.com/docker/engine-api/types"
+	"github.com/golang/glog"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/kubernetes/pkg/features"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/kubelet/server/streaming"
@@ -34,6 +39,10 @@ import (
 type streamingRuntime struct {
 	client      dockertools.DockerInterface
 	execHandler dockertools.ExecHandler
+	// execSupervisor caps concurrent execs per container and enforces exec
+	// deadlines. It is nil in a few tests, in which case execs run
+	// unsupervised.
+	execSupervisor *execSupervisor
 }
 
 var _ streaming.Runtime = &streamingRuntime{}
@@ -48,7 +57,12 @@ func (r *streamingRuntime) exec(containerID string, cmd []string, in io.Reader,
 	if err != nil {
 		return err
 	}
-	return r.execHandler.ExecInContainer(r.client, container, cmd, in, out, errw, tty, resize, timeout)
+	if r.execSupervisor == nil {
+		return r.execHandler.ExecInContainer(r.client, container, cmd, in, out, errw, tty, resize, timeout)
+	}
+	return r.execSupervisor.supervise(container.ID, container.State.Pid, timeout, func() error {
+		return r.execHandler.ExecInContainer(r.client, container, cmd, in, out, errw, tty, resize, timeout)
+	})
 }
 
 func (r *streamingRuntime) Attach(containerID string, in io.Reader, out, errw io.WriteCloser, tty bool, resize <-chan term.Size) error {
@@ -57,6 +71,16 @@ func (r *streamingRuntime) Attach(containerID string, in io.Reader, out, errw io
 		return err
 	}
 
+	// Resize events are handled by AttachContainer. Signal forwarding only
+	// applies without a TTY; with one, the line discipline in the container
+	// already turns the control bytes into signals.
+	if in != nil && !tty {
+		in = newSignalForwardingReader(in, func(signal string) {
+			if err := r.client.KillContainer(containerID, signal); err != nil {
+				glog.Warningf("Failed to forward %s to attached container %q: %v", signal, containerID, err)
+			}
+		})
+	}
 	return dockertools.AttachContainer(r.client, containerID, in, out, errw, tty, resize)
 }
 
@@ -81,6 +105,65 @@ func (ds *dockerService) ExecSync(containerID string, cmd []string, timeout time
 	return stdoutBuffer.Bytes(), stderrBuffer.Bytes(), err
 }
 
+// ExecSandbox executes a command in the sandbox (infra) container of a pod
+// and returns the stdout/stderr output. It gives operators a way to run
+// network debugging tools (e.g. `ip addr`, `ss`) in the pod's network
+// namespace without scheduling a separate debug pod. The capability is only
+// available when the ExperimentalSandboxExec feature gate is enabled.
+func (ds *dockerService) ExecSandbox(podSandboxID string, cmd []string, timeout time.Duration) ([]byte, []byte, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.ExperimentalSandboxExec) {
+		return nil, nil, fmt.Errorf("exec in sandbox %q is disabled; enable the %s feature gate to use it", podSandboxID, features.ExperimentalSandboxExec)
+	}
+	container, err := checkContainerStatus(ds.client, podSandboxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if container.Config == nil || container.Config.Labels[containerTypeLabelKey] != containerTypeLabelSandbox {
+		return nil, nil, fmt.Errorf("container %q is not a sandbox container", podSandboxID)
+	}
+	return ds.ExecSync(podSandboxID, cmd, timeout)
+}
+
+// execSandboxPath is the path of the node-local admin endpoint that runs a
+// command in a pod's sandbox container.
+const execSandboxPath = "/exec-sandbox"
+
+// serveExecSandbox handles POST requests with a JSON body of the form
+// {"podSandboxId": "...", "cmd": ["..."], "timeoutSeconds": 10} and responds
+// with the command's stdout and stderr.
+func (ds *dockerService) serveExecSandbox(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		PodSandboxID   string   `json:"podSandboxId"`
+		Cmd            []string `json:"cmd"`
+		TimeoutSeconds int64    `json:"timeoutSeconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.PodSandboxID == "" || len(req.Cmd) == 0 {
+		http.Error(w, "podSandboxId and cmd are required", http.StatusBadRequest)
+		return
+	}
+	stdout, stderr, err := ds.ExecSandbox(req.PodSandboxID, req.Cmd, time.Duration(req.TimeoutSeconds)*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Stdout string `json:"stdout"`
+		Stderr string `json:"stderr"`
+	}{Stdout: string(stdout), Stderr: string(stderr)}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		glog.Errorf("Failed to encode sandbox exec response: %v", err)
+	}
+}
+
 // Exec prepares a streaming endpoint to execute a command in the container, and returns the address.
 func (ds *dockerService) Exec(req *runtimeapi.ExecRequest) (*runtimeapi.ExecResponse, error) {
 	if ds.streamingServer == nil {
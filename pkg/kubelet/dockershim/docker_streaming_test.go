@@ -0,0 +1,107 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+// newTestDockerServiceWithExec returns a test service whose streaming
+// runtime can run execs against the fake docker client.
+func newTestDockerServiceWithExec() (*dockerService, *dockertools.FakeDockerClient) {
+	ds, fakeDocker, _ := newTestDockerService()
+	ds.streamingRuntime = &streamingRuntime{client: fakeDocker, execHandler: &dockertools.NativeExecHandler{}}
+	fakeDocker.ExecInspect = &dockertypes.ContainerExecInspect{Running: false, ExitCode: 0}
+	return ds, fakeDocker
+}
+
+// TestExecSandbox tests the feature gating and sandbox checks of exec in
+// the sandbox container.
+func TestExecSandbox(t *testing.T) {
+	ds, fakeDocker := newTestDockerServiceWithExec()
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.NoError(t, err)
+
+	// The feature gate is off by default.
+	_, _, err = ds.ExecSandbox(id, []string{"ip", "addr"}, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ExperimentalSandboxExec")
+
+	require.NoError(t, utilfeature.DefaultFeatureGate.Set("ExperimentalSandboxExec=true"))
+	defer utilfeature.DefaultFeatureGate.Set("ExperimentalSandboxExec=false")
+
+	fakeDocker.ClearCalls()
+	_, _, err = ds.ExecSandbox(id, []string{"ip", "addr"}, 0)
+	require.NoError(t, err)
+	assert.NoError(t, fakeDocker.AssertCalls([]string{"inspect_container", "inspect_container", "create_exec", "start_exec"}))
+
+	// Only sandbox containers accept a sandbox exec.
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil, nil)
+	cID, err := ds.CreateContainer(id, config, sConfig)
+	require.NoError(t, err)
+	require.NoError(t, ds.StartContainer(cID))
+	_, _, err = ds.ExecSandbox(cID, []string{"ip", "addr"}, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a sandbox container")
+}
+
+// TestServeExecSandbox tests the node-local exec-sandbox endpoint.
+func TestServeExecSandbox(t *testing.T) {
+	ds, _ := newTestDockerServiceWithExec()
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.NoError(t, err)
+
+	require.NoError(t, utilfeature.DefaultFeatureGate.Set("ExperimentalSandboxExec=true"))
+	defer utilfeature.DefaultFeatureGate.Set("ExperimentalSandboxExec=false")
+
+	// Only POST is accepted.
+	recorder := httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("GET", execSandboxPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	// Malformed and incomplete bodies are rejected.
+	recorder = httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("POST", execSandboxPath, strings.NewReader("not json")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	recorder = httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("POST", execSandboxPath, strings.NewReader(`{"podSandboxId": "x"}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// A valid request runs the command and returns its output.
+	body := strings.NewReader(`{"podSandboxId": "` + id + `", "cmd": ["ip", "addr"], "timeoutSeconds": 10}`)
+	recorder = httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("POST", execSandboxPath, body))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"stdout"`)
+
+	// Unknown sandboxes fail the request.
+	body = strings.NewReader(`{"podSandboxId": "nonexistent", "cmd": ["ip", "addr"]}`)
+	recorder = httptest.NewRecorder()
+	ds.ServeHTTP(recorder, httptest.NewRequest("POST", execSandboxPath, body))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
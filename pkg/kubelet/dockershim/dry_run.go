@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	dockertypes "github.com/docker/engine-api/types"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// Validation-only ("dry run") variants of the creation paths. They compute
+// the exact docker creation config the shim would use — labels, security
+// options, mounts, resources — and return it without creating anything, so
+// conformance tests and admission-time checks can validate how a pod
+// translates on this node and whether the node can satisfy it at all.
+// Read-only docker queries (the daemon API version, image inspection) still
+// happen, since parts of the config depend on their results.
+
+// DryRunCreatePodSandbox computes the sandbox container creation config for
+// the pod without creating the sandbox.
+func (ds *dockerService) DryRunCreatePodSandbox(config *runtimeapi.PodSandboxConfig) (*dockertypes.ContainerCreateConfig, error) {
+	if config == nil {
+		return nil, fmt.Errorf("sandbox config is nil")
+	}
+	image := defaultSandboxImage()
+	if len(ds.podSandboxImage) != 0 {
+		image = ds.podSandboxImage
+	}
+	return ds.makeSandboxDockerConfig(config, image)
+}
+
+// DryRunCreateContainer computes the workload container creation config
+// without creating the container.
+func (ds *dockerService) DryRunCreateContainer(podSandboxID string, config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) (*dockertypes.ContainerCreateConfig, error) {
+	if config == nil {
+		return nil, fmt.Errorf("container config is nil")
+	}
+	if sandboxConfig == nil {
+		return nil, fmt.Errorf("sandbox config is nil for container %q", config.Metadata.Name)
+	}
+	return ds.makeContainerDockerConfig(podSandboxID, config, sandboxConfig)
+}
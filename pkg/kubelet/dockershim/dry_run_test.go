@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDryRunCreateContainer tests that the dry run computes the same creation
+// config CreateContainer would use, without creating anything.
+func TestDryRunCreateContainer(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	sConfig := makeSandboxConfig("foo", "default", "1", 0)
+	config := makeContainerConfig(sConfig, "bar", "busybox", 0,
+		map[string]string{"a": "b"}, map[string]string{"c": "d"})
+
+	createConfig, err := ds.DryRunCreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	assert.Equal(t, makeContainerName(sConfig, config), createConfig.Name)
+	assert.Equal(t, "busybox", createConfig.Config.Image)
+	assert.Equal(t, "sandboxid", createConfig.Config.Labels[sandboxIDLabelKey])
+	assert.Equal(t, containerTypeLabelContainer, createConfig.Config.Labels[containerTypeLabelKey])
+
+	// Nothing was created.
+	assert.NoError(t, fDocker.AssertCreated([]string{}))
+
+	// Nil configs are rejected instead of dereferenced.
+	_, err = ds.DryRunCreateContainer("sandboxid", nil, sConfig)
+	assert.Error(t, err)
+	_, err = ds.DryRunCreateContainer("sandboxid", config, nil)
+	assert.Error(t, err)
+}
+
+// TestDryRunCreatePodSandbox tests the sandbox variant.
+func TestDryRunCreatePodSandbox(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	sConfig := makeSandboxConfig("foo", "default", "1", 0)
+
+	createConfig, err := ds.DryRunCreatePodSandbox(sConfig)
+	require.NoError(t, err)
+	assert.Equal(t, makeSandboxName(sConfig), createConfig.Name)
+	assert.Equal(t, containerTypeLabelSandbox, createConfig.Config.Labels[containerTypeLabelKey])
+	assert.NoError(t, fDocker.AssertCreated([]string{}))
+
+	_, err = ds.DryRunCreatePodSandbox(nil)
+	assert.Error(t, err)
+}
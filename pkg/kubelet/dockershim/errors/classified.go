@@ -0,0 +1,96 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+// ErrorClass buckets shim errors by how callers should react to them, so
+// retry policies can branch on error class instead of matching message
+// strings.
+type ErrorClass string
+
+const (
+	// NotFound means the referenced container, sandbox, image or checkpoint
+	// does not exist; retrying the same operation cannot succeed.
+	NotFound ErrorClass = "NotFound"
+	// Conflict means the operation raced with another change to the same
+	// object; the caller should re-read the object's state before retrying.
+	Conflict ErrorClass = "Conflict"
+	// Timeout means the operation timed out and its outcome is unknown.
+	Timeout ErrorClass = "Timeout"
+	// DaemonUnavailable means the docker daemon cannot be reached; every
+	// operation will fail until the daemon is back.
+	DaemonUnavailable ErrorClass = "DaemonUnavailable"
+	// ImagePullBackoff means an image pull was rejected by the shim's pull
+	// throttling; the caller should back off before pulling again.
+	ImagePullBackoff ErrorClass = "ImagePullBackoff"
+	// ImageRejected means the node's image policy refused to run the image;
+	// retrying cannot succeed until the image or the policy changes.
+	ImageRejected ErrorClass = "ImageRejected"
+)
+
+// classifiedError attaches an ErrorClass to a cause. Its message is the
+// cause's, unchanged, so classification is invisible to log readers and to
+// legacy message matching.
+type classifiedError struct {
+	class ErrorClass
+	cause error
+}
+
+func (e *classifiedError) Error() string { return e.cause.Error() }
+
+// NewNotFound wraps cause as a NotFound error.
+func NewNotFound(cause error) error { return &classifiedError{NotFound, cause} }
+
+// NewConflict wraps cause as a Conflict error.
+func NewConflict(cause error) error { return &classifiedError{Conflict, cause} }
+
+// NewTimeout wraps cause as a Timeout error.
+func NewTimeout(cause error) error { return &classifiedError{Timeout, cause} }
+
+// NewDaemonUnavailable wraps cause as a DaemonUnavailable error.
+func NewDaemonUnavailable(cause error) error { return &classifiedError{DaemonUnavailable, cause} }
+
+// NewImagePullBackoff wraps cause as an ImagePullBackoff error.
+func NewImagePullBackoff(cause error) error { return &classifiedError{ImagePullBackoff, cause} }
+
+// NewImageRejected wraps cause as an ImageRejected error.
+func NewImageRejected(cause error) error { return &classifiedError{ImageRejected, cause} }
+
+// ClassOf returns the error's class, or "" for errors that carry none.
+func ClassOf(err error) ErrorClass {
+	if e, ok := err.(*classifiedError); ok {
+		return e.class
+	}
+	return ""
+}
+
+// IsNotFound checks whether the error is classified NotFound.
+func IsNotFound(err error) bool { return ClassOf(err) == NotFound }
+
+// IsConflict checks whether the error is classified Conflict.
+func IsConflict(err error) bool { return ClassOf(err) == Conflict }
+
+// IsTimeout checks whether the error is classified Timeout.
+func IsTimeout(err error) bool { return ClassOf(err) == Timeout }
+
+// IsDaemonUnavailable checks whether the error is classified DaemonUnavailable.
+func IsDaemonUnavailable(err error) bool { return ClassOf(err) == DaemonUnavailable }
+
+// IsImagePullBackoff checks whether the error is classified ImagePullBackoff.
+func IsImagePullBackoff(err error) bool { return ClassOf(err) == ImagePullBackoff }
+
+// IsImageRejected checks whether the error is classified ImageRejected.
+func IsImageRejected(err error) bool { return ClassOf(err) == ImageRejected }
@@ -0,0 +1,236 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/golang/glog"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+const (
+	// Event types reported via GetContainerEvents.
+	containerEventDied         = "died"
+	containerEventOOM          = "oom"
+	containerEventDestroyed    = "destroyed"
+	containerEventDisconnected = "disconnected"
+	// Published by the periodic network checker for sandboxes whose network
+	// attachment failed verification (see network_check.go).
+	containerEventNetworkUnhealthy = "network_unhealthy"
+
+	// eventStreamRetryPeriod is the delay before re-connecting to the docker
+	// event stream after it fails or returns EOF.
+	eventStreamRetryPeriod = 5 * time.Second
+	// eventChannelSize is the buffer size of per-subscriber event channels.
+	// Events for slow subscribers are dropped once the buffer is full.
+	eventChannelSize = 64
+)
+
+// dockerEvent mirrors the wire format of a docker daemon event. Only the
+// fields the shim consumes are decoded. The legacy (pre-1.10) format carries
+// status/id at the top level instead of Action/Actor.
+type dockerEvent struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	Status   string `json:"status"`
+	ID       string `json:"id"`
+	Time     int64  `json:"time"`
+	TimeNano int64  `json:"timeNano"`
+}
+
+// eventStream fans structured container events out to subscribers.
+type eventStream struct {
+	lock        sync.Mutex
+	nextID      int64
+	subscribers map[int64]chan *runtimeapi.ContainerEvent
+}
+
+func newEventStream() *eventStream {
+	return &eventStream{subscribers: make(map[int64]chan *runtimeapi.ContainerEvent)}
+}
+
+// subscribe registers a new subscriber and returns its event channel along
+// with a cancel function. The channel is closed on cancel.
+func (s *eventStream) subscribe() (<-chan *runtimeapi.ContainerEvent, func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	id := s.nextID
+	s.nextID++
+	ch := make(chan *runtimeapi.ContainerEvent, eventChannelSize)
+	s.subscribers[id] = ch
+	return ch, func() { s.unsubscribe(id) }
+}
+
+func (s *eventStream) unsubscribe(id int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if ch, ok := s.subscribers[id]; ok {
+		delete(s.subscribers, id)
+		close(ch)
+	}
+}
+
+// publish delivers the event to all subscribers without blocking; events for
+// subscribers with a full channel are dropped.
+func (s *eventStream) publish(event *runtimeapi.ContainerEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for id, ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			glog.Warningf("Event subscriber %d is too slow, dropping %s event for %q", id, event.EventType, event.ContainerId)
+		}
+	}
+}
+
+// convertDockerEvent converts a raw docker daemon event into a structured
+// container event. It returns nil for events the shim does not surface.
+func convertDockerEvent(ev *dockerEvent) *runtimeapi.ContainerEvent {
+	containerID := ev.Actor.ID
+	if containerID == "" {
+		containerID = ev.ID
+	}
+	action := ev.Action
+	if action == "" {
+		action = ev.Status
+	}
+
+	var eventType string
+	switch ev.Type {
+	case "", "container":
+		switch action {
+		case "die":
+			eventType = containerEventDied
+		case "oom":
+			eventType = containerEventOOM
+		case "destroy":
+			eventType = containerEventDestroyed
+		}
+	case "network":
+		if action == "disconnect" {
+			// For network events the actor is the network; the container is
+			// carried in the actor attributes.
+			containerID = ev.Actor.Attributes["container"]
+			eventType = containerEventDisconnected
+		}
+	}
+	if eventType == "" || containerID == "" {
+		return nil
+	}
+
+	timestamp := ev.TimeNano
+	if timestamp == 0 {
+		timestamp = ev.Time * int64(time.Second)
+	}
+	return &runtimeapi.ContainerEvent{
+		ContainerId: containerID,
+		EventType:   eventType,
+		Timestamp:   timestamp,
+	}
+}
+
+// SubscribeContainerEvents subscribes to the structured container event
+// stream. The returned function cancels the subscription.
+func (ds *dockerService) SubscribeContainerEvents() (<-chan *runtimeapi.ContainerEvent, func()) {
+	return ds.events.subscribe()
+}
+
+// startEventWatcher starts watching the docker daemon event stream,
+// reconnecting with a fixed delay whenever the stream breaks.
+func (ds *dockerService) startEventWatcher() {
+	go wait.Forever(ds.watchDockerEvents, eventStreamRetryPeriod)
+}
+
+// watchDockerEvents consumes one docker event stream until it fails or
+// returns EOF, publishing the events the shim surfaces and maintaining the
+// label index.
+func (ds *dockerService) watchDockerEvents() {
+	resp, err := ds.client.Events(dockertypes.EventsOptions{})
+	if err != nil {
+		glog.Warningf("Failed to connect to the docker event stream: %v", err)
+		return
+	}
+	defer resp.Close()
+	// Once the stream breaks create/destroy events may be missed, so the
+	// index can no longer be trusted. A broken stream is also the shim's
+	// only signal that the daemon restarted (e.g. with live-restore), so
+	// resync the shim's bookkeeping before reconnecting.
+	defer ds.maybeResync()
+	defer ds.labels.invalidate()
+	defer ds.inspectCache.invalidateAll()
+
+	// Seed the label index with the containers that already exist. The event
+	// stream is connected first so that no concurrent creation is missed.
+	if containers, err := ds.client.ListContainers(dockertypes.ContainerListOptions{All: true}); err != nil {
+		glog.Warningf("Failed to seed the label index, label selectors will not be indexed: %v", err)
+	} else {
+		ds.labels.replaceAll(containers)
+	}
+
+	dec := json.NewDecoder(resp)
+	for {
+		var ev dockerEvent
+		if err := dec.Decode(&ev); err != nil {
+			if err != io.EOF {
+				glog.Warningf("Failed to decode docker event: %v", err)
+			}
+			return
+		}
+		ds.updateLabelIndex(&ev)
+		ds.updateInspectCache(&ev)
+		if event := convertDockerEvent(&ev); event != nil {
+			ds.events.publish(event)
+		}
+	}
+}
+
+// updateLabelIndex applies a raw docker event to the label index. Container
+// create events carry the labels in the actor attributes.
+func (ds *dockerService) updateLabelIndex(ev *dockerEvent) {
+	if ev.Type != "" && ev.Type != "container" {
+		return
+	}
+	action := ev.Action
+	if action == "" {
+		action = ev.Status
+	}
+	containerID := ev.Actor.ID
+	if containerID == "" {
+		containerID = ev.ID
+	}
+	if containerID == "" {
+		return
+	}
+	switch action {
+	case "create":
+		ds.labels.add(containerID, ev.Actor.Attributes)
+	case "destroy":
+		ds.labels.remove(containerID)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+func TestConvertDockerEvent(t *testing.T) {
+	tests := []struct {
+		event    string
+		expected *runtimeapi.ContainerEvent
+	}{{
+		// New-style container die event.
+		event:    `{"Type":"container","Action":"die","Actor":{"ID":"c1"},"timeNano":123}`,
+		expected: &runtimeapi.ContainerEvent{ContainerId: "c1", EventType: containerEventDied, Timestamp: 123},
+	}, {
+		// New-style oom event.
+		event:    `{"Type":"container","Action":"oom","Actor":{"ID":"c2"},"timeNano":456}`,
+		expected: &runtimeapi.ContainerEvent{ContainerId: "c2", EventType: containerEventOOM, Timestamp: 456},
+	}, {
+		// Network disconnect carries the container in the actor attributes.
+		event:    `{"Type":"network","Action":"disconnect","Actor":{"ID":"net1","Attributes":{"container":"c3"}},"timeNano":789}`,
+		expected: &runtimeapi.ContainerEvent{ContainerId: "c3", EventType: containerEventDisconnected, Timestamp: 789},
+	}, {
+		// Legacy format falls back to status/id/time.
+		event:    `{"status":"destroy","id":"c4","time":1}`,
+		expected: &runtimeapi.ContainerEvent{ContainerId: "c4", EventType: containerEventDestroyed, Timestamp: int64(time.Second)},
+	}, {
+		// Events the shim does not surface are dropped.
+		event:    `{"Type":"container","Action":"create","Actor":{"ID":"c5"}}`,
+		expected: nil,
+	}, {
+		event:    `{"Type":"image","Action":"pull"}`,
+		expected: nil,
+	}}
+
+	for i, test := range tests {
+		var ev dockerEvent
+		require.NoError(t, json.Unmarshal([]byte(test.event), &ev), "TestCase[%d]", i)
+		assert.Equal(t, test.expected, convertDockerEvent(&ev), "TestCase[%d]", i)
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	s := newEventStream()
+	ch, cancel := s.subscribe()
+
+	event := &runtimeapi.ContainerEvent{ContainerId: "c1", EventType: containerEventDied}
+	s.publish(event)
+	assert.Equal(t, event, <-ch)
+
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok, "channel should be closed after cancel")
+	// Canceling twice must not panic.
+	cancel()
+}
+
+// TestWatchDockerEvents tests that raw docker events are decoded, converted
+// and delivered to subscribers.
+func TestWatchDockerEvents(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	events, cancel := ds.SubscribeContainerEvents()
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ds.watchDockerEvents()
+	}()
+
+	// Wait for the watcher to connect to the fake event stream.
+	require.NoError(t, wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		fDocker.Lock()
+		defer fDocker.Unlock()
+		return fDocker.EventsWriter != nil, nil
+	}))
+
+	_, err := fDocker.EventsWriter.Write([]byte(`{"Type":"container","Action":"die","Actor":{"ID":"c1"},"timeNano":123}`))
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, &runtimeapi.ContainerEvent{ContainerId: "c1", EventType: containerEventDied, Timestamp: 123}, event)
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timed out waiting for the container event")
+	}
+
+	// Closing the stream terminates the watcher.
+	fDocker.EventsWriter.Close()
+	select {
+	case <-done:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timed out waiting for the watcher to stop")
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// docker exec has no timeout of its own: a liveness probe that wedges inside
+// the container keeps its exec process alive forever while the kubelet
+// starts the next probe, until the daemon drowns in exec sessions. The exec
+// supervisor caps how many execs may run concurrently in one container and
+// abandons sessions whose deadline passed, killing the processes the exec
+// spawned inside the container on a best-effort basis.
+
+// defaultMaxExecsPerContainer is the number of exec sessions that may run
+// concurrently in a single container before new ones are rejected.
+const defaultMaxExecsPerContainer = 10
+
+// execSupervisor tracks in-flight exec sessions per container.
+type execSupervisor struct {
+	lock            sync.Mutex
+	maxPerContainer int
+	active          map[string]int
+}
+
+func newExecSupervisor(maxPerContainer int) *execSupervisor {
+	return &execSupervisor{
+		maxPerContainer: maxPerContainer,
+		active:          make(map[string]int),
+	}
+}
+
+// track registers a new exec session in the container and returns a release
+// function, or rejects the session when the container is at its cap.
+func (s *execSupervisor) track(containerID string) (func(), error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.active[containerID] >= s.maxPerContainer {
+		return nil, fmt.Errorf("container %q already has %d exec sessions in flight", containerID, s.active[containerID])
+	}
+	s.active[containerID]++
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.active[containerID]--; s.active[containerID] <= 0 {
+			delete(s.active, containerID)
+		}
+	}, nil
+}
+
+// supervise runs fn as an exec session in the container, enforcing the
+// per-container cap and, with a positive timeout, the deadline. On expiry
+// the session is abandoned and any process groups the exec created in the
+// container's PID namespace are killed; the goroutine running fn drains on
+// its own once they are gone. initPid is the container's init process, used
+// to identify its PID namespace.
+func (s *execSupervisor) supervise(containerID string, initPid int, timeout time.Duration, fn func() error) error {
+	release, err := s.track(containerID)
+	if err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		defer release()
+		return fn()
+	}
+	// Snapshot the process groups present in the container so that on a
+	// timeout only the groups the exec created are killed.
+	before, err := containerPGIDs(initPid)
+	if err != nil {
+		glog.V(4).Infof("Unable to snapshot process groups of container %q: %v", containerID, err)
+	}
+	done := make(chan error, 1)
+	go func() { defer release(); done <- fn() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		// Without a snapshot the exec's processes cannot be told apart from
+		// the container's own; leave them alone rather than guessing.
+		if before != nil {
+			s.killNewProcessGroups(containerID, initPid, before)
+		}
+		return fmt.Errorf("exec in container %q did not complete within %v", containerID, timeout)
+	}
+}
+
+// killNewProcessGroups kills every process in the container's PID namespace
+// whose process group did not exist when the exec session started.
+func (s *execSupervisor) killNewProcessGroups(containerID string, initPid int, before map[int]bool) {
+	procs, err := containerProcs(initPid)
+	if err != nil {
+		glog.Warningf("Unable to enumerate processes of container %q: %v", containerID, err)
+		return
+	}
+	for pid, pgid := range procs {
+		if pid == initPid || before[pgid] {
+			continue
+		}
+		glog.Warningf("Killing process %d (group %d) of timed out exec in container %q", pid, pgid, containerID)
+		if p, err := os.FindProcess(pid); err == nil {
+			if err := p.Kill(); err != nil {
+				glog.Warningf("Failed to kill process %d of timed out exec in container %q: %v", pid, containerID, err)
+			}
+		}
+	}
+}
+
+// containerPGIDs returns the set of process group IDs present in the
+// container's PID namespace.
+func containerPGIDs(initPid int) (map[int]bool, error) {
+	procs, err := containerProcs(initPid)
+	if err != nil {
+		return nil, err
+	}
+	pgids := make(map[int]bool, len(procs))
+	for _, pgid := range procs {
+		pgids[pgid] = true
+	}
+	return pgids, nil
+}
+
+// containerProcs maps the PID of every process in the container's PID
+// namespace to its process group ID, read from procfs. The namespace is
+// identified by the container's init process.
+func containerProcs(initPid int) (map[int]int, error) {
+	ns, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/pid", initPid))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	procs := make(map[int]int)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		pidNS, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/pid", pid))
+		if err != nil || pidNS != ns {
+			continue
+		}
+		stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			continue
+		}
+		// The command name in the second stat field may contain spaces and
+		// parentheses; the pgid is the third field after the closing paren.
+		rest := string(stat)
+		if i := strings.LastIndex(rest, ")"); i >= 0 {
+			rest = rest[i+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 3 {
+			continue
+		}
+		if pgid, err := strconv.Atoi(fields[2]); err == nil {
+			procs[pid] = pgid
+		}
+	}
+	return procs, nil
+}
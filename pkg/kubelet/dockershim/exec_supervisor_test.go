@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExecSupervisorCap tests that a container at its exec cap rejects
+// additional sessions until one finishes.
+func TestExecSupervisorCap(t *testing.T) {
+	s := newExecSupervisor(2)
+
+	block := make(chan struct{})
+	started := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.supervise("c1", os.Getpid(), 0, func() error {
+				started <- struct{}{}
+				<-block
+				return nil
+			}))
+		}()
+	}
+	<-started
+	<-started
+
+	// The third exec in the same container is rejected; other containers are
+	// unaffected.
+	err := s.supervise("c1", os.Getpid(), 0, func() error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exec sessions in flight")
+	assert.NoError(t, s.supervise("c2", os.Getpid(), 0, func() error { return nil }))
+
+	close(block)
+	wg.Wait()
+	assert.NoError(t, s.supervise("c1", os.Getpid(), 0, func() error { return nil }))
+}
+
+// TestExecSupervisorTimeout tests that a session exceeding its deadline is
+// abandoned with an error while a fast one passes its result through.
+func TestExecSupervisorTimeout(t *testing.T) {
+	s := newExecSupervisor(defaultMaxExecsPerContainer)
+
+	err := s.supervise("c1", os.Getpid(), 50*time.Millisecond, func() error {
+		time.Sleep(10 * time.Second)
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not complete within")
+
+	assert.NoError(t, s.supervise("c1", os.Getpid(), time.Minute, func() error { return nil }))
+}
+
+// TestContainerProcs tests the procfs parsing against the test process's own
+// PID namespace.
+func TestContainerProcs(t *testing.T) {
+	procs, err := containerProcs(os.Getpid())
+	require.NoError(t, err)
+	pgid, ok := procs[os.Getpid()]
+	require.True(t, ok)
+	assert.True(t, pgid > 0)
+}
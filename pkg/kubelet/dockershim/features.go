@@ -0,0 +1,164 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Dockershim-local feature gates. These gate behaviors internal to the shim
+// and are deliberately separate from the kubelet's global feature gates, so
+// that shim behaviors can be rolled out per node fleet in stages.
+const (
+	// netNSPinningFeature pins sandbox network namespaces to bind mounts so
+	// they survive docker daemon restarts.
+	netNSPinningFeature = "NetNSPinning"
+	// criContainerLogsFeature switches container logging to the CRI log path
+	// layout.
+	criContainerLogsFeature = "CRIContainerLogs"
+	// sandboxStatsFeature enables shim-collected sandbox stats.
+	sandboxStatsFeature = "SandboxStats"
+	// sandboxPodCgroupFeature places the sandbox container directly in the
+	// pod-level cgroup (when cgroups-per-qos is enabled) instead of giving
+	// it a dedicated resource allotment.
+	sandboxPodCgroupFeature = "SandboxPodCgroup"
+	// hostportManagerFeature makes the shim program hostport DNAT rules via
+	// iptables instead of docker's publish mechanism.
+	hostportManagerFeature = "HostportManager"
+	// containerLogRotationFeature enforces per-container max-size/max-file
+	// log options so container logs are rotated instead of growing unbounded.
+	containerLogRotationFeature = "ContainerLogRotation"
+	// sharedPIDNamespaceFeature lets workload containers join the sandbox's
+	// PID namespace when the pod requests it.
+	sharedPIDNamespaceFeature = "SharedPIDNamespace"
+	// bandwidthShapingFeature makes the shim apply the pod bandwidth
+	// annotations via tc when the network plugin does not shape traffic
+	// itself.
+	bandwidthShapingFeature = "BandwidthShaping"
+	// checkpointRestoreFeature enables the experimental CRIU-backed container
+	// checkpoint and restore operations.
+	checkpointRestoreFeature = "CheckpointRestore"
+	// jsonTraceLogsFeature emits operation trace log lines as JSON objects
+	// instead of key=value text.
+	jsonTraceLogsFeature = "JSONTraceLogs"
+	// legacyMigrationFeature makes the shim actively replace legacy (pre-1.6
+	// naming scheme) containers by stopping them, instead of waiting for
+	// them to exit on their own.
+	legacyMigrationFeature = "LegacyContainerMigration"
+	// sandboxReadOnlyRootfsFeature starts the sandbox container with a
+	// read-only root filesystem (plus tmpfs mounts on /tmp and /run), to
+	// reduce the attack surface if the pause binary is ever compromised.
+	sandboxReadOnlyRootfsFeature = "SandboxReadOnlyRootfs"
+	// containerInitFeature runs workload containers under docker's init
+	// process (--init) by default, so zombies are reaped even when the
+	// image's entrypoint is not a proper PID 1. Requires docker API 1.25.
+	containerInitFeature = "ContainerInit"
+)
+
+// defaultShimFeatures enumerates the known dockershim feature gates and
+// their defaults.
+var defaultShimFeatures = map[string]bool{
+	netNSPinningFeature:          false,
+	criContainerLogsFeature:      false,
+	sandboxStatsFeature:          false,
+	sandboxPodCgroupFeature:      false,
+	hostportManagerFeature:       false,
+	containerLogRotationFeature:  false,
+	sharedPIDNamespaceFeature:    false,
+	bandwidthShapingFeature:      false,
+	checkpointRestoreFeature:     false,
+	jsonTraceLogsFeature:         false,
+	legacyMigrationFeature:       false,
+	sandboxReadOnlyRootfsFeature: false,
+	containerInitFeature:         false,
+}
+
+// shimFeatureGate holds the dockershim-local feature gates, parsed from the
+// kubelet configuration. It is queryable at runtime via Enabled.
+type shimFeatureGate struct {
+	lock    sync.RWMutex
+	enabled map[string]bool
+}
+
+// newShimFeatureGate returns a feature gate populated with the defaults.
+func newShimFeatureGate() *shimFeatureGate {
+	enabled := make(map[string]bool, len(defaultShimFeatures))
+	for k, v := range defaultShimFeatures {
+		enabled[k] = v
+	}
+	return &shimFeatureGate{enabled: enabled}
+}
+
+// set overrides the defaults from a comma-separated list of key=value pairs,
+// e.g. "NetNSPinning=true,SandboxStats=false".
+func (f *shimFeatureGate) set(value string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, s := range strings.Split(value, ",") {
+		if len(s) == 0 {
+			continue
+		}
+		arr := strings.SplitN(s, "=", 2)
+		key := strings.TrimSpace(arr[0])
+		if _, known := defaultShimFeatures[key]; !known {
+			return fmt.Errorf("unrecognized dockershim feature gate %q (known features: %s)", key, strings.Join(knownShimFeatures(), ", "))
+		}
+		if len(arr) != 2 {
+			return fmt.Errorf("missing bool value for dockershim feature gate %q", key)
+		}
+		boolValue, err := strconv.ParseBool(strings.TrimSpace(arr[1]))
+		if err != nil {
+			return fmt.Errorf("invalid value for dockershim feature gate %q: %v", key, err)
+		}
+		f.enabled[key] = boolValue
+	}
+	return nil
+}
+
+// Enabled returns whether the named feature is enabled. Unknown features are
+// disabled.
+func (f *shimFeatureGate) Enabled(name string) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.enabled[name]
+}
+
+// String returns the gates as a sorted, comma-separated list of key=value
+// pairs.
+func (f *shimFeatureGate) String() string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	pairs := make([]string, 0, len(f.enabled))
+	for k, v := range f.enabled {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func knownShimFeatures() []string {
+	known := make([]string, 0, len(defaultShimFeatures))
+	for k := range defaultShimFeatures {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+	return known
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShimFeatureGateSet(t *testing.T) {
+	tests := []struct {
+		value       string
+		expectError bool
+		expected    map[string]bool
+	}{{
+		value:    "",
+		expected: map[string]bool{netNSPinningFeature: false, criContainerLogsFeature: false, sandboxStatsFeature: false},
+	}, {
+		value:    "NetNSPinning=true",
+		expected: map[string]bool{netNSPinningFeature: true, criContainerLogsFeature: false, sandboxStatsFeature: false},
+	}, {
+		value:    "NetNSPinning=true,SandboxStats=true",
+		expected: map[string]bool{netNSPinningFeature: true, criContainerLogsFeature: false, sandboxStatsFeature: true},
+	}, {
+		value:       "UnknownFeature=true",
+		expectError: true,
+	}, {
+		value:       "NetNSPinning",
+		expectError: true,
+	}, {
+		value:       "NetNSPinning=banana",
+		expectError: true,
+	}}
+
+	for i, test := range tests {
+		fg := newShimFeatureGate()
+		err := fg.set(test.value)
+		if test.expectError {
+			assert.Error(t, err, "TestCase[%d]: %q", i, test.value)
+			continue
+		}
+		assert.NoError(t, err, "TestCase[%d]: %q", i, test.value)
+		for feature, enabled := range test.expected {
+			assert.Equal(t, enabled, fg.Enabled(feature), "TestCase[%d]: %q feature %q", i, test.value, feature)
+		}
+	}
+}
+
+func TestShimFeatureGateString(t *testing.T) {
+	fg := newShimFeatureGate()
+	assert.NoError(t, fg.set("SandboxStats=true"))
+	assert.Equal(t, "BandwidthShaping=false,CRIContainerLogs=false,CheckpointRestore=false,ContainerInit=false,ContainerLogRotation=false,HostportManager=false,JSONTraceLogs=false,LegacyContainerMigration=false,NetNSPinning=false,SandboxPodCgroup=false,SandboxReadOnlyRootfs=false,SandboxStats=true,SharedPIDNamespace=false", fg.String())
+}
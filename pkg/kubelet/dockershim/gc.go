@@ -0,0 +1,203 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+	dockerfilters "github.com/docker/engine-api/types/filters"
+	"github.com/golang/glog"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/clock"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+const (
+	// How often the sandbox garbage collector runs.
+	sandboxGCPeriod = time.Minute
+
+	// Directory in which pinned sandbox network namespaces are kept. Mounts
+	// left behind by sandboxes that no longer exist are cleaned up by the
+	// garbage collector.
+	sandboxNetNSDir = dockershimRootDir + "/netns"
+)
+
+// SandboxGCPolicy specifies when exited sandboxes are garbage collected by
+// the shim. It complements the kubelet container GC, which only looks at
+// workload containers.
+type SandboxGCPolicy struct {
+	// MinAge is the minimum age of an exited sandbox before it may be
+	// removed.
+	MinAge time.Duration
+	// MaxSandboxes is the maximum number of exited sandboxes to keep. A
+	// negative value keeps all exited sandboxes.
+	MaxSandboxes int
+}
+
+// sandboxGC removes exited sandboxes according to a SandboxGCPolicy, along
+// with checkpoints and pinned network namespaces whose sandbox container no
+// longer exists.
+type sandboxGC struct {
+	ds     *dockerService
+	policy SandboxGCPolicy
+	clock  clock.Clock
+}
+
+func newSandboxGC(ds *dockerService, policy SandboxGCPolicy) *sandboxGC {
+	return &sandboxGC{
+		ds:     ds,
+		policy: policy,
+		clock:  clock.RealClock{},
+	}
+}
+
+// run performs garbage collection periodically until the process exits.
+func (gc *sandboxGC) run() {
+	wait.Forever(func() {
+		if err := gc.collect(); err != nil {
+			glog.Errorf("Sandbox garbage collection failed: %v", err)
+		}
+	}, sandboxGCPeriod)
+}
+
+// collect runs a single garbage collection cycle.
+func (gc *sandboxGC) collect() error {
+	errs := []error{}
+	if err := gc.evictSandboxes(); err != nil {
+		errs = append(errs, err)
+	}
+	live, err := gc.ds.sandboxContainerIDs()
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if err := gc.removeStaleCheckpoints(live); err != nil {
+			errs = append(errs, err)
+		}
+		gc.cleanupNetNSMounts(live)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// sandboxContainerIDs returns the IDs of all sandboxes whose container still
+// exists, in any state. The docker client is queried directly because
+// ListPodSandbox also reports checkpoint-only sandboxes, which are exactly
+// the ones the cleanup paths need to find.
+func (ds *dockerService) sandboxContainerIDs() (sets.String, error) {
+	opts := dockertypes.ContainerListOptions{All: true}
+	opts.Filter = dockerfilters.NewArgs()
+	f := newDockerFilter(&opts.Filter)
+	f.AddLabel(containerTypeLabelKey, containerTypeLabelSandbox)
+	containers, err := ds.client.ListContainers(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sandbox containers: %v", err)
+	}
+	live := sets.NewString()
+	for _, c := range containers {
+		live.Insert(c.ID)
+	}
+	return live, nil
+}
+
+// sandboxesByCreated sorts sandboxes by creation time, oldest first.
+type sandboxesByCreated []*runtimeapi.PodSandbox
+
+func (s sandboxesByCreated) Len() int           { return len(s) }
+func (s sandboxesByCreated) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sandboxesByCreated) Less(i, j int) bool { return s[i].CreatedAt < s[j].CreatedAt }
+
+// evictSandboxes removes exited sandboxes so that at most MaxSandboxes of
+// them remain, never removing a sandbox younger than MinAge.
+func (gc *sandboxGC) evictSandboxes() error {
+	sandboxes, err := gc.ds.ListPodSandbox(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list sandboxes: %v", err)
+	}
+	exited := sandboxesByCreated{}
+	for _, s := range sandboxes {
+		if s.State == runtimeapi.PodSandboxState_SANDBOX_NOTREADY {
+			exited = append(exited, s)
+		}
+	}
+	if gc.policy.MaxSandboxes < 0 || len(exited) <= gc.policy.MaxSandboxes {
+		return nil
+	}
+	sort.Sort(exited)
+
+	errs := []error{}
+	for _, s := range exited[:len(exited)-gc.policy.MaxSandboxes] {
+		if gc.clock.Since(time.Unix(0, s.CreatedAt)) < gc.policy.MinAge {
+			continue
+		}
+		glog.V(3).Infof("Garbage collecting exited sandbox %q", s.Id)
+		if err := gc.ds.RemovePodSandbox(s.Id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove sandbox %q: %v", s.Id, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// removeStaleCheckpoints removes checkpoints whose sandbox container no
+// longer exists, e.g. because the container was removed behind the shim's
+// back.
+func (gc *sandboxGC) removeStaleCheckpoints(live sets.String) error {
+	ids, err := gc.ds.checkpointHandler.ListCheckpoints()
+	if err != nil {
+		return fmt.Errorf("failed to list checkpoints: %v", err)
+	}
+	errs := []error{}
+	for _, id := range ids {
+		if live.Has(id) {
+			continue
+		}
+		glog.V(3).Infof("Garbage collecting checkpoint of removed sandbox %q", id)
+		if err := gc.ds.checkpointHandler.RemoveCheckpoint(id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove checkpoint %q: %v", id, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// cleanupNetNSMounts removes pinned network namespaces whose sandbox no
+// longer exists. Cleanup is best-effort: a pin that is still mounted cannot
+// be removed and is retried on the next cycle.
+func (gc *sandboxGC) cleanupNetNSMounts(live sets.String) {
+	entries, err := gc.ds.os.ReadDir(sandboxNetNSDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Warningf("Failed to read netns directory %q: %v", sandboxNetNSDir, err)
+		}
+		return
+	}
+	for _, entry := range entries {
+		if live.Has(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(sandboxNetNSDir, entry.Name())
+		glog.V(3).Infof("Garbage collecting orphaned netns pin %q", path)
+		if err := gc.ds.os.Remove(path); err != nil {
+			glog.Warningf("Failed to remove orphaned netns pin %q: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+)
+
+// TestEvictSandboxes verifies that exited sandboxes over the count threshold
+// are removed oldest first, and that sandboxes younger than MinAge are kept.
+func TestEvictSandboxes(t *testing.T) {
+	ds, fDocker, fClock := newTestDockerService()
+	gc := newSandboxGC(ds, SandboxGCPolicy{MinAge: time.Hour, MaxSandboxes: 1})
+	gc.clock = fClock
+
+	fClock.SetTime(time.Now())
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		id, err := ds.RunPodSandbox(makeSandboxConfig(fmt.Sprintf("foo%d", i), "bar", fmt.Sprintf("%d", i), 0))
+		assert.NoError(t, err)
+		// The fake client does not fill in creation times on its list
+		// entries; the garbage collector needs them to order evictions.
+		fDocker.RunningContainerList[0].Created = fClock.Now().Unix()
+		ids = append(ids, id)
+		fClock.Step(time.Minute)
+	}
+	assert.NoError(t, ds.StopPodSandbox(ids[0]))
+	assert.NoError(t, ds.StopPodSandbox(ids[1]))
+
+	// Both exited sandboxes are younger than MinAge; nothing is removed.
+	assert.NoError(t, gc.evictSandboxes())
+	sandboxes, err := ds.ListPodSandbox(nil)
+	assert.NoError(t, err)
+	assert.Len(t, sandboxes, 3)
+
+	// Once the sandboxes have aged past MinAge, only the oldest exited
+	// sandbox is removed to get back under MaxSandboxes.
+	fClock.Step(2 * time.Hour)
+	assert.NoError(t, gc.evictSandboxes())
+	sandboxes, err = ds.ListPodSandbox(nil)
+	assert.NoError(t, err)
+	assert.Len(t, sandboxes, 2)
+	for _, s := range sandboxes {
+		assert.NotEqual(t, ids[0], s.Id)
+	}
+}
+
+// TestRemoveStaleCheckpoints verifies that checkpoints whose sandbox no
+// longer exists are removed, while checkpoints of live sandboxes are kept.
+func TestRemoveStaleCheckpoints(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	gc := newSandboxGC(ds, SandboxGCPolicy{})
+
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	assert.NoError(t, err)
+	assert.NoError(t, ds.checkpointHandler.CreateCheckpoint("removedsandbox", NewPodSandboxCheckpoint("bar", "gone")))
+
+	live, err := ds.sandboxContainerIDs()
+	assert.NoError(t, err)
+	assert.NoError(t, gc.removeStaleCheckpoints(live))
+
+	_, err = ds.checkpointHandler.GetCheckpoint(id)
+	assert.NoError(t, err)
+	_, err = ds.checkpointHandler.GetCheckpoint("removedsandbox")
+	assert.Equal(t, errors.CheckpointNotFoundError, err)
+}
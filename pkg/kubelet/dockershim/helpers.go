@@ -18,24 +18,28 @@ package dockershim
 
 import (
 	"fmt"
+	"net"
 	"regexp"
+	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/blang/semver"
 	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
 	dockerfilters "github.com/docker/engine-api/types/filters"
 	dockernat "github.com/docker/go-connections/nat"
 	"github.com/golang/glog"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/api/v1"
 	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/convert"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
-	"k8s.io/kubernetes/pkg/kubelet/types"
+	"k8s.io/kubernetes/pkg/kubelet/sysctl"
 )
 
 const (
-	annotationPrefix = "annotation."
+	annotationPrefix = convert.AnnotationPrefix
 )
 
 var (
@@ -44,6 +48,10 @@ var (
 	// Docker changes the security option separator from ':' to '=' in the 1.23
 	// API version.
 	optsSeparatorChangeVersion = semver.MustParse(dockertools.SecurityOptSeparatorChangeVersion)
+
+	// Docker supports joining another container's PID namespace
+	// (--pid=container:<id>) starting with the 1.24 API version.
+	sharedPidNamespaceVersion = semver.MustParse("1.24.0")
 )
 
 // generateEnvList converts KeyValue list to a list of strings, in the form of
@@ -60,50 +68,13 @@ func generateEnvList(envs []*runtimeapi.KeyValue) (result []string) {
 // we *fake* annotations using labels. Note that docker labels are not
 // updatable.
 func makeLabels(labels, annotations map[string]string) map[string]string {
-	merged := make(map[string]string)
-	for k, v := range labels {
-		merged[k] = v
-	}
-	for k, v := range annotations {
-		// Assume there won't be conflict.
-		merged[fmt.Sprintf("%s%s", annotationPrefix, k)] = v
-	}
-	return merged
+	return convert.MakeLabels(labels, annotations)
 }
 
 // extractLabels converts raw docker labels to the CRI labels and annotations.
 // It also filters out internal labels used by this shim.
 func extractLabels(input map[string]string) (map[string]string, map[string]string) {
-	labels := make(map[string]string)
-	annotations := make(map[string]string)
-	for k, v := range input {
-		// Check if the key is used internally by the shim.
-		internal := false
-		for _, internalKey := range internalLabelKeys {
-			if k == internalKey {
-				internal = true
-				break
-			}
-		}
-		if internal {
-			continue
-		}
-
-		// Delete the container name label for the sandbox. It is added in the shim,
-		// should not be exposed via CRI.
-		if k == types.KubernetesContainerNameLabel &&
-			input[containerTypeLabelKey] == containerTypeLabelSandbox {
-			continue
-		}
-
-		// Check if the label should be treated as an annotation.
-		if strings.HasPrefix(k, annotationPrefix) {
-			annotations[strings.TrimPrefix(k, annotationPrefix)] = v
-			continue
-		}
-		labels[k] = v
-	}
-	return labels, annotations
+	return convert.ExtractLabels(input)
 }
 
 // generateMountBindings converts the mount list to a list of strings that
@@ -112,23 +83,35 @@ func extractLabels(input map[string]string) (map[string]string, map[string]strin
 // '<HostPath>:<ContainerPath>', or
 // '<HostPath>:<ContainerPath>:ro', if the path is read only, or
 // '<HostPath>:<ContainerPath>:Z', if the volume requires SELinux
-// relabeling and the pod provides an SELinux label
-func generateMountBindings(mounts []*runtimeapi.Mount) (result []string) {
+// relabeling and the pod provides an SELinux label.
+// A propagation mode requested for the container path (see
+// mount_propagation.go) is appended as an additional bind option.
+func generateMountBindings(mounts []*runtimeapi.Mount, propagation map[string]string) (result []string) {
 	for _, m := range mounts {
 		bind := fmt.Sprintf("%s:%s", m.HostPath, m.ContainerPath)
+		hasOpts := false
 		readOnly := m.Readonly
 		if readOnly {
 			bind += ":ro"
+			hasOpts = true
 		}
 		// Only request relabeling if the pod provides an SELinux context. If the pod
 		// does not provide an SELinux context relabeling will label the volume with
 		// the container's randomly allocated MCS label. This would restrict access
 		// to the volume to the container which mounts it first.
 		if m.SelinuxRelabel {
-			if readOnly {
+			if hasOpts {
 				bind += ",Z"
 			} else {
 				bind += ":Z"
+				hasOpts = true
+			}
+		}
+		if opt := propagation[m.ContainerPath]; opt != "" {
+			if hasOpts {
+				bind += "," + opt
+			} else {
+				bind += ":" + opt
 			}
 		}
 		result = append(result, bind)
@@ -136,6 +119,40 @@ func generateMountBindings(mounts []*runtimeapi.Mount) (result []string) {
 	return
 }
 
+// generateDeviceMappings converts CRI device specifications to docker device
+// mappings, so device plugin allocated devices (GPUs, FPGAs, ...) can be
+// exposed without privileged mode. The container path defaults to the host
+// path and the cgroup permissions default to "mrw"; a device without a host
+// path or with permissions outside of "mrw" is rejected, since docker would
+// only fail the start later with a less direct error.
+func generateDeviceMappings(devices []*runtimeapi.Device) ([]dockercontainer.DeviceMapping, error) {
+	result := make([]dockercontainer.DeviceMapping, 0, len(devices))
+	for _, device := range devices {
+		if device.HostPath == "" {
+			return nil, fmt.Errorf("device host path is required")
+		}
+		containerPath := device.ContainerPath
+		if containerPath == "" {
+			containerPath = device.HostPath
+		}
+		permissions := device.Permissions
+		if permissions == "" {
+			permissions = "mrw"
+		}
+		for _, p := range permissions {
+			if p != 'm' && p != 'r' && p != 'w' {
+				return nil, fmt.Errorf("invalid device permissions %q for device %q", device.Permissions, device.HostPath)
+			}
+		}
+		result = append(result, dockercontainer.DeviceMapping{
+			PathOnHost:        device.HostPath,
+			PathInContainer:   containerPath,
+			CgroupPermissions: permissions,
+		})
+	}
+	return result, nil
+}
+
 func makePortsAndBindings(pm []*runtimeapi.PortMapping) (map[dockernat.Port]struct{}, map[dockernat.Port][]dockernat.PortBinding) {
 	exposedPorts := map[dockernat.Port]struct{}{}
 	portBindings := map[dockernat.Port][]dockernat.PortBinding{}
@@ -149,10 +166,10 @@ func makePortsAndBindings(pm []*runtimeapi.PortMapping) (map[dockernat.Port]stru
 		// Some of this port stuff is under-documented voodoo.
 		// See http://stackoverflow.com/questions/20428302/binding-a-port-to-a-host-interface-using-the-rest-api
 		var protocol string
-		switch strings.ToUpper(string(port.Protocol)) {
-		case "UDP":
+		switch port.Protocol {
+		case runtimeapi.Protocol_UDP:
 			protocol = "/udp"
-		case "TCP":
+		case runtimeapi.Protocol_TCP:
 			protocol = "/tcp"
 		default:
 			glog.Warningf("Unknown protocol %q: defaulting to TCP", port.Protocol)
@@ -162,6 +179,12 @@ func makePortsAndBindings(pm []*runtimeapi.PortMapping) (map[dockernat.Port]stru
 		dockerPort := dockernat.Port(strconv.Itoa(int(interiorPort)) + protocol)
 		exposedPorts[dockerPort] = struct{}{}
 
+		// Honor the requested host IP, which may be an IPv4 or IPv6 literal.
+		// Docker accepts both families unbracketed in the port binding.
+		if port.HostIp != "" && net.ParseIP(port.HostIp) == nil {
+			glog.Warningf("Invalid hostIP %q in port mapping, skipping the binding for container port %d", port.HostIp, interiorPort)
+			continue
+		}
 		hostBinding := dockernat.PortBinding{
 			HostPort: strconv.Itoa(int(exteriorPort)),
 			HostIP:   port.HostIp,
@@ -184,23 +207,25 @@ func makePortsAndBindings(pm []*runtimeapi.PortMapping) (map[dockernat.Port]stru
 // getContainerSecurityOpt gets container security options from container and sandbox config, currently from sandbox
 // annotations.
 // It is an experimental feature and may be promoted to official runtime api in the future.
-func getContainerSecurityOpts(containerName string, sandboxConfig *runtimeapi.PodSandboxConfig, seccompProfileRoot string, separator rune) ([]string, error) {
-	appArmorOpts, err := dockertools.GetAppArmorOpts(sandboxConfig.GetAnnotations(), containerName)
+func getContainerSecurityOpts(containerName string, sandboxConfig *runtimeapi.PodSandboxConfig, seccompProfiles *seccompProfileCache, separator rune, profile *securityProfile, defaultSeccomp string) ([]string, error) {
+	// The configured security profile contributes its defaults first; the
+	// pod's own options follow and take precedence.
+	securityOpts := profile.securityOpts()
+	appArmorOpts, err := getAppArmorSecurityOpts(sandboxConfig.GetAnnotations(), containerName, separator)
 	if err != nil {
 		return nil, err
 	}
-	seccompOpts, err := dockertools.GetSeccompOpts(sandboxConfig.GetAnnotations(), containerName, seccompProfileRoot)
+	securityOpts = append(securityOpts, appArmorOpts...)
+	seccompOpts, err := seccompProfiles.getSeccompSecurityOpts(sandboxConfig.GetAnnotations(), containerName, separator, defaultSeccomp)
 	if err != nil {
 		return nil, err
 	}
-	securityOpts := append(appArmorOpts, seccompOpts...)
-	fmtOpts := dockertools.FmtDockerOpts(securityOpts, separator)
-	return fmtOpts, nil
+	return append(securityOpts, seccompOpts...), nil
 }
 
-func getSandboxSecurityOpts(sandboxConfig *runtimeapi.PodSandboxConfig, seccompProfileRoot string, separator rune) ([]string, error) {
+func getSandboxSecurityOpts(sandboxConfig *runtimeapi.PodSandboxConfig, seccompProfiles *seccompProfileCache, separator rune, profile *securityProfile, defaultSeccomp string) ([]string, error) {
 	// sandboxContainerName doesn't exist in the pod, so pod security options will be returned by default.
-	return getContainerSecurityOpts(sandboxContainerName, sandboxConfig, seccompProfileRoot, separator)
+	return getContainerSecurityOpts(sandboxContainerName, sandboxConfig, seccompProfiles, separator, profile, defaultSeccomp)
 }
 
 func getNetworkNamespace(c *dockertypes.ContainerJSON) string {
@@ -213,6 +238,45 @@ func getNetworkNamespace(c *dockertypes.ContainerJSON) string {
 	return fmt.Sprintf(dockerNetNSFmt, c.State.Pid)
 }
 
+// getSandboxSysctls returns the sysctls requested for a sandbox. First-class
+// sysctls in the sandbox config take precedence over the experimental
+// annotations, which are only consulted as a fallback for compatibility.
+func getSandboxSysctls(config *runtimeapi.PodSandboxConfig) (map[string]string, error) {
+	if sysctls := config.GetLinux().GetSysctls(); len(sysctls) > 0 {
+		if err := validateSandboxSysctls(sysctls, config.GetLinux().GetSecurityContext().GetNamespaceOptions()); err != nil {
+			return nil, err
+		}
+		return sysctls, nil
+	}
+	return getSysctlsFromAnnotations(config.GetAnnotations())
+}
+
+// validateSandboxSysctls checks that each requested sysctl is namespaced in
+// the kernel and is not masked by a host namespace the sandbox shares.
+// Sysctls outside the safe whitelist are applied but logged, matching the
+// semantics of the unsafe sysctl annotation.
+func validateSandboxSysctls(sysctls map[string]string, nsOpts *runtimeapi.NamespaceOption) error {
+	safeSysctls := sets.NewString(sysctl.SafeSysctlWhitelist()...)
+	for s := range sysctls {
+		ns := sysctl.NamespacedBy(s)
+		if ns == sysctl.UnknownNamespace {
+			return fmt.Errorf("sysctl %q is not known to be namespaced and cannot be set in a sandbox", s)
+		}
+		if nsOpts != nil {
+			if ns == sysctl.IpcNamespace && nsOpts.HostIpc {
+				return fmt.Errorf("sysctl %q is not allowed for a sandbox sharing the host IPC namespace", s)
+			}
+			if ns == sysctl.NetNamespace && nsOpts.HostNetwork {
+				return fmt.Errorf("sysctl %q is not allowed for a sandbox sharing the host network namespace", s)
+			}
+		}
+		if !safeSysctls.Has(s) {
+			glog.V(4).Infof("Applying unsafe sysctl %q to sandbox", s)
+		}
+	}
+	return nil
+}
+
 // getSysctlsFromAnnotations gets sysctls from annotations.
 func getSysctlsFromAnnotations(annotations map[string]string) (map[string]string, error) {
 	var results map[string]string
@@ -255,19 +319,7 @@ func (f *dockerFilter) AddLabel(key, value string) {
 // getUserFromImageUser gets uid or user name of the image user.
 // If user is numeric, it will be treated as uid; or else, it is treated as user name.
 func getUserFromImageUser(imageUser string) (*int64, string) {
-	user := dockertools.GetUserFromImageUser(imageUser)
-	// return both nil if user is not specified in the image.
-	if user == "" {
-		return nil, ""
-	}
-	// user could be either uid or user name. Try to interpret as numeric uid.
-	uid, err := strconv.ParseInt(user, 10, 64)
-	if err != nil {
-		// If user is non numeric, assume it's user name.
-		return nil, user
-	}
-	// If user is a numeric uid.
-	return &uid, ""
+	return convert.GetUserFromImageUser(imageUser)
 }
 
 // See #33189. If the previous attempt to create a sandbox container name FOO
@@ -295,7 +347,7 @@ func recoverFromCreationConflictIfNeeded(client dockertools.DockerInterface, cre
 	} else {
 		glog.Errorf("Failed to remove the conflicting container %q: %v", id, rmErr)
 		// Return if the error is not container not found error.
-		if !dockertools.IsContainerNotFoundError(rmErr) {
+		if !isContainerNotFound(rmErr) {
 			return nil, err
 		}
 	}
@@ -320,3 +372,52 @@ func getSecurityOptSeparator(v *semver.Version) rune {
 		return dockertools.SecurityOptSeparatorNew
 	}
 }
+
+// podSandboxByID sorts sandboxes by ID so that continuation tokens are stable
+// across paginated list calls.
+type podSandboxByID []*runtimeapi.PodSandbox
+
+func (s podSandboxByID) Len() int           { return len(s) }
+func (s podSandboxByID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s podSandboxByID) Less(i, j int) bool { return s[i].Id < s[j].Id }
+
+// containerByID is podSandboxByID for containers.
+type containerByID []*runtimeapi.Container
+
+func (s containerByID) Len() int           { return len(s) }
+func (s containerByID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s containerByID) Less(i, j int) bool { return s[i].Id < s[j].Id }
+
+// paginateSandboxes sorts the sandboxes by ID and returns the page selected
+// by limit and continueToken, along with the token for the next page. An
+// empty next token means there are no more items.
+func paginateSandboxes(items []*runtimeapi.PodSandbox, limit int32, continueToken string) ([]*runtimeapi.PodSandbox, string) {
+	sort.Sort(podSandboxByID(items))
+	start := 0
+	if continueToken != "" {
+		start = sort.Search(len(items), func(i int) bool { return items[i].Id > continueToken })
+	}
+	end := len(items)
+	next := ""
+	if limit > 0 && start+int(limit) < end {
+		end = start + int(limit)
+		next = items[end-1].Id
+	}
+	return items[start:end], next
+}
+
+// paginateContainers is paginateSandboxes for containers.
+func paginateContainers(items []*runtimeapi.Container, limit int32, continueToken string) ([]*runtimeapi.Container, string) {
+	sort.Sort(containerByID(items))
+	start := 0
+	if continueToken != "" {
+		start = sort.Search(len(items), func(i int) bool { return items[i].Id > continueToken })
+	}
+	end := len(items)
+	next := ""
+	if limit > 0 && start+int(limit) < end {
+		end = start + int(limit)
+		next = items[end-1].Id
+	}
+	return items[start:end], next
+}
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/blang/semver"
+	dockercontainer "github.com/docker/engine-api/types/container"
+	dockernat "github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -43,6 +45,7 @@ func TestLabelsAndAnnotationsRoundTrip(t *testing.T) {
 // The actual profile loading logic is tested in dockertools.
 // TODO: Migrate the corresponding test to dockershim.
 func TestGetContainerSecurityOpts(t *testing.T) {
+	defer setLoadedAppArmorProfiles(t, "foo")()
 	containerName := "bar"
 	makeConfig := func(annotations map[string]string) *runtimeapi.PodSandboxConfig {
 		return makeSandboxConfigWithLabelsAndAnnotations("pod", "ns", "1234", 1, nil, annotations)
@@ -96,7 +99,7 @@ func TestGetContainerSecurityOpts(t *testing.T) {
 	}}
 
 	for i, test := range tests {
-		opts, err := getContainerSecurityOpts(containerName, test.config, "test/seccomp/profile/root", '=')
+		opts, err := getContainerSecurityOpts(containerName, test.config, newSeccompProfileCache("test/seccomp/profile/root"), '=', nil, "")
 		assert.NoError(t, err, "TestCase[%d]: %s", i, test.msg)
 		assert.Len(t, opts, len(test.expectedOpts), "TestCase[%d]: %s", i, test.msg)
 		for _, opt := range test.expectedOpts {
@@ -141,7 +144,7 @@ func TestGetSandboxSecurityOpts(t *testing.T) {
 	}}
 
 	for i, test := range tests {
-		opts, err := getSandboxSecurityOpts(test.config, "test/seccomp/profile/root", '=')
+		opts, err := getSandboxSecurityOpts(test.config, newSeccompProfileCache("test/seccomp/profile/root"), '=', nil, "")
 		assert.NoError(t, err, "TestCase[%d]: %s", i, test.msg)
 		assert.Len(t, opts, len(test.expectedOpts), "TestCase[%d]: %s", i, test.msg)
 		for _, opt := range test.expectedOpts {
@@ -191,6 +194,60 @@ func TestGetSystclsFromAnnotations(t *testing.T) {
 }
 
 // TestGetUserFromImageUser tests the logic of getting image uid or user name of image user.
+func TestGetSandboxSysctls(t *testing.T) {
+	tests := []struct {
+		sysctls         map[string]string
+		nsOpts          *runtimeapi.NamespaceOption
+		annotations     map[string]string
+		expectedSysctls map[string]string
+		expectError     bool
+	}{{
+		// Native sysctls take precedence over annotations.
+		sysctls: map[string]string{"net.ipv4.ip_local_port_range": "1024 65535"},
+		annotations: map[string]string{
+			v1.SysctlsPodAnnotationKey: "kernel.shmmni=32768",
+		},
+		expectedSysctls: map[string]string{"net.ipv4.ip_local_port_range": "1024 65535"},
+	}, {
+		// Non-namespaced sysctls are rejected.
+		sysctls:     map[string]string{"kernel.panic": "1"},
+		expectError: true,
+	}, {
+		// Network sysctls are rejected for host network sandboxes.
+		sysctls:     map[string]string{"net.ipv4.tcp_syncookies": "1"},
+		nsOpts:      &runtimeapi.NamespaceOption{HostNetwork: true},
+		expectError: true,
+	}, {
+		// IPC sysctls are rejected for host IPC sandboxes.
+		sysctls:     map[string]string{"kernel.shmmax": "1000000000"},
+		nsOpts:      &runtimeapi.NamespaceOption{HostIpc: true},
+		expectError: true,
+	}, {
+		// Without native sysctls, fall back to annotations.
+		annotations: map[string]string{
+			v1.SysctlsPodAnnotationKey: "kernel.shmmni=32768",
+		},
+		expectedSysctls: map[string]string{"kernel.shmmni": "32768"},
+	}}
+
+	for i, test := range tests {
+		config := makeSandboxConfigWithLabelsAndAnnotations("pod", "ns", "1234", 1, nil, test.annotations)
+		config.Linux = &runtimeapi.LinuxPodSandboxConfig{
+			Sysctls: test.sysctls,
+			SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+				NamespaceOptions: test.nsOpts,
+			},
+		}
+		actual, err := getSandboxSysctls(config)
+		if test.expectError {
+			assert.Error(t, err, "TestCase[%d]", i)
+			continue
+		}
+		assert.NoError(t, err, "TestCase[%d]", i)
+		assert.Equal(t, test.expectedSysctls, actual, "TestCase[%d]", i)
+	}
+}
+
 func TestGetUserFromImageUser(t *testing.T) {
 	newI64 := func(i int64) *int64 { return &i }
 	for c, test := range map[string]struct {
@@ -261,3 +318,96 @@ func TestGetSecurityOptSeparator(t *testing.T) {
 		assert.Equal(t, test.expected, actual, c)
 	}
 }
+
+// TestMakePortsAndBindings tests that port bindings honor the requested host
+// IP for both IPv4 and IPv6, and that invalid host IPs are skipped.
+func TestGenerateDeviceMappings(t *testing.T) {
+	devices, err := generateDeviceMappings([]*runtimeapi.Device{
+		{HostPath: "/dev/nvidia0", ContainerPath: "/dev/nvidia0", Permissions: "rwm"},
+		{HostPath: "/dev/fpga0"},
+	})
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, dockercontainer.DeviceMapping{PathOnHost: "/dev/nvidia0", PathInContainer: "/dev/nvidia0", CgroupPermissions: "rwm"}, devices[0])
+	// Container path and permissions are defaulted.
+	assert.Equal(t, dockercontainer.DeviceMapping{PathOnHost: "/dev/fpga0", PathInContainer: "/dev/fpga0", CgroupPermissions: "mrw"}, devices[1])
+
+	// A device without a host path is rejected.
+	_, err = generateDeviceMappings([]*runtimeapi.Device{{ContainerPath: "/dev/null"}})
+	assert.Error(t, err)
+
+	// Permissions outside of "mrw" are rejected.
+	_, err = generateDeviceMappings([]*runtimeapi.Device{{HostPath: "/dev/null", Permissions: "rwx"}})
+	assert.Error(t, err)
+}
+
+func TestMakePortsAndBindings(t *testing.T) {
+	pm := []*runtimeapi.PortMapping{{
+		Protocol:      runtimeapi.Protocol_TCP,
+		ContainerPort: 80,
+		HostPort:      8080,
+	}, {
+		Protocol:      runtimeapi.Protocol_UDP,
+		ContainerPort: 53,
+		HostPort:      53,
+		HostIp:        "10.0.0.1",
+	}, {
+		Protocol:      runtimeapi.Protocol_TCP,
+		ContainerPort: 443,
+		HostPort:      8443,
+		HostIp:        "2001:db8::1",
+	}, {
+		Protocol:      runtimeapi.Protocol_TCP,
+		ContainerPort: 443,
+		HostPort:      9443,
+		HostIp:        "not-an-ip",
+	}}
+
+	exposedPorts, portBindings := makePortsAndBindings(pm)
+	assert.Len(t, exposedPorts, 3)
+	assert.Equal(t, []dockernat.PortBinding{{HostPort: "8080"}}, portBindings["80/tcp"])
+	assert.Equal(t, []dockernat.PortBinding{{HostPort: "53", HostIP: "10.0.0.1"}}, portBindings["53/udp"])
+	// The IPv6 binding is kept; the invalid host IP is dropped.
+	assert.Equal(t, []dockernat.PortBinding{{HostPort: "8443", HostIP: "2001:db8::1"}}, portBindings["443/tcp"])
+}
+
+func TestPaginateSandboxes(t *testing.T) {
+	makeItems := func(ids ...string) []*runtimeapi.PodSandbox {
+		items := make([]*runtimeapi.PodSandbox, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, &runtimeapi.PodSandbox{Id: id})
+		}
+		return items
+	}
+
+	// No limit returns everything sorted with no continuation token.
+	page, next := paginateSandboxes(makeItems("c", "a", "b"), 0, "")
+	assert.Equal(t, makeItems("a", "b", "c"), page)
+	assert.Empty(t, next)
+
+	// Walk all items two at a time.
+	page, next = paginateSandboxes(makeItems("c", "a", "e", "b", "d"), 2, "")
+	assert.Equal(t, makeItems("a", "b"), page)
+	assert.Equal(t, "b", next)
+	page, next = paginateSandboxes(makeItems("c", "a", "e", "b", "d"), 2, next)
+	assert.Equal(t, makeItems("c", "d"), page)
+	assert.Equal(t, "d", next)
+	page, next = paginateSandboxes(makeItems("c", "a", "e", "b", "d"), 2, next)
+	assert.Equal(t, makeItems("e"), page)
+	assert.Empty(t, next)
+
+	// An unknown token resumes after its sort position.
+	page, next = paginateSandboxes(makeItems("a", "c"), 0, "b")
+	assert.Equal(t, makeItems("c"), page)
+	assert.Empty(t, next)
+}
+
+func TestPaginateContainers(t *testing.T) {
+	items := []*runtimeapi.Container{{Id: "b"}, {Id: "a"}, {Id: "c"}}
+	page, next := paginateContainers(items, 2, "")
+	assert.Equal(t, []*runtimeapi.Container{{Id: "a"}, {Id: "b"}}, page)
+	assert.Equal(t, "b", next)
+	page, next = paginateContainers(items, 2, next)
+	assert.Equal(t, []*runtimeapi.Container{{Id: "c"}}, page)
+	assert.Empty(t, next)
+}
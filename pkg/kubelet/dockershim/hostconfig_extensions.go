@@ -0,0 +1,159 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// This file implements a guarded extension mechanism that maps a vetted set
+// of pod annotations onto docker HostConfig fields, so operators can unlock
+// docker features the CRI does not carry without forking the shim. Each
+// extension must be allowlisted on the kubelet command line before its
+// annotation is honored; an annotation for an extension the operator has
+// not enabled fails the pod with a clear error instead of being silently
+// dropped.
+
+const (
+	// ulimitsAnnotationKey sets ulimits on the workload containers, comma
+	// separated in docker's notation (e.g. "nofile=65536:65536,memlock=-1").
+	ulimitsAnnotationKey = "docker.alpha.kubernetes.io/ulimits"
+	// ipcModeAnnotationKey sets the sandbox's IPC mode to "private" or
+	// "shareable". Host IPC has a first-class security context field and
+	// may not be requested this way.
+	ipcModeAnnotationKey = "docker.alpha.kubernetes.io/ipc-mode"
+)
+
+// Extension names, as used in the kubelet allowlist flag. The shm size
+// started out as an extension and is now a first-class pod setting (see
+// shm_size.go); its old name stays accepted in the allowlist so existing
+// kubelet command lines keep working.
+const (
+	shmSizeExtension = "shm-size"
+	ulimitsExtension = "ulimits"
+	ipcModeExtension = "ipc-mode"
+)
+
+// hostConfigExtension describes one vetted annotation: where it lands
+// (sandbox or workload container host config) and how its value is applied.
+type hostConfigExtension struct {
+	annotation string
+	sandbox    bool
+	container  bool
+	apply      func(value string, hc *dockercontainer.HostConfig) error
+}
+
+var knownHostConfigExtensions = map[string]*hostConfigExtension{
+	// shm-size is ungated now; the entry only keeps the name recognized.
+	shmSizeExtension: {annotation: shmSizeAnnotationKey},
+	ulimitsExtension: {annotation: ulimitsAnnotationKey, container: true, apply: applyUlimits},
+	ipcModeExtension: {annotation: ipcModeAnnotationKey, sandbox: true, apply: applyIPCMode},
+}
+
+// hostConfigExtensions holds the extensions the operator has allowlisted.
+// The nil value (and an empty allowlist) rejects every extension annotation.
+type hostConfigExtensions struct {
+	allowed sets.String
+}
+
+// newHostConfigExtensions parses the comma-separated allowlist.
+func newHostConfigExtensions(allowlist string) (*hostConfigExtensions, error) {
+	allowed := sets.NewString()
+	for _, name := range strings.Split(allowlist, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, known := knownHostConfigExtensions[name]; !known {
+			return nil, fmt.Errorf("unknown dockershim hostconfig extension %q (known extensions: %s)", name, strings.Join(knownHostConfigExtensionNames(), ", "))
+		}
+		allowed.Insert(name)
+	}
+	return &hostConfigExtensions{allowed: allowed}, nil
+}
+
+// enabled reports whether the operator has allowlisted the named extension.
+func (e *hostConfigExtensions) enabled(name string) bool {
+	return e != nil && e.allowed.Has(name)
+}
+
+// applySandbox applies the sandbox-scoped extension annotations.
+func (e *hostConfigExtensions) applySandbox(c *runtimeapi.PodSandboxConfig, hc *dockercontainer.HostConfig) error {
+	return e.applyAnnotations(c.GetAnnotations(), hc, true)
+}
+
+// applyContainer applies the container-scoped extension annotations.
+func (e *hostConfigExtensions) applyContainer(sandboxConfig *runtimeapi.PodSandboxConfig, hc *dockercontainer.HostConfig) error {
+	return e.applyAnnotations(sandboxConfig.GetAnnotations(), hc, false)
+}
+
+func (e *hostConfigExtensions) applyAnnotations(annotations map[string]string, hc *dockercontainer.HostConfig, sandbox bool) error {
+	for _, name := range knownHostConfigExtensionNames() {
+		ext := knownHostConfigExtensions[name]
+		value, ok := annotations[ext.annotation]
+		if !ok {
+			continue
+		}
+		applies := ext.container
+		if sandbox {
+			applies = ext.sandbox
+		}
+		if !applies {
+			continue
+		}
+		if e == nil || !e.allowed.Has(name) {
+			return fmt.Errorf("annotation %q requires the %q dockershim hostconfig extension, which is not enabled on this node", ext.annotation, name)
+		}
+		if err := ext.apply(value, hc); err != nil {
+			return fmt.Errorf("invalid value %q for annotation %q: %v", value, ext.annotation, err)
+		}
+	}
+	return nil
+}
+
+func applyUlimits(value string, hc *dockercontainer.HostConfig) error {
+	ulimits, err := parseUlimits(value)
+	if err != nil {
+		return err
+	}
+	hc.Resources.Ulimits = append(hc.Resources.Ulimits, ulimits...)
+	return nil
+}
+
+func applyIPCMode(value string, hc *dockercontainer.HostConfig) error {
+	if value != "private" && value != "shareable" {
+		return fmt.Errorf("ipc mode must be \"private\" or \"shareable\"")
+	}
+	hc.IpcMode = dockercontainer.IpcMode(value)
+	return nil
+}
+
+func knownHostConfigExtensionNames() []string {
+	names := make([]string, 0, len(knownHostConfigExtensions))
+	for name := range knownHostConfigExtensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestNewHostConfigExtensions tests the allowlist parsing.
+func TestNewHostConfigExtensions(t *testing.T) {
+	_, err := newHostConfigExtensions("")
+	assert.NoError(t, err)
+
+	extensions, err := newHostConfigExtensions("shm-size, ulimits")
+	require.NoError(t, err)
+	assert.True(t, extensions.allowed.Has(shmSizeExtension))
+	assert.True(t, extensions.allowed.Has(ulimitsExtension))
+	assert.False(t, extensions.allowed.Has(ipcModeExtension))
+
+	_, err = newHostConfigExtensions("shm-size,banana")
+	assert.Error(t, err)
+}
+
+// TestHostConfigExtensionsPolicy tests that extension annotations are
+// rejected unless the operator has allowlisted them.
+func TestHostConfigExtensionsPolicy(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	config := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{},
+		map[string]string{ipcModeAnnotationKey: "shareable"})
+
+	// Nothing is allowlisted on the test service.
+	_, err := ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not enabled on this node")
+
+	extensions, err := newHostConfigExtensions("ipc-mode")
+	require.NoError(t, err)
+	ds.hostConfigExtensions = extensions
+	createConfig, err := ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Equal(t, "shareable", string(createConfig.HostConfig.IpcMode))
+
+	// Invalid values are rejected even when the extension is allowed.
+	config.Annotations[ipcModeAnnotationKey] = "host"
+	_, err = ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	assert.Error(t, err)
+}
+
+// TestHostConfigExtensionScopes tests that each extension lands on the
+// sandbox or the workload container as documented.
+func TestHostConfigExtensionScopes(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	extensions, err := newHostConfigExtensions("shm-size,ulimits,ipc-mode")
+	require.NoError(t, err)
+	ds.hostConfigExtensions = extensions
+
+	sConfig := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{},
+		map[string]string{
+			shmSizeAnnotationKey: "64Mi",
+			ulimitsAnnotationKey: "nofile=65536:65536,memlock=-1",
+			ipcModeAnnotationKey: "shareable",
+		})
+
+	sandboxConfig, err := ds.makeSandboxDockerConfig(sConfig, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Equal(t, int64(64*1024*1024), sandboxConfig.HostConfig.ShmSize)
+	assert.Equal(t, "shareable", string(sandboxConfig.HostConfig.IpcMode))
+	assert.Empty(t, sandboxConfig.HostConfig.Ulimits)
+
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil, nil)
+	config.Linux = &runtimeapi.LinuxContainerConfig{}
+	containerConfig, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.Len(t, containerConfig.HostConfig.Ulimits, 2)
+	assert.Equal(t, "nofile", containerConfig.HostConfig.Ulimits[0].Name)
+	assert.Equal(t, int64(65536), containerConfig.HostConfig.Ulimits[0].Hard)
+	// The shm size and IPC mode stay on the sandbox; the containers join
+	// its IPC namespace.
+	assert.Zero(t, containerConfig.HostConfig.ShmSize)
+	assert.Equal(t, "container:sandboxid", string(containerConfig.HostConfig.IpcMode))
+
+	// Malformed ulimits are rejected.
+	sConfig.Annotations[ulimitsAnnotationKey] = "nofile=high"
+	_, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strconv"
+
+	"github.com/golang/glog"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+)
+
+// This file implements the shim-managed hostport manager. Docker's publish
+// mechanism (-p) binds host ports through docker-proxy and conflicts with
+// CNI-managed pod networking. When the HostportManager feature gate is
+// enabled, the shim programs the DNAT rules itself: one chain per sandbox in
+// the nat table, reached from PREROUTING and OUTPUT via a shared parent
+// chain. The checkpointed port mappings tell the shim on teardown whether a
+// sandbox had hostports, even if the sandbox container is already gone.
+
+const (
+	// hostportChain is the parent chain holding one jump rule per sandbox
+	// with host ports.
+	hostportChain utiliptables.Chain = "KUBE-SHIM-HP"
+)
+
+// hostportChainName returns the name of the per-sandbox hostport chain.
+// Chain names are limited to 28 characters, so the sandbox ID is hashed.
+func hostportChainName(podSandboxID string) utiliptables.Chain {
+	hash := fnv.New32a()
+	hash.Write([]byte(podSandboxID))
+	return utiliptables.Chain(fmt.Sprintf("%s-%08X", hostportChain, hash.Sum32()))
+}
+
+// hostportRuleArgs builds the iptables rule matching the host port of the
+// given mapping and DNATing it to the pod IP. An explicit host IP in the
+// mapping restricts the rule to that address.
+func hostportRuleArgs(pm *runtimeapi.PortMapping, podIP string) []string {
+	proto := "tcp"
+	if pm.Protocol == runtimeapi.Protocol_UDP {
+		proto = "udp"
+	}
+	args := []string{"-m", "comment", "--comment", "dockershim hostport"}
+	if pm.HostIp != "" {
+		args = append(args, "-d", pm.HostIp)
+	}
+	args = append(args,
+		"-p", proto, "--dport", strconv.Itoa(int(pm.HostPort)),
+		"-j", "DNAT", "--to-destination", net.JoinHostPort(podIP, strconv.Itoa(int(pm.ContainerPort))))
+	return args
+}
+
+// hasHostPorts returns whether any of the mappings requests a host port.
+func hasHostPorts(portMappings []*runtimeapi.PortMapping) bool {
+	for _, pm := range portMappings {
+		if pm.HostPort > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSandboxHostPortConflicts refuses sandbox creation when one of the
+// requested host ports is already claimed by a ready sandbox. Docker would
+// only fail the start later with an opaque bind error; the checkpoints name
+// the owning pod, so the error can say who holds the port. Ports bound to
+// different explicit host IPs do not conflict.
+func (ds *dockerService) checkSandboxHostPortConflicts(config *runtimeapi.PodSandboxConfig) error {
+	if !hasHostPorts(config.GetPortMappings()) {
+		return nil
+	}
+	readyState := runtimeapi.PodSandboxState_SANDBOX_READY
+	sandboxes, err := ds.ListPodSandbox(&runtimeapi.PodSandboxFilter{
+		State: &runtimeapi.PodSandboxStateValue{State: readyState},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list sandboxes: %v", err)
+	}
+	for _, sandbox := range sandboxes {
+		checkpoint, err := ds.checkpointHandler.GetCheckpoint(sandbox.Id)
+		if err != nil {
+			// Sandboxes without a readable checkpoint predate the shim's port
+			// bookkeeping; leave their conflicts to docker.
+			continue
+		}
+		for _, used := range checkpoint.Data.PortMappings {
+			if used.HostPort == nil || *used.HostPort <= 0 {
+				continue
+			}
+			for _, pm := range config.GetPortMappings() {
+				if pm.HostPort != *used.HostPort {
+					continue
+				}
+				if used.Protocol != nil && toCheckpointProtocol(pm.Protocol) != *used.Protocol {
+					continue
+				}
+				if pm.HostIp != "" && used.HostIp != "" && pm.HostIp != used.HostIp {
+					continue
+				}
+				return fmt.Errorf("host port %d/%s requested by pod %s/%s is already in use by pod %s/%s (sandbox %q)",
+					pm.HostPort, toCheckpointProtocol(pm.Protocol), config.GetMetadata().Namespace, config.GetMetadata().Name,
+					checkpoint.Namespace, checkpoint.Name, sandbox.Id)
+			}
+		}
+	}
+	return nil
+}
+
+// setUpSandboxHostPorts programs the DNAT rules for the sandbox's port
+// mappings. It must be called after the network plugin has set up the pod,
+// since it needs the pod IP.
+func (ds *dockerService) setUpSandboxHostPorts(podSandboxID string, portMappings []*runtimeapi.PortMapping) error {
+	if !hasHostPorts(portMappings) {
+		return nil
+	}
+	r, err := ds.client.InspectContainer(podSandboxID)
+	if err != nil {
+		return err
+	}
+	podIP, err := ds.getIP(r)
+	if err != nil {
+		return err
+	}
+	if podIP == "" {
+		return fmt.Errorf("no IP found for sandbox %q", podSandboxID)
+	}
+
+	chain := hostportChainName(podSandboxID)
+	if _, err := ds.iptables.EnsureChain(utiliptables.TableNAT, hostportChain); err != nil {
+		return err
+	}
+	if _, err := ds.iptables.EnsureChain(utiliptables.TableNAT, chain); err != nil {
+		return err
+	}
+	// Rebuild the per-sandbox chain from scratch to drop stale rules from a
+	// previous attempt.
+	if err := ds.iptables.FlushChain(utiliptables.TableNAT, chain); err != nil {
+		return err
+	}
+	for _, pm := range portMappings {
+		if pm.HostPort <= 0 {
+			continue
+		}
+		if _, err := ds.iptables.EnsureRule(utiliptables.Append, utiliptables.TableNAT, chain, hostportRuleArgs(pm, podIP)...); err != nil {
+			return err
+		}
+	}
+	if _, err := ds.iptables.EnsureRule(utiliptables.Append, utiliptables.TableNAT, hostportChain, "-j", string(chain)); err != nil {
+		return err
+	}
+	// Hostports must work for both external traffic and traffic originating
+	// on the node itself.
+	for _, builtin := range []utiliptables.Chain{utiliptables.ChainPrerouting, utiliptables.ChainOutput} {
+		if _, err := ds.iptables.EnsureRule(utiliptables.Prepend, utiliptables.TableNAT, builtin,
+			"-m", "addrtype", "--dst-type", "LOCAL", "-j", string(hostportChain)); err != nil {
+			return err
+		}
+	}
+	glog.V(2).Infof("Set up hostport rules for sandbox %q via %q", podSandboxID, chain)
+	return nil
+}
+
+// tearDownSandboxHostPorts removes the hostport rules of the sandbox. Errors
+// for rules or chains that no longer exist are ignored.
+func (ds *dockerService) tearDownSandboxHostPorts(podSandboxID string) error {
+	chain := hostportChainName(podSandboxID)
+	errList := []error{}
+	if err := ds.iptables.DeleteRule(utiliptables.TableNAT, hostportChain, "-j", string(chain)); err != nil {
+		errList = append(errList, err)
+	}
+	if err := ds.iptables.FlushChain(utiliptables.TableNAT, chain); err == nil {
+		if err := ds.iptables.DeleteChain(utiliptables.TableNAT, chain); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return utilerrors.NewAggregate(errList)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+func TestHostportChainName(t *testing.T) {
+	chain := hostportChainName("0123456789abcdef0123456789abcdef")
+	// iptables chain names are limited to 28 characters.
+	assert.True(t, len(chain) <= 28, "chain name %q is too long", chain)
+	assert.Equal(t, chain, hostportChainName("0123456789abcdef0123456789abcdef"))
+	assert.NotEqual(t, chain, hostportChainName("anothersandboxid"))
+}
+
+func TestHostportRuleArgs(t *testing.T) {
+	tests := []struct {
+		mapping      *runtimeapi.PortMapping
+		expectedArgs []string
+	}{{
+		mapping: &runtimeapi.PortMapping{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 8080},
+		expectedArgs: []string{
+			"-m", "comment", "--comment", "dockershim hostport",
+			"-p", "tcp", "--dport", "8080", "-j", "DNAT", "--to-destination", "10.1.2.3:80",
+		},
+	}, {
+		mapping: &runtimeapi.PortMapping{Protocol: runtimeapi.Protocol_UDP, ContainerPort: 53, HostPort: 53, HostIp: "192.168.0.2"},
+		expectedArgs: []string{
+			"-m", "comment", "--comment", "dockershim hostport",
+			"-d", "192.168.0.2",
+			"-p", "udp", "--dport", "53", "-j", "DNAT", "--to-destination", "10.1.2.3:53",
+		},
+	}}
+
+	for i, test := range tests {
+		assert.Equal(t, test.expectedArgs, hostportRuleArgs(test.mapping, "10.1.2.3"), "TestCase[%d]", i)
+	}
+}
+
+func TestCheckSandboxHostPortConflicts(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	config := makeSandboxConfig("foo", "default", "1", 0)
+	config.PortMappings = []*runtimeapi.PortMapping{
+		{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 8080},
+	}
+	_, err := ds.RunPodSandbox(config)
+	assert.NoError(t, err)
+
+	// The same host port and protocol conflicts, and the error names the
+	// owning pod.
+	conflicting := makeSandboxConfig("bar", "default", "2", 0)
+	conflicting.PortMappings = []*runtimeapi.PortMapping{
+		{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 8080},
+	}
+	_, err = ds.RunPodSandbox(conflicting)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "8080/tcp")
+	assert.Contains(t, err.Error(), "default/foo")
+
+	// A different port or a different protocol does not conflict.
+	for i, mappings := range [][]*runtimeapi.PortMapping{
+		{{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 8081}},
+		{{Protocol: runtimeapi.Protocol_UDP, ContainerPort: 80, HostPort: 8080}},
+	} {
+		ok := makeSandboxConfig("baz", "default", string(rune('3'+i)), 0)
+		ok.PortMappings = mappings
+		_, err = ds.RunPodSandbox(ok)
+		assert.NoError(t, err, "TestCase[%d]", i)
+	}
+
+	// Ports bound to different explicit host IPs do not conflict, but a
+	// wildcard bind conflicts with any of them.
+	bound := makeSandboxConfig("qux", "default", "5", 0)
+	bound.PortMappings = []*runtimeapi.PortMapping{
+		{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 9090, HostIp: "10.0.0.1"},
+	}
+	_, err = ds.RunPodSandbox(bound)
+	assert.NoError(t, err)
+
+	otherIP := makeSandboxConfig("quux", "default", "6", 0)
+	otherIP.PortMappings = []*runtimeapi.PortMapping{
+		{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 9090, HostIp: "10.0.0.2"},
+	}
+	_, err = ds.RunPodSandbox(otherIP)
+	assert.NoError(t, err)
+
+	wildcard := makeSandboxConfig("corge", "default", "7", 0)
+	wildcard.PortMappings = []*runtimeapi.PortMapping{
+		{Protocol: runtimeapi.Protocol_TCP, ContainerPort: 80, HostPort: 9090},
+	}
+	_, err = ds.RunPodSandbox(wildcard)
+	assert.Error(t, err)
+}
+
+func TestHasHostPorts(t *testing.T) {
+	assert.False(t, hasHostPorts(nil))
+	assert.False(t, hasHostPorts([]*runtimeapi.PortMapping{{ContainerPort: 80}}))
+	assert.True(t, hasHostPorts([]*runtimeapi.PortMapping{{ContainerPort: 80}, {ContainerPort: 443, HostPort: 8443}}))
+}
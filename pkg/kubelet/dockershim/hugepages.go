@@ -0,0 +1,143 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// This file translates pod hugepages requests into hugetlb cgroup limits.
+// Neither the CRI version nor the docker API version in use carry hugetlb
+// fields, so the limits travel as pod annotations and the shim writes them
+// into the container's hugetlb cgroup right after the container starts. The
+// hugetlbfs volume itself reaches the container as a regular bind mount,
+// prepared by the kubelet like any other emptyDir medium.
+
+const (
+	// hugepageLimitAnnotationPrefix prefixes the per-size hugepage limit
+	// annotations, e.g. "hugepages.alpha.kubernetes.io/2Mi": "1Gi".
+	hugepageLimitAnnotationPrefix = "hugepages.alpha.kubernetes.io/"
+	// hugetlbCgroupRoot is the mount point of the hugetlb cgroup hierarchy.
+	hugetlbCgroupRoot = "/sys/fs/cgroup/hugetlb"
+	// hugepageSysDir is where the kernel exposes the supported page sizes.
+	hugepageSysDir = "/sys/kernel/mm/hugepages"
+)
+
+// hugepageSize describes one supported page size.
+type hugepageSize struct {
+	// label is the size as used in hugetlb cgroup file names.
+	label string
+	// sysDir is the kernel's per-size directory under hugepageSysDir; its
+	// presence means the kernel supports the size.
+	sysDir string
+}
+
+// hugepageSizes maps the annotation form of a page size to its kernel
+// representations.
+var hugepageSizes = map[string]hugepageSize{
+	"2Mi": {label: "2MB", sysDir: "hugepages-2048kB"},
+	"1Gi": {label: "1GB", sysDir: "hugepages-1048576kB"},
+}
+
+// hugepageLimitsFromAnnotations extracts the per-size hugetlb limits, in
+// bytes, from the pod annotations. Unknown sizes and malformed quantities
+// are rejected.
+func hugepageLimitsFromAnnotations(annotations map[string]string) (map[string]int64, error) {
+	var limits map[string]int64
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, hugepageLimitAnnotationPrefix) {
+			continue
+		}
+		size := strings.TrimPrefix(key, hugepageLimitAnnotationPrefix)
+		if _, ok := hugepageSizes[size]; !ok {
+			return nil, fmt.Errorf("unsupported hugepage size %q", size)
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hugepage limit %q for size %q: %v", value, size, err)
+		}
+		if limits == nil {
+			limits = map[string]int64{}
+		}
+		limits[size] = quantity.Value()
+	}
+	return limits, nil
+}
+
+// hugepageSizeSupported checks whether the kernel was built with support for
+// the page size.
+func hugepageSizeSupported(size hugepageSize) bool {
+	_, err := os.Stat(filepath.Join(hugepageSysDir, size.sysDir))
+	return err == nil
+}
+
+// containerHugetlbCgroupPath returns the container's directory in the
+// hugetlb cgroup hierarchy. The layout depends on the cgroup driver docker
+// runs with.
+func (ds *dockerService) containerHugetlbCgroupPath(cgroupParent, containerID string) string {
+	if ds.cgroupDriver == "systemd" {
+		return filepath.Join(hugetlbCgroupRoot, cgroupParent, fmt.Sprintf("docker-%s.scope", containerID))
+	}
+	return filepath.Join(hugetlbCgroupRoot, cgroupParent, containerID)
+}
+
+// applyHugetlbLimits writes the hugetlb limits into the container's cgroup.
+// Page sizes the kernel does not support are rejected rather than silently
+// left unlimited.
+func (ds *dockerService) applyHugetlbLimits(containerID, cgroupParent string, limits map[string]int64) error {
+	cgroupPath := ds.containerHugetlbCgroupPath(cgroupParent, containerID)
+	for size, limit := range limits {
+		info := hugepageSizes[size]
+		if !hugepageSizeSupported(info) {
+			return fmt.Errorf("hugepage size %q is not supported by the kernel", size)
+		}
+		file := filepath.Join(cgroupPath, fmt.Sprintf("hugetlb.%s.limit_in_bytes", info.label))
+		if err := ioutil.WriteFile(file, []byte(strconv.FormatInt(limit, 10)), 0644); err != nil {
+			return fmt.Errorf("failed to set hugetlb limit for size %q: %v", size, err)
+		}
+		glog.V(4).Infof("Set hugetlb limit of %d bytes for size %s on container %q", limit, size, containerID)
+	}
+	return nil
+}
+
+// setUpContainerHugetlbLimits reads the hugepage limits from the pod
+// annotations recorded on the container and applies them to its cgroup. It
+// must run after the container started, once the cgroup exists.
+func (ds *dockerService) setUpContainerHugetlbLimits(containerID string) error {
+	r, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return err
+	}
+	_, annotations := extractLabels(r.Config.Labels)
+	limits, err := hugepageLimitsFromAnnotations(annotations)
+	if err != nil {
+		return err
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+	return ds.applyHugetlbLimits(containerID, r.HostConfig.CgroupParent, limits)
+}
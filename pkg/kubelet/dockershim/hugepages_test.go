@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHugepageLimitsFromAnnotations(t *testing.T) {
+	// Unrelated annotations yield no limits.
+	limits, err := hugepageLimitsFromAnnotations(map[string]string{"foo": "bar"})
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+
+	limits, err = hugepageLimitsFromAnnotations(map[string]string{
+		hugepageLimitAnnotationPrefix + "2Mi": "1Gi",
+		hugepageLimitAnnotationPrefix + "1Gi": "2Gi",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"2Mi": 1073741824, "1Gi": 2147483648}, limits)
+
+	// Unknown page sizes and malformed quantities are rejected.
+	_, err = hugepageLimitsFromAnnotations(map[string]string{hugepageLimitAnnotationPrefix + "4Ki": "1Gi"})
+	assert.Error(t, err)
+	_, err = hugepageLimitsFromAnnotations(map[string]string{hugepageLimitAnnotationPrefix + "2Mi": "many"})
+	assert.Error(t, err)
+}
+
+func TestContainerHugetlbCgroupPath(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	ds.cgroupDriver = "cgroupfs"
+	assert.Equal(t, hugetlbCgroupRoot+"/kubepods/pod1/abcd", ds.containerHugetlbCgroupPath("/kubepods/pod1", "abcd"))
+
+	ds.cgroupDriver = "systemd"
+	assert.Equal(t, hugetlbCgroupRoot+"/kubepods.slice/docker-abcd.scope", ds.containerHugetlbCgroupPath("kubepods.slice", "abcd"))
+}
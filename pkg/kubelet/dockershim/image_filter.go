@@ -0,0 +1,96 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"strings"
+
+	dockertypes "github.com/docker/engine-api/types"
+	dockerfilters "github.com/docker/engine-api/types/filters"
+)
+
+// The CRI image filter carries only an image reference string. To let
+// callers narrow ListImages without pulling the full image list and
+// filtering client-side, the shim also accepts comma-separated
+// "<key>=<value>" terms in that string:
+//
+//	reference=busybox:*         repo name or pattern, matched by the daemon
+//	dangling=true               untagged images
+//	label=<key> or label=<k>=<v>  image label, matched by the daemon
+//	digest=sha256:...           repo digest, matched by the shim
+//
+// A plain string without "=" keeps its CRI meaning, a repo name or pattern.
+// The docker API version in use cannot filter by digest server-side, so the
+// digest term is returned separately and matched against the narrowed list.
+const (
+	imageFilterReference = "reference"
+	imageFilterDangling  = "dangling"
+	imageFilterLabel     = "label"
+	imageFilterDigest    = "digest"
+)
+
+// parseImageFilter translates the CRI image filter string into docker list
+// options plus the digest the caller must match client-side, if any.
+func parseImageFilter(image string) (dockertypes.ImageListOptions, string, error) {
+	opts := dockertypes.ImageListOptions{}
+	if !strings.Contains(image, "=") {
+		opts.MatchName = image
+		return opts, "", nil
+	}
+	opts.Filters = dockerfilters.NewArgs()
+	digest := ""
+	for _, term := range strings.Split(image, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		parts := strings.SplitN(term, "=", 2)
+		if len(parts) != 2 {
+			return opts, "", fmt.Errorf("image filter term %q must be \"<key>=<value>\"", term)
+		}
+		key, value := parts[0], parts[1]
+		switch key {
+		case imageFilterReference:
+			opts.MatchName = value
+		case imageFilterDangling:
+			if value != "true" && value != "false" {
+				return opts, "", fmt.Errorf("image filter %q must be true or false", key)
+			}
+			opts.Filters.Add(imageFilterDangling, value)
+		case imageFilterLabel:
+			opts.Filters.Add(imageFilterLabel, value)
+		case imageFilterDigest:
+			digest = value
+		default:
+			return opts, "", fmt.Errorf("unknown image filter key %q", key)
+		}
+	}
+	return opts, digest, nil
+}
+
+// imageMatchesDigest returns whether one of the image's repo digests is the
+// given digest, either in full ("busybox@sha256:...") or by the bare digest
+// after the "@".
+func imageMatchesDigest(image *dockertypes.Image, digest string) bool {
+	for _, repoDigest := range image.RepoDigests {
+		if repoDigest == digest || strings.HasSuffix(repoDigest, "@"+digest) {
+			return true
+		}
+	}
+	return false
+}
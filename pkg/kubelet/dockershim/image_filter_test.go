@@ -0,0 +1,85 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestParseImageFilter tests the rich filter term parsing.
+func TestParseImageFilter(t *testing.T) {
+	// A plain string keeps its CRI meaning, a repo name or pattern.
+	opts, digest, err := parseImageFilter("busybox:latest")
+	require.NoError(t, err)
+	assert.Equal(t, "busybox:latest", opts.MatchName)
+	assert.Empty(t, digest)
+
+	opts, digest, err = parseImageFilter("reference=busybox:*, dangling=true, label=maintainer=core")
+	require.NoError(t, err)
+	assert.Equal(t, "busybox:*", opts.MatchName)
+	assert.Equal(t, []string{"true"}, opts.Filters.Get(imageFilterDangling))
+	assert.Equal(t, []string{"maintainer=core"}, opts.Filters.Get(imageFilterLabel))
+	assert.Empty(t, digest)
+
+	// The digest term is matched by the shim, not the daemon.
+	opts, digest, err = parseImageFilter("digest=sha256:beef")
+	require.NoError(t, err)
+	assert.Empty(t, opts.MatchName)
+	assert.Equal(t, "sha256:beef", digest)
+
+	for _, filter := range []string{"dangling=maybe", "before=busybox"} {
+		_, _, err = parseImageFilter(filter)
+		assert.Error(t, err, "filter %q", filter)
+	}
+}
+
+// TestListImagesByDigest tests the client-side digest match on top of the
+// daemon-side filters.
+func TestListImagesByDigest(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	fakeDocker.InjectImages([]dockertypes.Image{
+		{ID: "img1", RepoTags: []string{"busybox:latest"}, RepoDigests: []string{"busybox@sha256:beef"}},
+		{ID: "img2", RepoTags: []string{"mysql:5.7"}, RepoDigests: []string{"mysql@sha256:cafe"}},
+	})
+
+	images, err := ds.ListImages(&runtimeapi.ImageFilter{
+		Image: &runtimeapi.ImageSpec{Image: "digest=sha256:beef"},
+	})
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, "img1", images[0].Id)
+
+	// The full repo digest matches too.
+	images, err = ds.ListImages(&runtimeapi.ImageFilter{
+		Image: &runtimeapi.ImageSpec{Image: "digest=mysql@sha256:cafe"},
+	})
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, "img2", images[0].Id)
+
+	// Malformed filters are rejected instead of matching everything.
+	_, err = ds.ListImages(&runtimeapi.ImageFilter{
+		Image: &runtimeapi.ImageSpec{Image: "dangling=perhaps"},
+	})
+	assert.Error(t, err)
+}
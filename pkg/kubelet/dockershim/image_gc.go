@@ -0,0 +1,181 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/clock"
+)
+
+// This file moves part of the image garbage collection decision into the
+// shim. The shim sees every container creation, so it can track when each
+// image was last used; the kubelet only sees containers come and go. The
+// candidates returned here honor a pinned-image list and a minimum image
+// age, and are ordered least recently used first, so the caller removes the
+// least valuable images first when reclaiming space.
+
+// imageUsageTracker records when images were last used by a container
+// creation. Images never used since the shim started age from when the
+// tracker first saw them, so a freshly pulled image is not collected before
+// the minimum age passes.
+type imageUsageTracker struct {
+	lock     sync.Mutex
+	clock    clock.Clock
+	lastUsed map[string]time.Time
+}
+
+func newImageUsageTracker(c clock.Clock) *imageUsageTracker {
+	return &imageUsageTracker{
+		clock:    c,
+		lastUsed: make(map[string]time.Time),
+	}
+}
+
+// recordUse marks the image ref as used now. Nil-safe because most tests do
+// not set up the tracker.
+func (t *imageUsageTracker) recordUse(ref string) {
+	if t == nil || ref == "" {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.lastUsed[ref] = t.clock.Now()
+}
+
+// lastUsedOrObserve returns the most recent use among the given refs. Refs
+// the tracker has never seen are recorded as first observed now, so their
+// age is measured from this call.
+func (t *imageUsageTracker) lastUsedOrObserve(refs []string) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var last time.Time
+	found := false
+	for _, ref := range refs {
+		if ts, ok := t.lastUsed[ref]; ok && ts.After(last) {
+			last = ts
+			found = true
+		}
+	}
+	if !found && len(refs) > 0 {
+		last = t.clock.Now()
+		t.lastUsed[refs[0]] = last
+	}
+	return last
+}
+
+// prune drops tracker entries for refs that no longer name an image, so the
+// map does not grow with every image that ever existed on the node.
+func (t *imageUsageTracker) prune(known sets.String) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for ref := range t.lastUsed {
+		if !known.Has(ref) {
+			delete(t.lastUsed, ref)
+		}
+	}
+}
+
+// imageRefs returns every name the image answers to: its ID, tags, and repo
+// digests.
+func imageRefs(image *dockertypes.Image) []string {
+	refs := []string{image.ID}
+	refs = append(refs, image.RepoTags...)
+	refs = append(refs, image.RepoDigests...)
+	return refs
+}
+
+// imageGCCandidate pairs an image ID with the time it was last used, for
+// LRU ordering.
+type imageGCCandidate struct {
+	id       string
+	lastUsed time.Time
+}
+
+// candidatesByLastUsed sorts candidates least recently used first, with the
+// image ID as a deterministic tie breaker.
+type candidatesByLastUsed []imageGCCandidate
+
+func (c candidatesByLastUsed) Len() int      { return len(c) }
+func (c candidatesByLastUsed) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c candidatesByLastUsed) Less(i, j int) bool {
+	if !c[i].lastUsed.Equal(c[j].lastUsed) {
+		return c[i].lastUsed.Before(c[j].lastUsed)
+	}
+	return c[i].id < c[j].id
+}
+
+// ImageGCCandidates returns the IDs of images eligible for removal: not on
+// the pinned list, not used by an existing container, and last used at
+// least minAge ago. Candidates are ordered least recently used first.
+func (ds *dockerService) ImageGCCandidates(pinned []string, minAge time.Duration) ([]string, error) {
+	images, err := ds.client.ListImages(dockertypes.ImageListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %v", err)
+	}
+	containers, err := ds.client.ListContainers(dockertypes.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+	// Refs of images an existing container was created from, by name or by
+	// the digest recorded in its labels.
+	inUse := sets.NewString()
+	for _, c := range containers {
+		inUse.Insert(c.Image)
+		if digest := c.Labels[imageDigestLabelKey]; digest != "" {
+			inUse.Insert(digest)
+		}
+	}
+	pinnedSet := sets.NewString(pinned...)
+
+	known := sets.NewString()
+	candidates := candidatesByLastUsed{}
+	for i := range images {
+		refs := imageRefs(&images[i])
+		known.Insert(refs...)
+		lastUsed := ds.imageUsage.lastUsedOrObserve(refs)
+		if pinnedSet.HasAny(refs...) || inUse.HasAny(refs...) {
+			continue
+		}
+		// Without a tracker (tests) the zero last-used time makes every
+		// unpinned, unused image old enough.
+		if ds.imageUsage != nil && ds.imageUsage.clock.Since(lastUsed) < minAge {
+			continue
+		}
+		candidates = append(candidates, imageGCCandidate{id: images[i].ID, lastUsed: lastUsed})
+	}
+	ds.imageUsage.prune(known)
+
+	sort.Sort(candidates)
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.id)
+	}
+	return ids, nil
+}
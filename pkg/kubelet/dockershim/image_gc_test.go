@@ -0,0 +1,107 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/clock"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+// TestImageUsageTracker tests the last-used bookkeeping.
+func TestImageUsageTracker(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	tracker := newImageUsageTracker(fakeClock)
+
+	// A ref never seen before is recorded as observed now.
+	first := tracker.lastUsedOrObserve([]string{"img1", "busybox:latest"})
+	assert.Equal(t, fakeClock.Now(), first)
+
+	fakeClock.Step(time.Hour)
+	// The recorded observation is remembered, not refreshed.
+	assert.Equal(t, first, tracker.lastUsedOrObserve([]string{"img1", "busybox:latest"}))
+
+	// A use on any of the image's refs counts.
+	tracker.recordUse("busybox:latest")
+	assert.Equal(t, fakeClock.Now(), tracker.lastUsedOrObserve([]string{"img1", "busybox:latest"}))
+
+	// Pruning drops refs that no longer name an image; the surviving entry
+	// keeps its timestamp.
+	tracker.prune(sets.NewString("img1"))
+	assert.Equal(t, first, tracker.lastUsedOrObserve([]string{"img1"}))
+	fakeClock.Step(time.Hour)
+	assert.Equal(t, fakeClock.Now(), tracker.lastUsedOrObserve([]string{"busybox:latest"}))
+
+	// The nil tracker is inert.
+	var nilTracker *imageUsageTracker
+	nilTracker.recordUse("img1")
+	assert.True(t, nilTracker.lastUsedOrObserve([]string{"img1"}).IsZero())
+}
+
+// TestImageGCCandidates tests candidate selection and LRU ordering.
+func TestImageGCCandidates(t *testing.T) {
+	ds, fDocker, fakeClock := newTestDockerService()
+	fakeClock.SetTime(time.Now())
+	ds.imageUsage = newImageUsageTracker(fakeClock)
+
+	fDocker.InjectImages([]dockertypes.Image{
+		{ID: "img1", RepoTags: []string{"busybox:latest"}},
+		{ID: "img2", RepoTags: []string{"pause:3.0"}},
+		{ID: "img3", RepoTags: []string{"mysql:5.7"}, RepoDigests: []string{"mysql@sha256:d1ges7"}},
+	})
+	// An existing container pins img3 by its recorded digest.
+	fDocker.SetFakeRunningContainers([]*dockertools.FakeContainer{{
+		ID:     "c1",
+		Name:   "/k8s_c1",
+		Config: &dockercontainer.Config{Labels: map[string]string{imageDigestLabelKey: "mysql@sha256:d1ges7"}},
+	}})
+
+	// All images were just observed, so nothing is older than the minimum
+	// age yet.
+	candidates, err := ds.ImageGCCandidates([]string{"pause:3.0"}, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	// Once the minimum age passes, only the unpinned, unused image is
+	// eligible.
+	fakeClock.Step(time.Hour)
+	candidates, err = ds.ImageGCCandidates([]string{"pause:3.0"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"img1"}, candidates)
+
+	// A recent use disqualifies the image again.
+	ds.imageUsage.recordUse("busybox:latest")
+	candidates, err = ds.ImageGCCandidates([]string{"pause:3.0"}, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	// Without the pin and the container, the candidates come back least
+	// recently used first.
+	fakeClock.Step(time.Hour)
+	fDocker.SetFakeRunningContainers(nil)
+	candidates, err = ds.ImageGCCandidates(nil, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"img2", "img3", "img1"}, candidates)
+}
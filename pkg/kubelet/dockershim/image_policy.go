@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+)
+
+// This file hooks an image policy into container creation. Before the shim
+// creates a container, the policy gets to verify the image it would run
+// from, e.g. by checking a signature or consulting an external admission
+// endpoint. A rejection fails the create with a typed error, so the kubelet
+// reports the policy's reason instead of retrying blindly.
+
+// imagePolicyCallTimeout bounds each call to a policy endpoint so a hung
+// endpoint cannot block container creation forever.
+const imagePolicyCallTimeout = 10 * time.Second
+
+// imagePolicy verifies the image a container is about to be created from.
+type imagePolicy interface {
+	// verifyImage returns nil when the image may run. ref is the image the
+	// pod requested and digests are the repo digests it resolves to on this
+	// node.
+	verifyImage(ref string, digests []string) error
+}
+
+// verifyContainerImage runs the configured image policy against the image.
+// Without a policy every image is allowed.
+func (ds *dockerService) verifyContainerImage(image string) error {
+	if ds.imagePolicy == nil {
+		return nil
+	}
+	// The digests identify the exact content the container would run, which
+	// is what signature checks care about; an image without digests (e.g.
+	// docker load) is presented with only its name.
+	var digests []string
+	if inspect, err := ds.client.InspectImageByRef(image); err == nil && inspect != nil {
+		digests = inspect.RepoDigests
+	}
+	if err := ds.imagePolicy.verifyImage(image, digests); err != nil {
+		return errors.NewImageRejected(fmt.Errorf("image %q rejected by policy: %v", image, err))
+	}
+	return nil
+}
+
+// webhookImagePolicy consults an external policy endpoint over HTTP. The
+// endpoint receives the image name and digests and answers whether the image
+// may run. Verification fails closed: an unreachable endpoint rejects the
+// image, because the policy exists precisely for the images it would have
+// denied.
+type webhookImagePolicy struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newWebhookImagePolicy(endpoint string) *webhookImagePolicy {
+	return &webhookImagePolicy{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: imagePolicyCallTimeout},
+	}
+}
+
+// imagePolicyRequest is the JSON document posted to the policy endpoint.
+type imagePolicyRequest struct {
+	Image   string   `json:"image"`
+	Digests []string `json:"digests,omitempty"`
+}
+
+// imagePolicyResponse is the endpoint's answer.
+type imagePolicyResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func (p *webhookImagePolicy) verifyImage(ref string, digests []string) error {
+	body, err := json.Marshal(&imagePolicyRequest{Image: ref, Digests: digests})
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Post(p.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("policy endpoint %q is unreachable: %v", p.endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("policy endpoint %q answered with status %d", p.endpoint, resp.StatusCode)
+	}
+	var answer imagePolicyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return fmt.Errorf("failed to decode policy endpoint answer: %v", err)
+	}
+	if !answer.Allowed {
+		if answer.Reason != "" {
+			return fmt.Errorf("%s", answer.Reason)
+		}
+		return fmt.Errorf("denied by the policy endpoint")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+)
+
+// denyListPolicy is a test policy that rejects the listed images.
+type denyListPolicy struct {
+	denied map[string]string
+}
+
+func (p *denyListPolicy) verifyImage(ref string, digests []string) error {
+	if reason, ok := p.denied[ref]; ok {
+		return fmt.Errorf("%s", reason)
+	}
+	return nil
+}
+
+// TestWebhookImagePolicy tests the external policy endpoint client.
+func TestWebhookImagePolicy(t *testing.T) {
+	var lastRequest imagePolicyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&lastRequest))
+		answer := imagePolicyResponse{Allowed: true}
+		if lastRequest.Image == "evil:latest" {
+			answer = imagePolicyResponse{Allowed: false, Reason: "unsigned image"}
+		}
+		json.NewEncoder(w).Encode(&answer)
+	}))
+	defer server.Close()
+	policy := newWebhookImagePolicy(server.URL)
+
+	err := policy.verifyImage("busybox:latest", []string{"busybox@sha256:beef"})
+	assert.NoError(t, err)
+	assert.Equal(t, "busybox:latest", lastRequest.Image)
+	assert.Equal(t, []string{"busybox@sha256:beef"}, lastRequest.Digests)
+
+	err = policy.verifyImage("evil:latest", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsigned image")
+
+	// An unreachable endpoint fails closed.
+	server.Close()
+	err = policy.verifyImage("busybox:latest", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unreachable")
+}
+
+// TestCreateContainerImagePolicy tests that a policy rejection fails the
+// create with a typed error.
+func TestCreateContainerImagePolicy(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.imagePolicy = &denyListPolicy{denied: map[string]string{"evil:latest": "unsigned image"}}
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "evil:latest", 0, nil, nil)
+	_, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.True(t, errors.IsImageRejected(err))
+	assert.Contains(t, err.Error(), "unsigned image")
+
+	// Allowed images proceed to the daemon.
+	config = makeContainerConfig(sConfig, "c2", "good:latest", 0, nil, nil)
+	_, err = ds.CreateContainer("sandboxid", config, sConfig)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"sync"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+
+	"k8s.io/client-go/util/clock"
+)
+
+// The kubelet's PLEG asks for the status of every sandbox on each relist.
+// On nodes with hundreds of containers that turns into hundreds of docker
+// inspect calls per relist period, most of which return the same answer as
+// the last one. The inspect cache keeps the container JSON keyed by ID and
+// invalidates entries when the docker event stream reports a change, with a
+// TTL as a backstop for events the stream missed.
+
+// inspectCacheTTL bounds how stale a cached inspect result can get when the
+// invalidating docker event was missed.
+const inspectCacheTTL = 30 * time.Second
+
+type inspectCacheEntry struct {
+	container *dockertypes.ContainerJSON
+	expires   time.Time
+}
+
+// inspectCache caches docker inspect results keyed by container ID. All
+// methods are no-ops on a nil cache, which is how most tests run.
+type inspectCache struct {
+	lock    sync.RWMutex
+	ttl     time.Duration
+	clock   clock.Clock
+	entries map[string]inspectCacheEntry
+}
+
+func newInspectCache(ttl time.Duration, c clock.Clock) *inspectCache {
+	return &inspectCache{
+		ttl:     ttl,
+		clock:   c,
+		entries: make(map[string]inspectCacheEntry),
+	}
+}
+
+// get returns the cached inspect result for the container, or false when
+// there is none or it expired.
+func (c *inspectCache) get(id string) (*dockertypes.ContainerJSON, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	entry, ok := c.entries[id]
+	if !ok || c.clock.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.container, true
+}
+
+func (c *inspectCache) put(id string, container *dockertypes.ContainerJSON) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[id] = inspectCacheEntry{container: container, expires: c.clock.Now().Add(c.ttl)}
+}
+
+func (c *inspectCache) invalidate(id string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, id)
+}
+
+func (c *inspectCache) invalidateAll() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = make(map[string]inspectCacheEntry)
+}
+
+// inspectContainerCached returns the container JSON from the inspect cache,
+// falling back to (and populating the cache from) a real inspect call. Only
+// read paths should use it; paths that act on the container state must see
+// the daemon's current answer.
+func (ds *dockerService) inspectContainerCached(id string) (*dockertypes.ContainerJSON, error) {
+	if container, ok := ds.inspectCache.get(id); ok {
+		return container, nil
+	}
+	container, err := ds.client.InspectContainer(id)
+	if err != nil {
+		return nil, err
+	}
+	ds.inspectCache.put(id, container)
+	return container, nil
+}
+
+// updateInspectCache applies a raw docker event to the inspect cache. Any
+// container event may change the inspect result, so the entry is dropped.
+func (ds *dockerService) updateInspectCache(ev *dockerEvent) {
+	if ev.Type != "" && ev.Type != "container" {
+		return
+	}
+	containerID := ev.Actor.ID
+	if containerID == "" {
+		containerID = ev.ID
+	}
+	if containerID == "" {
+		return
+	}
+	ds.inspectCache.invalidate(containerID)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/clock"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestInspectCacheTTL tests entry expiry and invalidation, and that a nil
+// cache behaves as a no-op.
+func TestInspectCacheTTL(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	c := newInspectCache(inspectCacheTTL, fakeClock)
+	container := &dockertypes.ContainerJSON{}
+
+	c.put("id1", container)
+	got, ok := c.get("id1")
+	require.True(t, ok)
+	assert.Equal(t, container, got)
+
+	fakeClock.Step(inspectCacheTTL + time.Second)
+	_, ok = c.get("id1")
+	assert.False(t, ok)
+
+	c.put("id1", container)
+	c.invalidate("id1")
+	_, ok = c.get("id1")
+	assert.False(t, ok)
+
+	c.put("id1", container)
+	c.invalidateAll()
+	_, ok = c.get("id1")
+	assert.False(t, ok)
+
+	var nilCache *inspectCache
+	nilCache.put("id1", container)
+	_, ok = nilCache.get("id1")
+	assert.False(t, ok)
+	nilCache.invalidate("id1")
+	nilCache.invalidateAll()
+}
+
+// TestPodSandboxStatusCached tests that sandbox status is served from the
+// cache until a docker event invalidates the entry.
+func TestPodSandboxStatusCached(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	ds.inspectCache = newInspectCache(inspectCacheTTL, clock.NewFakeClock(time.Now()))
+
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "default", "1", 0))
+	require.NoError(t, err)
+	status, err := ds.PodSandboxStatus(id)
+	require.NoError(t, err)
+	assert.Equal(t, runtimeapi.PodSandboxState_SANDBOX_READY, status.State)
+
+	// Delete the container behind the shim's back; the cached result is
+	// still served. (The fake client hands out a shared struct, so the
+	// cached state itself is not asserted here.)
+	require.NoError(t, fDocker.StopContainer(id, 0))
+	require.NoError(t, fDocker.RemoveContainer(id, dockertypes.ContainerRemoveOptions{}))
+	_, err = ds.PodSandboxStatus(id)
+	require.NoError(t, err)
+
+	// The corresponding docker event drops the entry and the next status
+	// reflects reality.
+	ds.updateInspectCache(&dockerEvent{Status: "destroy", ID: id})
+	_, err = ds.PodSandboxStatus(id)
+	require.Error(t, err)
+}
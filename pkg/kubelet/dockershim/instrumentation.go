@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"time"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/metrics"
+)
+
+// Operation types the shim exports metrics for.
+const (
+	runPodSandboxOperation            = "run_podsandbox"
+	stopPodSandboxOperation           = "stop_podsandbox"
+	updatePodSandboxNetworkOperation  = "update_podsandbox_network"
+	pullImageOperation                = "pull_image"
+	createContainerOperation          = "create_container"
+	updateContainerResourcesOperation = "update_container_resources"
+	networkSetupOperation             = "network_setup"
+	networkTeardownOperation          = "network_teardown"
+)
+
+// recordOperation marks an operation as in flight and returns a function
+// recording its latency and outcome. It is meant to be deferred with a
+// pointer to the operation's named error return:
+//
+//	defer recordOperation(pullImageOperation)(&err)
+func recordOperation(operation string) func(err *error) {
+	start := time.Now()
+	metrics.OperationsInFlight.WithLabelValues(operation).Inc()
+	return func(err *error) {
+		metrics.OperationsInFlight.WithLabelValues(operation).Dec()
+		metrics.OperationsLatency.WithLabelValues(operation).Observe(metrics.SinceInSeconds(start))
+		if *err != nil {
+			metrics.OperationsErrors.WithLabelValues(operation).Inc()
+		}
+	}
+}
+
+// networkSetUpPod invokes the network plugin's SetUpPod, bounded by the
+// network call timeout, and records metrics for the call.
+func (ds *dockerService) networkSetUpPod(namespace, name string, id kubecontainer.ContainerID) (err error) {
+	defer recordOperation(networkSetupOperation)(&err)
+	err = ds.callPluginWithTimeout("network setup", func() error {
+		return ds.networkPlugin.SetUpPod(namespace, name, id)
+	})
+	ds.netMonitor.record(err == nil)
+	return err
+}
+
+// networkTearDownPod invokes the network plugin's TearDownPod, bounded by
+// the network call timeout, and records metrics for the call.
+func (ds *dockerService) networkTearDownPod(namespace, name string, id kubecontainer.ContainerID) (err error) {
+	defer recordOperation(networkTeardownOperation)(&err)
+	return ds.callPluginWithTimeout("network teardown", func() error {
+		return ds.networkPlugin.TearDownPod(namespace, name, id)
+	})
+}
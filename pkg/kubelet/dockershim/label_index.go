@@ -0,0 +1,147 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"sync"
+
+	dockertypes "github.com/docker/engine-api/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// labelIndex is an in-memory inverted index from label key=value pairs to
+// container IDs. Docker's label filter scans every container, which is slow
+// on nodes with thousands of exited containers; the index serves label
+// selectors of list calls in O(1) per label instead.
+//
+// The index is seeded from a full container list when the docker event
+// watcher connects and is maintained from create/destroy events afterwards.
+// While unpopulated (e.g. after the event stream breaks), list calls fall
+// back to docker's label filter.
+type labelIndex struct {
+	lock sync.RWMutex
+	// populated is true while the index reflects the full container set.
+	populated bool
+	// byLabel maps a "key=value" pair to the IDs of containers carrying it.
+	byLabel map[string]sets.String
+	// byID maps a container ID to its label pairs, for removal.
+	byID map[string][]string
+}
+
+func newLabelIndex() *labelIndex {
+	return &labelIndex{
+		byLabel: make(map[string]sets.String),
+		byID:    make(map[string][]string),
+	}
+}
+
+func labelPair(k, v string) string {
+	return k + "=" + v
+}
+
+// add indexes the container under all its labels. Docker event attributes
+// carry a few extra non-label entries (e.g. name, image); indexing them is
+// harmless since kubelet-managed labels are io.kubernetes.* prefixed.
+func (x *labelIndex) add(id string, labels map[string]string) {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	x.addLocked(id, labels)
+}
+
+func (x *labelIndex) addLocked(id string, labels map[string]string) {
+	x.removeLocked(id)
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pair := labelPair(k, v)
+		ids, ok := x.byLabel[pair]
+		if !ok {
+			ids = sets.NewString()
+			x.byLabel[pair] = ids
+		}
+		ids.Insert(id)
+		pairs = append(pairs, pair)
+	}
+	x.byID[id] = pairs
+}
+
+// remove drops the container from the index.
+func (x *labelIndex) remove(id string) {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	x.removeLocked(id)
+}
+
+func (x *labelIndex) removeLocked(id string) {
+	for _, pair := range x.byID[id] {
+		if ids, ok := x.byLabel[pair]; ok {
+			ids.Delete(id)
+			if ids.Len() == 0 {
+				delete(x.byLabel, pair)
+			}
+		}
+	}
+	delete(x.byID, id)
+}
+
+// replaceAll rebuilds the index from a full container list and marks it
+// populated.
+func (x *labelIndex) replaceAll(containers []dockertypes.Container) {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	x.byLabel = make(map[string]sets.String)
+	x.byID = make(map[string][]string)
+	for i := range containers {
+		x.addLocked(containers[i].ID, containers[i].Labels)
+	}
+	x.populated = true
+}
+
+// invalidate marks the index unpopulated, e.g. when the event stream breaks
+// and updates may have been missed.
+func (x *labelIndex) invalidate() {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	x.populated = false
+}
+
+// match returns the IDs of containers carrying all the selector labels and
+// whether the index was able to serve the query. Empty selectors are not
+// served since the index has nothing to narrow down.
+func (x *labelIndex) match(selector map[string]string) (sets.String, bool) {
+	x.lock.RLock()
+	defer x.lock.RUnlock()
+	if !x.populated || len(selector) == 0 {
+		return nil, false
+	}
+	var result sets.String
+	for k, v := range selector {
+		ids, ok := x.byLabel[labelPair(k, v)]
+		if !ok {
+			return sets.NewString(), true
+		}
+		if result == nil {
+			result = sets.NewString(ids.List()...)
+		} else {
+			result = result.Intersection(ids)
+		}
+		if result.Len() == 0 {
+			return result, true
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelIndexMatch(t *testing.T) {
+	x := newLabelIndex()
+
+	// An unpopulated index cannot serve queries.
+	_, ok := x.match(map[string]string{"a": "1"})
+	assert.False(t, ok)
+
+	x.replaceAll([]dockertypes.Container{
+		{ID: "c1", Labels: map[string]string{"a": "1", "b": "1"}},
+		{ID: "c2", Labels: map[string]string{"a": "1", "b": "2"}},
+		{ID: "c3", Labels: map[string]string{"a": "2"}},
+	})
+
+	// Empty selectors are not served; the index cannot narrow them down.
+	_, ok = x.match(nil)
+	assert.False(t, ok)
+
+	ids, ok := x.match(map[string]string{"a": "1"})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"c1", "c2"}, ids.List())
+
+	// Multiple labels intersect.
+	ids, ok = x.match(map[string]string{"a": "1", "b": "2"})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"c2"}, ids.List())
+
+	// Unknown labels yield an empty result, not a fallback.
+	ids, ok = x.match(map[string]string{"a": "3"})
+	assert.True(t, ok)
+	assert.Empty(t, ids.List())
+}
+
+func TestLabelIndexUpdates(t *testing.T) {
+	x := newLabelIndex()
+	x.replaceAll(nil)
+
+	x.add("c1", map[string]string{"a": "1"})
+	ids, ok := x.match(map[string]string{"a": "1"})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"c1"}, ids.List())
+
+	// Re-adding replaces the previous labels.
+	x.add("c1", map[string]string{"a": "2"})
+	ids, _ = x.match(map[string]string{"a": "1"})
+	assert.Empty(t, ids.List())
+	ids, _ = x.match(map[string]string{"a": "2"})
+	assert.Equal(t, []string{"c1"}, ids.List())
+
+	x.remove("c1")
+	ids, _ = x.match(map[string]string{"a": "2"})
+	assert.Empty(t, ids.List())
+
+	// Invalidation stops serving queries until the next replaceAll.
+	x.invalidate()
+	_, ok = x.match(map[string]string{"a": "2"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// Migration of legacy containers (created by kubernetes 1.4 and 1.5) to the
+// dockershim naming and label scheme. Docker container labels are immutable,
+// so the labels cannot be rewritten in place; instead, running legacy
+// containers are scheduled for replacement by stopping them, so the pod's
+// sync loop recreates them under the new scheme, and exited ones are left
+// for the kubelet's garbage collector. Progress is tracked persistently:
+// once a run has verified that no legacy containers remain, later kubelet
+// starts skip the legacy listing path entirely instead of re-checking on
+// every restart.
+
+const (
+	// legacyMigrationDir is the directory under the dockershim root where the
+	// migration progress record is persisted.
+	legacyMigrationDir = "legacy-migration"
+	// legacyMigrationStateKey is the store key of the progress record.
+	legacyMigrationStateKey = "state"
+)
+
+// legacyMigrationState is the persisted migration progress record.
+type legacyMigrationState struct {
+	// StartedAt is when legacy containers were first observed.
+	StartedAt time.Time `json:"startedAt"`
+	// Pending maps the IDs of the remaining legacy containers and sandboxes
+	// to the pod they belong to.
+	Pending map[string]string `json:"pending,omitempty"`
+	// Done is set once no legacy containers remain.
+	Done bool `json:"done"`
+}
+
+// legacyMigration tracks migration progress and persists it across kubelet
+// restarts. A nil *legacyMigration tracks nothing.
+type legacyMigration struct {
+	lock  sync.Mutex
+	store CheckpointStore
+	state legacyMigrationState
+}
+
+func newLegacyMigration() *legacyMigration {
+	return &legacyMigration{store: &FileStore{path: filepath.Join(dockershimRootDir, legacyMigrationDir)}}
+}
+
+// load reads the persisted progress record; a missing record leaves the
+// zero state.
+func (m *legacyMigration) load() {
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	data, err := m.store.Read(legacyMigrationStateKey)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &m.state); err != nil {
+		glog.Warningf("Failed to decode the legacy migration record, restarting migration tracking: %v", err)
+		m.state = legacyMigrationState{}
+	}
+}
+
+// persist writes the progress record; failures are logged, the migration
+// then simply re-lists on the next kubelet start.
+func (m *legacyMigration) persist() {
+	data, err := json.Marshal(&m.state)
+	if err == nil {
+		err = m.store.Write(legacyMigrationStateKey, data)
+	}
+	if err != nil {
+		glog.Warningf("Failed to persist the legacy migration record: %v", err)
+	}
+}
+
+// done checks whether a completed migration is recorded.
+func (m *legacyMigration) done() bool {
+	if m == nil {
+		return false
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.state.Done
+}
+
+// recordPending records the currently remaining legacy containers.
+func (m *legacyMigration) recordPending(pending map[string]string) {
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.state.StartedAt.IsZero() {
+		m.state.StartedAt = time.Now()
+	}
+	m.state.Pending = pending
+	m.persist()
+}
+
+// markDone records that no legacy containers remain.
+func (m *legacyMigration) markDone() {
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.state.Done {
+		return
+	}
+	m.state.Pending = nil
+	m.state.Done = true
+	m.persist()
+}
+
+// migrateLegacyContainers performs one migration round: it records the
+// remaining legacy containers, schedules running ones for replacement when
+// the LegacyContainerMigration feature gate is enabled, and retires the
+// legacy handling paths once nothing remains. It has the signature of a
+// wait.ConditionFunc so LegacyCleanupInit can poll it.
+func (ds *dockerService) migrateLegacyContainers() (bool, error) {
+	// Always retry when a list fails.
+	sandboxes, err := ds.ListLegacyPodSandbox(nil)
+	if err != nil {
+		glog.Errorf("Failed to list legacy pod sandboxes: %v", err)
+		return false, nil
+	}
+	containers, err := ds.ListLegacyContainers(nil)
+	if err != nil {
+		glog.Errorf("Failed to list legacy containers: %v", err)
+		return false, nil
+	}
+	if len(sandboxes) == 0 && len(containers) == 0 {
+		ds.legacyMigration.markDone()
+		ds.legacyCleanup.MarkDone()
+		glog.V(2).Infof("No legacy containers found, stop performing legacy cleanup.")
+		return true, nil
+	}
+
+	pending := map[string]string{}
+	for _, s := range sandboxes {
+		pending[s.Id] = fmt.Sprintf("%s/%s", s.GetMetadata().Namespace, s.GetMetadata().Name)
+	}
+	for _, c := range containers {
+		pending[c.Id] = c.GetMetadata().Name
+	}
+	glog.V(4).Infof("Found legacy sandboxes %+v, legacy containers %+v, continue legacy cleanup",
+		sandboxes, containers)
+	ds.legacyMigration.recordPending(pending)
+
+	// Labels cannot be rewritten in place, so replacement is the only road to
+	// the new scheme: stop running legacy workload containers and let their
+	// pods' sync loops recreate them with dockershim names and labels. The
+	// sandboxes and the stopped containers are then garbage collected the
+	// usual way.
+	if ds.featureGate.Enabled(legacyMigrationFeature) {
+		for _, c := range containers {
+			if c.State != runtimeapi.ContainerState_CONTAINER_RUNNING {
+				continue
+			}
+			if err := ds.client.StopContainer(c.Id, defaultContainerGracePeriod); err != nil && !isContainerNotFound(err) {
+				glog.Warningf("Failed to stop legacy container %q for replacement: %v", c.Id, err)
+			}
+		}
+	}
+	return false, nil
+}
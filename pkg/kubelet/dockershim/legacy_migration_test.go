@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dockershimtesting "k8s.io/kubernetes/pkg/kubelet/dockershim/testing"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+// TestMigrateLegacyContainers tests that migration rounds persist the set of
+// remaining legacy containers, replace running ones when the gate is on, and
+// record completion once nothing remains.
+func TestMigrateLegacyContainers(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	store := dockershimtesting.NewMemStore()
+	ds.legacyMigration = &legacyMigration{store: store}
+	require.NoError(t, ds.featureGate.set("LegacyContainerMigration=true"))
+
+	legacy := getFakeLegacyContainers()
+	legacy[1].Running = true
+	fDocker.SetFakeContainers(append(legacy, getFakeNewContainers()...))
+	// The fake's list entries carry no status string; mark the running one.
+	require.Len(t, fDocker.RunningContainerList, 1)
+	fDocker.RunningContainerList[0].Status = "Up 2 hours"
+
+	done, err := ds.migrateLegacyContainers()
+	assert.NoError(t, err)
+	assert.False(t, done)
+	// The running legacy container was stopped so the pod's sync loop
+	// recreates it under the new scheme; new containers were untouched.
+	assert.NoError(t, fDocker.AssertStopped([]string{legacy[1].ID}))
+	// The remaining legacy containers are recorded persistently.
+	var state legacyMigrationState
+	data, err := store.Read(legacyMigrationStateKey)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &state))
+	assert.False(t, state.Done)
+	assert.Len(t, state.Pending, 2)
+	assert.False(t, state.StartedAt.IsZero())
+
+	// Once no legacy container remains, completion is recorded and the
+	// cleanup flag retires the legacy paths.
+	fDocker.SetFakeContainers(getFakeNewContainers())
+	done, err = ds.migrateLegacyContainers()
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.True(t, ds.legacyCleanup.Done())
+	var final legacyMigrationState
+	data, err = store.Read(legacyMigrationStateKey)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &final))
+	assert.True(t, final.Done)
+	assert.Empty(t, final.Pending)
+}
+
+// TestLegacyCleanupInitSkipsWhenMigrated tests that a persisted completion
+// record short-circuits legacy handling without listing containers.
+func TestLegacyCleanupInitSkipsWhenMigrated(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	store := dockershimtesting.NewMemStore()
+	data, err := json.Marshal(&legacyMigrationState{Done: true})
+	require.NoError(t, err)
+	require.NoError(t, store.Write(legacyMigrationStateKey, data))
+	ds.legacyMigration = &legacyMigration{store: store}
+	ds.legacyCleanup.done = 0
+
+	ds.LegacyCleanupInit()
+	assert.True(t, ds.legacyCleanup.Done())
+	assert.NoError(t, fDocker.AssertCalls([]string{}))
+}
+
+// TestLegacyMigrationNil tests that a nil tracker keeps the passive legacy
+// cleanup behavior working.
+func TestLegacyMigrationNil(t *testing.T) {
+	var m *legacyMigration
+	m.load()
+	m.recordPending(map[string]string{"id": "pod"})
+	m.markDone()
+	assert.False(t, m.done())
+
+	ds, fDocker, _ := newTestDockerService()
+	fDocker.SetFakeContainers([]*dockertools.FakeContainer{})
+	done, err := ds.migrateLegacyContainers()
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Lifecycle hooks executed by the shim. The kubelet traditionally runs
+// postStart/preStop exec hooks itself, which leaves a window where the
+// container is running but the hook has not: a kubelet restart in that
+// window drops the hook on the floor. Running them in the shim ties the
+// hook to the container transition itself — postStart runs after the
+// container process starts and before StartContainer returns, preStop runs
+// before the stop signal is sent and within the stop grace period — and the
+// result is kept for reporting through ContainerStatus.
+
+const (
+	// postStartExecAnnotationKey carries the postStart exec hook command for
+	// a container as a JSON array of arguments.
+	postStartExecAnnotationKey = "lifecycle.alpha.kubernetes.io/post-start-exec"
+	// preStopExecAnnotationKey carries the preStop exec hook command for a
+	// container as a JSON array of arguments.
+	preStopExecAnnotationKey = "lifecycle.alpha.kubernetes.io/pre-stop-exec"
+
+	// postStartHookTimeout bounds how long a postStart hook may run; the
+	// container is considered failed when the hook exceeds it.
+	postStartHookTimeout = 30 * time.Second
+
+	// postStartHookName and preStopHookName identify the hook in results
+	// and status reasons.
+	postStartHookName = "PostStart"
+	preStopHookName   = "PreStop"
+)
+
+// hookCommand parses the hook command from the annotation, returning nil
+// when the annotation is absent.
+func hookCommand(annotations map[string]string, key string) ([]string, error) {
+	value, ok := annotations[key]
+	if !ok {
+		return nil, nil
+	}
+	var cmd []string
+	if err := json.Unmarshal([]byte(value), &cmd); err != nil {
+		return nil, fmt.Errorf("invalid value %q for annotation %q: %v", value, key, err)
+	}
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("empty command in annotation %q", key)
+	}
+	return cmd, nil
+}
+
+// lifecycleHookResult is the outcome of the last lifecycle hook run in a
+// container.
+type lifecycleHookResult struct {
+	// Hook is postStartHookName or preStopHookName.
+	Hook string
+	// Error is empty when the hook succeeded.
+	Error string
+}
+
+// lifecycleHookRunner executes lifecycle hook commands in containers and
+// remembers the last result per container for status reporting.
+type lifecycleHookRunner struct {
+	lock    sync.Mutex
+	results map[string]*lifecycleHookResult
+	// execSync runs a command in the container; split out so tests can
+	// substitute the docker exec path.
+	execSync func(containerID string, cmd []string, timeout time.Duration) ([]byte, []byte, error)
+}
+
+func newLifecycleHookRunner(execSync func(containerID string, cmd []string, timeout time.Duration) ([]byte, []byte, error)) *lifecycleHookRunner {
+	return &lifecycleHookRunner{
+		results:  make(map[string]*lifecycleHookResult),
+		execSync: execSync,
+	}
+}
+
+// run executes the hook command in the container with the given timeout and
+// records the result. A nil runner runs nothing.
+func (r *lifecycleHookRunner) run(containerID, hook string, cmd []string, timeout time.Duration) error {
+	if r == nil {
+		return nil
+	}
+	_, stderr, err := r.execSync(containerID, cmd, timeout)
+	result := &lifecycleHookResult{Hook: hook}
+	if err != nil {
+		result.Error = fmt.Sprintf("%s hook %v failed: %v", hook, cmd, err)
+		if output := strings.TrimSpace(string(stderr)); output != "" {
+			result.Error = fmt.Sprintf("%s, stderr: %s", result.Error, output)
+		}
+	}
+	r.lock.Lock()
+	r.results[containerID] = result
+	r.lock.Unlock()
+	if result.Error != "" {
+		return fmt.Errorf("%s", result.Error)
+	}
+	return nil
+}
+
+// result returns the last hook result for the container, or nil.
+func (r *lifecycleHookRunner) result(containerID string) *lifecycleHookResult {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.results[containerID]
+}
+
+// remove drops the recorded result when the container is removed.
+func (r *lifecycleHookRunner) remove(containerID string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.results, containerID)
+}
+
+// containerHookCommand inspects the container and returns the hook command
+// from its annotations, or nil when the container has none.
+func (ds *dockerService) containerHookCommand(containerID, key string) ([]string, error) {
+	r, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %q: %v", containerID, err)
+	}
+	_, annotations := extractLabels(r.Config.Labels)
+	return hookCommand(annotations, key)
+}
+
+// runPostStartHook runs the container's postStart hook, if any. It is
+// called by StartContainer after the container process starts; a non-nil
+// error means the hook failed and the container must not be reported as
+// successfully started.
+func (ds *dockerService) runPostStartHook(containerID string) error {
+	if ds.lifecycleHooks == nil {
+		return nil
+	}
+	cmd, err := ds.containerHookCommand(containerID, postStartExecAnnotationKey)
+	if err != nil {
+		return err
+	}
+	if cmd == nil {
+		return nil
+	}
+	return ds.lifecycleHooks.run(containerID, postStartHookName, cmd, postStartHookTimeout)
+}
+
+// runPreStopHook runs the container's preStop hook, if any, bounded by the
+// stop grace period. It is called by StopContainer before the stop signal
+// is sent; hook failures are recorded but never block the stop.
+func (ds *dockerService) runPreStopHook(containerID string, gracePeriod int64) {
+	if ds.lifecycleHooks == nil {
+		return
+	}
+	cmd, err := ds.containerHookCommand(containerID, preStopExecAnnotationKey)
+	if err != nil {
+		glog.Warningf("Skipping preStop hook for container %q: %v", containerID, err)
+		return
+	}
+	if cmd == nil {
+		return
+	}
+	if err := ds.lifecycleHooks.run(containerID, preStopHookName, cmd, time.Duration(gracePeriod)*time.Second); err != nil {
+		glog.Warningf("PreStop hook for container %q failed: %v", containerID, err)
+	}
+}
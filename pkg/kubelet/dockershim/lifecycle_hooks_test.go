@@ -0,0 +1,151 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHookExec records the hook commands run through the lifecycle hook
+// runner and fails them on demand.
+type fakeHookExec struct {
+	calls    [][]string
+	timeouts []time.Duration
+	err      error
+	stderr   string
+}
+
+func (f *fakeHookExec) exec(containerID string, cmd []string, timeout time.Duration) ([]byte, []byte, error) {
+	f.calls = append(f.calls, cmd)
+	f.timeouts = append(f.timeouts, timeout)
+	return nil, []byte(f.stderr), f.err
+}
+
+// TestHookCommand tests the hook annotation parsing.
+func TestHookCommand(t *testing.T) {
+	cmd, err := hookCommand(nil, postStartExecAnnotationKey)
+	require.NoError(t, err)
+	assert.Nil(t, cmd)
+
+	cmd, err = hookCommand(map[string]string{postStartExecAnnotationKey: `["/bin/sh","-c","warm-up"]`}, postStartExecAnnotationKey)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bin/sh", "-c", "warm-up"}, cmd)
+
+	for _, value := range []string{"not json", "[]", `"/bin/sh"`} {
+		_, err = hookCommand(map[string]string{postStartExecAnnotationKey: value}, postStartExecAnnotationKey)
+		assert.Error(t, err, "value %q", value)
+	}
+}
+
+// TestPostStartHook tests that StartContainer runs the postStart hook and
+// stops the container when the hook fails.
+func TestPostStartHook(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	fakeExec := &fakeHookExec{}
+	ds.lifecycleHooks = newLifecycleHookRunner(fakeExec.exec)
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil,
+		map[string]string{postStartExecAnnotationKey: `["/bin/sh","-c","warm-up"]`})
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.NoError(t, ds.StartContainer(id))
+	require.Len(t, fakeExec.calls, 1)
+	assert.Equal(t, []string{"/bin/sh", "-c", "warm-up"}, fakeExec.calls[0])
+	assert.Equal(t, postStartHookTimeout, fakeExec.timeouts[0])
+	result := ds.lifecycleHooks.result(id)
+	require.NotNil(t, result)
+	assert.Equal(t, postStartHookName, result.Hook)
+	assert.Equal(t, "", result.Error)
+
+	// A failed hook fails the start and stops the container.
+	fakeExec.err = fmt.Errorf("command terminated with exit code 1")
+	fakeExec.stderr = "not ready"
+	config = makeContainerConfig(sConfig, "c2", "iamimage", 0, nil,
+		map[string]string{postStartExecAnnotationKey: `["/bin/false"]`})
+	id, err = ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	err = ds.StartContainer(id)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "postStart hook")
+	c, err := fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.False(t, c.State.Running)
+	result = ds.lifecycleHooks.result(id)
+	require.NotNil(t, result)
+	assert.Contains(t, result.Error, "not ready")
+
+	// The result is dropped with the container.
+	require.NoError(t, ds.RemoveContainer(id))
+	assert.Nil(t, ds.lifecycleHooks.result(id))
+}
+
+// TestPreStopHook tests that StopContainer runs the preStop hook within the
+// grace period and stops the container regardless of the hook's outcome.
+func TestPreStopHook(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	fakeExec := &fakeHookExec{}
+	ds.lifecycleHooks = newLifecycleHookRunner(fakeExec.exec)
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil,
+		map[string]string{preStopExecAnnotationKey: `["/bin/sh","-c","drain"]`})
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.NoError(t, ds.StartContainer(id))
+
+	require.NoError(t, ds.StopContainer(id, 10))
+	require.Len(t, fakeExec.calls, 1)
+	assert.Equal(t, []string{"/bin/sh", "-c", "drain"}, fakeExec.calls[0])
+	assert.Equal(t, 10*time.Second, fakeExec.timeouts[0])
+	c, err := fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.False(t, c.State.Running)
+
+	// A failed hook is recorded but does not block the stop.
+	config = makeContainerConfig(sConfig, "c2", "iamimage", 0, nil,
+		map[string]string{preStopExecAnnotationKey: `["/bin/false"]`})
+	id, err = ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.NoError(t, ds.StartContainer(id))
+	fakeExec.err = fmt.Errorf("command terminated with exit code 1")
+	require.NoError(t, ds.StopContainer(id, 10))
+	c, err = fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.False(t, c.State.Running)
+	result := ds.lifecycleHooks.result(id)
+	require.NotNil(t, result)
+	assert.Equal(t, preStopHookName, result.Hook)
+	assert.Contains(t, result.Error, "PreStop hook")
+}
+
+// TestMakeContainerDockerConfigHooks tests that malformed hook annotations
+// fail the container at creation time.
+func TestMakeContainerDockerConfigHooks(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil,
+		map[string]string{preStopExecAnnotationKey: "not json"})
+	_, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), preStopExecAnnotationKey)
+}
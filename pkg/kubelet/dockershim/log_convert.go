@@ -0,0 +1,135 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Docker's json-file driver writes one JSON object per line, e.g.
+//   {"log":"content\n","stream":"stdout","time":"2017-02-08T10:00:00.000000001Z"}
+// while the CRI log format is a plain-text line of the form
+//   2017-02-08T10:00:00.000000001Z stdout F content
+// with a partial-line tag of "P" when the entry does not end the line (the
+// driver splits long lines into multiple entries). Converting at read time
+// keeps the kubelet's log reading code path uniform across runtimes without
+// changing how docker stores the logs on disk.
+
+const (
+	// criLogTagFull marks a CRI log entry that ends its log line.
+	criLogTagFull = "F"
+	// criLogTagPartial marks a CRI log entry continued by the next one.
+	criLogTagPartial = "P"
+)
+
+// jsonFileLogEntry is one line of docker's json-file log format.
+type jsonFileLogEntry struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// parseJSONFileLogEntry parses and validates one json-file line, returning
+// the entry and its timestamp.
+func parseJSONFileLogEntry(line []byte) (*jsonFileLogEntry, time.Time, error) {
+	var entry jsonFileLogEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse json-file log entry: %v", err)
+	}
+	if entry.Stream != "stdout" && entry.Stream != "stderr" {
+		return nil, time.Time{}, fmt.Errorf("unexpected stream %q in json-file log entry", entry.Stream)
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, entry.Time)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid timestamp %q in json-file log entry: %v", entry.Time, err)
+	}
+	return &entry, timestamp, nil
+}
+
+// criLine formats the entry as one CRI log format line, including the
+// trailing newline.
+func (e *jsonFileLogEntry) criLine() []byte {
+	tag := criLogTagPartial
+	log := e.Log
+	if len(log) > 0 && log[len(log)-1] == '\n' {
+		tag = criLogTagFull
+		log = log[:len(log)-1]
+	}
+	return []byte(fmt.Sprintf("%s %s %s %s\n", e.Time, e.Stream, tag, log))
+}
+
+// convertJSONFileLogEntry converts one json-file line into one CRI log
+// format line, including the trailing newline.
+func convertJSONFileLogEntry(line []byte) ([]byte, error) {
+	entry, _, err := parseJSONFileLogEntry(line)
+	if err != nil {
+		return nil, err
+	}
+	return entry.criLine(), nil
+}
+
+// convertJSONFileLogs reads docker json-file log lines from r and writes
+// them to w in the CRI log format. A trailing fragment without a newline is
+// ignored, since the live log file's last entry may not be fully written
+// yet; a malformed complete line is an error.
+func convertJSONFileLogs(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read json-file log: %v", err)
+		}
+		line = bytes.TrimRight(line, "\n")
+		if len(line) == 0 {
+			continue
+		}
+		converted, err := convertJSONFileLogEntry(line)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(converted); err != nil {
+			return fmt.Errorf("failed to write converted log: %v", err)
+		}
+	}
+}
+
+// serveContainerLogs writes the container's logs to w in the CRI log
+// format, converting from docker's on-disk json-file format.
+func (ds *dockerService) serveContainerLogs(containerID string, w io.Writer) error {
+	info, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %q: %v", containerID, err)
+	}
+	if info.HostConfig != nil && info.HostConfig.LogConfig.Type != "" && info.HostConfig.LogConfig.Type != jsonFileLogDriver {
+		return fmt.Errorf("cannot convert logs of container %q: log driver is %q, not %q", containerID, info.HostConfig.LogConfig.Type, jsonFileLogDriver)
+	}
+	f, err := os.Open(info.LogPath)
+	if err != nil {
+		return fmt.Errorf("failed to open log file of container %q: %v", containerID, err)
+	}
+	defer f.Close()
+	return convertJSONFileLogs(f, w)
+}
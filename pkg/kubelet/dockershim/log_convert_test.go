@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConvertJSONFileLogEntry tests single-entry conversion.
+func TestConvertJSONFileLogEntry(t *testing.T) {
+	for _, tc := range []struct {
+		line        string
+		expected    string
+		expectError bool
+	}{
+		// A complete line is tagged F.
+		{
+			line:     `{"log":"hello world\n","stream":"stdout","time":"2017-02-08T10:00:00.000000001Z"}`,
+			expected: "2017-02-08T10:00:00.000000001Z stdout F hello world\n",
+		},
+		// An entry without a trailing newline is a partial line.
+		{
+			line:     `{"log":"hello ","stream":"stdout","time":"2017-02-08T10:00:00.000000001Z"}`,
+			expected: "2017-02-08T10:00:00.000000001Z stdout P hello \n",
+		},
+		// Stderr keeps its stream tag.
+		{
+			line:     `{"log":"oops\n","stream":"stderr","time":"2017-02-08T10:00:00.000000001Z"}`,
+			expected: "2017-02-08T10:00:00.000000001Z stderr F oops\n",
+		},
+		// An empty log entry still produces a line.
+		{
+			line:     `{"log":"\n","stream":"stdout","time":"2017-02-08T10:00:00.000000001Z"}`,
+			expected: "2017-02-08T10:00:00.000000001Z stdout F \n",
+		},
+		{
+			line:        `not json`,
+			expectError: true,
+		},
+		{
+			line:        `{"log":"x\n","stream":"tty","time":"2017-02-08T10:00:00.000000001Z"}`,
+			expectError: true,
+		},
+		{
+			line:        `{"log":"x\n","stream":"stdout","time":"yesterday"}`,
+			expectError: true,
+		},
+	} {
+		converted, err := convertJSONFileLogEntry([]byte(tc.line))
+		if tc.expectError {
+			assert.Error(t, err, "line %q", tc.line)
+			continue
+		}
+		require.NoError(t, err, "line %q", tc.line)
+		assert.Equal(t, tc.expected, string(converted), "line %q", tc.line)
+	}
+}
+
+// TestConvertJSONFileLogs tests streaming conversion of a whole log file.
+func TestConvertJSONFileLogs(t *testing.T) {
+	input := strings.Join([]string{
+		`{"log":"line one\n","stream":"stdout","time":"2017-02-08T10:00:00.000000001Z"}`,
+		`{"log":"split ","stream":"stdout","time":"2017-02-08T10:00:00.000000002Z"}`,
+		`{"log":"line\n","stream":"stdout","time":"2017-02-08T10:00:00.000000003Z"}`,
+		`{"log":"to stderr\n","stream":"stderr","time":"2017-02-08T10:00:00.000000004Z"}`,
+	}, "\n") + "\n"
+	expected := strings.Join([]string{
+		"2017-02-08T10:00:00.000000001Z stdout F line one",
+		"2017-02-08T10:00:00.000000002Z stdout P split ",
+		"2017-02-08T10:00:00.000000003Z stdout F line",
+		"2017-02-08T10:00:00.000000004Z stderr F to stderr",
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	require.NoError(t, convertJSONFileLogs(strings.NewReader(input), &out))
+	assert.Equal(t, expected, out.String())
+
+	// A trailing fragment still being written by the daemon is ignored.
+	out.Reset()
+	truncated := input + `{"log":"in pro`
+	require.NoError(t, convertJSONFileLogs(strings.NewReader(truncated), &out))
+	assert.Equal(t, expected, out.String())
+
+	// A malformed complete line is an error.
+	out.Reset()
+	corrupt := input + "garbage\n"
+	assert.Error(t, convertJSONFileLogs(strings.NewReader(corrupt), &out))
+
+	// The empty file converts to empty output.
+	out.Reset()
+	require.NoError(t, convertJSONFileLogs(strings.NewReader(""), &out))
+	assert.Equal(t, "", out.String())
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+)
+
+const (
+	// containerLogMaxSizeAnnotationKey overrides the maximum size a container
+	// log file may grow to before it is rotated. The value uses docker's
+	// max-size syntax, e.g. "10m".
+	containerLogMaxSizeAnnotationKey = "logging.alpha.kubernetes.io/container-log-max-size"
+	// containerLogMaxFilesAnnotationKey overrides the maximum number of log
+	// files (including the live one) kept per container.
+	containerLogMaxFilesAnnotationKey = "logging.alpha.kubernetes.io/container-log-max-files"
+
+	// defaultContainerLogMaxSize is the default rotation threshold for a
+	// container log file.
+	defaultContainerLogMaxSize = "10m"
+	// defaultContainerLogMaxFiles is the default number of log files kept per
+	// container.
+	defaultContainerLogMaxFiles = 5
+
+	// jsonFileLogDriver is docker's default log driver, and the only one the
+	// kubelet can read container logs from.
+	jsonFileLogDriver = "json-file"
+)
+
+// containerLogMaxSizeRegexp matches docker's max-size syntax: a positive
+// integer with an optional k, m or g unit suffix.
+var containerLogMaxSizeRegexp = regexp.MustCompile(`^[1-9][0-9]*[kmg]?$`)
+
+// containerLogConfig returns the docker log configuration enforcing the log
+// rotation policy for a container. Rotation is performed by docker's
+// json-file driver, which keeps writing to the same file name; the CRI log
+// path symlink therefore stays valid across rotations and kubectl logs keeps
+// following the live file.
+func containerLogConfig(annotations map[string]string) (dockercontainer.LogConfig, error) {
+	maxSize := defaultContainerLogMaxSize
+	if value, ok := annotations[containerLogMaxSizeAnnotationKey]; ok {
+		if !containerLogMaxSizeRegexp.MatchString(value) {
+			return dockercontainer.LogConfig{}, fmt.Errorf("invalid value %q in %s annotation", value, containerLogMaxSizeAnnotationKey)
+		}
+		maxSize = value
+	}
+
+	maxFiles := defaultContainerLogMaxFiles
+	if value, ok := annotations[containerLogMaxFilesAnnotationKey]; ok {
+		files, err := strconv.Atoi(value)
+		if err != nil || files < 1 {
+			return dockercontainer.LogConfig{}, fmt.Errorf("invalid value %q in %s annotation", value, containerLogMaxFilesAnnotationKey)
+		}
+		maxFiles = files
+	}
+
+	return dockercontainer.LogConfig{
+		Type: jsonFileLogDriver,
+		Config: map[string]string{
+			"max-size": maxSize,
+			"max-file": strconv.Itoa(maxFiles),
+		},
+	}, nil
+}
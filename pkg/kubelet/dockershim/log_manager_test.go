@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerLogConfig(t *testing.T) {
+	tests := []struct {
+		annotations      map[string]string
+		expectedMaxSize  string
+		expectedMaxFiles string
+		expectError      bool
+	}{{
+		// Defaults apply without annotations.
+		annotations:      nil,
+		expectedMaxSize:  "10m",
+		expectedMaxFiles: "5",
+	}, {
+		annotations: map[string]string{
+			containerLogMaxSizeAnnotationKey:  "100k",
+			containerLogMaxFilesAnnotationKey: "2",
+		},
+		expectedMaxSize:  "100k",
+		expectedMaxFiles: "2",
+	}, {
+		annotations: map[string]string{containerLogMaxSizeAnnotationKey: "10mb"},
+		expectError: true,
+	}, {
+		annotations: map[string]string{containerLogMaxSizeAnnotationKey: "0m"},
+		expectError: true,
+	}, {
+		annotations: map[string]string{containerLogMaxFilesAnnotationKey: "0"},
+		expectError: true,
+	}, {
+		annotations: map[string]string{containerLogMaxFilesAnnotationKey: "many"},
+		expectError: true,
+	}}
+
+	for i, test := range tests {
+		logConfig, err := containerLogConfig(test.annotations)
+		if test.expectError {
+			assert.Error(t, err, "TestCase[%d]", i)
+			continue
+		}
+		require.NoError(t, err, "TestCase[%d]", i)
+		assert.Equal(t, jsonFileLogDriver, logConfig.Type, "TestCase[%d]", i)
+		assert.Equal(t, test.expectedMaxSize, logConfig.Config["max-size"], "TestCase[%d]", i)
+		assert.Equal(t, test.expectedMaxFiles, logConfig.Config["max-file"], "TestCase[%d]", i)
+	}
+}
+
+func TestCreateContainerLogRotation(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+
+	// With the feature disabled, the daemon default logging applies.
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil, nil)
+	id, err := ds.CreateContainer("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	assert.Empty(t, fDocker.ContainerMap[id].HostConfig.LogConfig.Type)
+
+	require.NoError(t, ds.featureGate.set("ContainerLogRotation=true"))
+	sConfig = makeSandboxConfigWithLabelsAndAnnotations("foo2", "bar", "2", 0,
+		nil, map[string]string{containerLogMaxSizeAnnotationKey: "1m"})
+	config = makeContainerConfig(sConfig, "c2", "iamimage", 0, nil, nil)
+	id, err = ds.CreateContainer("sandboxid2", config, sConfig)
+	require.NoError(t, err)
+	logConfig := fDocker.ContainerMap[id].HostConfig.LogConfig
+	assert.Equal(t, jsonFileLogDriver, logConfig.Type)
+	assert.Equal(t, "1m", logConfig.Config["max-size"])
+	assert.Equal(t, "5", logConfig.Config["max-file"])
+}
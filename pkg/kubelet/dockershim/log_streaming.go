@@ -0,0 +1,175 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+)
+
+// Log streaming serves a container's json-file log in the CRI log format
+// with the selection applied on the node: follow mode, a since-time bound,
+// tail line counts and server-side regex filtering. Filtering before the
+// bytes leave the node keeps kubectl logs on chatty containers from
+// transferring log data the caller will immediately discard.
+
+// logStreamPollPeriod is how often follow mode re-checks the log file for
+// appended data after hitting the end.
+const logStreamPollPeriod = 100 * time.Millisecond
+
+// logStreamOptions selects which log entries are streamed.
+type logStreamOptions struct {
+	// follow keeps the stream open and serves entries as the daemon
+	// appends them.
+	follow bool
+	// since drops entries logged before the given time. The zero value
+	// serves from the start of the log.
+	since time.Time
+	// tailLines starts the stream at the last N selected entries. A
+	// negative value serves the whole log.
+	tailLines int64
+	// filter, when non-nil, only serves entries whose log content matches.
+	filter *regexp.Regexp
+}
+
+// matches returns whether an entry passes the since and filter selection.
+func (o *logStreamOptions) matches(entry *jsonFileLogEntry, timestamp time.Time) bool {
+	if !o.since.IsZero() && timestamp.Before(o.since) {
+		return false
+	}
+	if o.filter != nil && !o.filter.MatchString(entry.Log) {
+		return false
+	}
+	return true
+}
+
+// seekToTail positions f at the first of the last tailLines selected
+// entries, or at the end of the file when nothing is selected.
+func seekToTail(f *os.File, opts *logStreamOptions) error {
+	reader := bufio.NewReader(f)
+	offsets := make([]int64, 0, opts.tailLines)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// An unfinished trailing fragment is not a complete entry;
+			// streaming resumes from its start so follow mode can finish
+			// reading it.
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read json-file log: %v", err)
+		}
+		lineStart := offset
+		offset += int64(len(line))
+		line = bytes.TrimRight(line, "\n")
+		if len(line) == 0 {
+			continue
+		}
+		entry, timestamp, err := parseJSONFileLogEntry(line)
+		if err != nil {
+			return err
+		}
+		if !opts.matches(entry, timestamp) {
+			continue
+		}
+		offsets = append(offsets, lineStart)
+		if int64(len(offsets)) > opts.tailLines {
+			offsets = offsets[1:]
+		}
+	}
+	start := offset
+	if len(offsets) > 0 {
+		start = offsets[0]
+	}
+	if _, err := f.Seek(start, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek in json-file log: %v", err)
+	}
+	return nil
+}
+
+// streamJSONFileLogs reads docker json-file log lines from f and writes the
+// selected entries to w in the CRI log format. In follow mode it keeps
+// polling for appended data until stop is closed.
+func streamJSONFileLogs(f *os.File, w io.Writer, opts *logStreamOptions, stop <-chan struct{}) error {
+	if opts.tailLines >= 0 {
+		if err := seekToTail(f, opts); err != nil {
+			return err
+		}
+	}
+	reader := bufio.NewReader(f)
+	var pending []byte
+	for {
+		chunk, err := reader.ReadBytes('\n')
+		pending = append(pending, chunk...)
+		if err == io.EOF {
+			if !opts.follow {
+				// A trailing fragment still being written is ignored, as in
+				// the plain converter.
+				return nil
+			}
+			select {
+			case <-stop:
+				return nil
+			case <-time.After(logStreamPollPeriod):
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read json-file log: %v", err)
+		}
+		line := bytes.TrimRight(pending, "\n")
+		pending = nil
+		if len(line) == 0 {
+			continue
+		}
+		entry, timestamp, err := parseJSONFileLogEntry(line)
+		if err != nil {
+			return err
+		}
+		if !opts.matches(entry, timestamp) {
+			continue
+		}
+		if _, err := w.Write(entry.criLine()); err != nil {
+			return fmt.Errorf("failed to write log entry: %v", err)
+		}
+	}
+}
+
+// streamContainerLogs streams the container's logs to w in the CRI log
+// format, applying the selection in opts. It returns when the log is
+// exhausted, or in follow mode when stop is closed.
+func (ds *dockerService) streamContainerLogs(containerID string, opts *logStreamOptions, w io.Writer, stop <-chan struct{}) error {
+	info, err := ds.client.InspectContainer(containerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %q: %v", containerID, err)
+	}
+	if info.HostConfig != nil && info.HostConfig.LogConfig.Type != "" && info.HostConfig.LogConfig.Type != jsonFileLogDriver {
+		return fmt.Errorf("cannot stream logs of container %q: log driver is %q, not %q", containerID, info.HostConfig.LogConfig.Type, jsonFileLogDriver)
+	}
+	f, err := os.Open(info.LogPath)
+	if err != nil {
+		return fmt.Errorf("failed to open log file of container %q: %v", containerID, err)
+	}
+	defer f.Close()
+	return streamJSONFileLogs(f, w, opts, stop)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// writeLogFile writes a json-file log with the given lines to a temp file
+// and returns it opened for reading.
+func writeLogFile(t *testing.T, lines ...string) *os.File {
+	tmp, err := ioutil.TempFile("", "log-streaming-test")
+	require.NoError(t, err)
+	for _, line := range lines {
+		_, err := tmp.WriteString(line + "\n")
+		require.NoError(t, err)
+	}
+	_, err = tmp.Seek(0, 0)
+	require.NoError(t, err)
+	return tmp
+}
+
+// syncBuffer is a bytes.Buffer safe for a concurrent reader, for follow
+// mode tests.
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
+
+// TestStreamJSONFileLogsSelection tests the since, tail and filter options.
+func TestStreamJSONFileLogsSelection(t *testing.T) {
+	lines := []string{
+		`{"log":"alpha\n","stream":"stdout","time":"2017-02-08T10:00:01Z"}`,
+		`{"log":"beta\n","stream":"stdout","time":"2017-02-08T10:00:02Z"}`,
+		`{"log":"alpha again\n","stream":"stderr","time":"2017-02-08T10:00:03Z"}`,
+		`{"log":"gamma\n","stream":"stdout","time":"2017-02-08T10:00:04Z"}`,
+	}
+	for _, tc := range []struct {
+		name     string
+		opts     logStreamOptions
+		expected []string
+	}{
+		{
+			name:     "everything",
+			opts:     logStreamOptions{tailLines: -1},
+			expected: []string{"alpha", "beta", "alpha again", "gamma"},
+		},
+		{
+			name:     "since drops older entries",
+			opts:     logStreamOptions{tailLines: -1, since: time.Date(2017, 2, 8, 10, 0, 3, 0, time.UTC)},
+			expected: []string{"alpha again", "gamma"},
+		},
+		{
+			name:     "tail starts at the last N entries",
+			opts:     logStreamOptions{tailLines: 2},
+			expected: []string{"alpha again", "gamma"},
+		},
+		{
+			name:     "tail of zero serves nothing",
+			opts:     logStreamOptions{tailLines: 0},
+			expected: []string{},
+		},
+		{
+			name:     "filter only serves matching entries",
+			opts:     logStreamOptions{tailLines: -1, filter: regexp.MustCompile("^alpha")},
+			expected: []string{"alpha", "alpha again"},
+		},
+		{
+			name:     "tail counts selected entries only",
+			opts:     logStreamOptions{tailLines: 1, filter: regexp.MustCompile("^alpha")},
+			expected: []string{"alpha again"},
+		},
+	} {
+		f := writeLogFile(t, lines...)
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		var out bytes.Buffer
+		require.NoError(t, streamJSONFileLogs(f, &out, &tc.opts, nil), tc.name)
+		var contents []string
+		for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			// Strip "<timestamp> <stream> <tag> " to compare contents.
+			parts := strings.SplitN(line, " ", 4)
+			require.Len(t, parts, 4, tc.name)
+			contents = append(contents, parts[3])
+		}
+		assert.Equal(t, tc.expected, append([]string{}, contents...), tc.name)
+	}
+}
+
+// TestStreamJSONFileLogsFollow tests that follow mode serves appended
+// entries and stops when asked to.
+func TestStreamJSONFileLogsFollow(t *testing.T) {
+	f := writeLogFile(t, `{"log":"first\n","stream":"stdout","time":"2017-02-08T10:00:01Z"}`)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	out := &syncBuffer{}
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- streamJSONFileLogs(f, out, &logStreamOptions{follow: true, tailLines: -1}, stop)
+	}()
+
+	require.NoError(t, wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		return strings.Contains(out.String(), "first"), nil
+	}))
+
+	appender, err := os.OpenFile(f.Name(), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = appender.WriteString(`{"log":"second\n","stream":"stdout","time":"2017-02-08T10:00:02Z"}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, appender.Close())
+
+	require.NoError(t, wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		return strings.Contains(out.String(), "second"), nil
+	}))
+
+	close(stop)
+	require.NoError(t, <-done)
+}
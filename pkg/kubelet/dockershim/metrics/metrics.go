@@ -0,0 +1,171 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DockershimSubsystem is the subsystem all dockershim metrics are
+	// exported under.
+	DockershimSubsystem = "dockershim"
+
+	OperationsLatencyKey  = "operations_latency_seconds"
+	OperationsErrorsKey   = "operations_errors"
+	OperationsInFlightKey = "operations_in_flight"
+	SandboxChurnKey       = "sandbox_churn"
+
+	ImagePullsCoalescedKey  = "image_pulls_coalesced"
+	ImagePullWaitLatencyKey = "image_pull_wait_latency_seconds"
+
+	NetworkCheckFailuresKey = "network_check_failures"
+
+	SandboxOverheadCPUKey    = "sandbox_overhead_cpu_core_nanoseconds"
+	SandboxOverheadMemoryKey = "sandbox_overhead_memory_working_set_bytes"
+	SandboxOverheadCountKey  = "sandbox_overhead_sandboxes"
+)
+
+var (
+	// OperationsLatency collects the latency of dockershim operations by
+	// operation type.
+	OperationsLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      OperationsLatencyKey,
+			Help:      "Latency in seconds of dockershim operations. Broken down by operation type.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"operation_type"},
+	)
+	// OperationsErrors collects the number of failed dockershim operations
+	// by operation type.
+	OperationsErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      OperationsErrorsKey,
+			Help:      "Cumulative number of dockershim operation errors by operation type.",
+		},
+		[]string{"operation_type"},
+	)
+	// SandboxChurn counts sandbox recreations by pod. A sandbox is
+	// recreated when its predecessor died or was evicted, so a pod with a
+	// fast-growing count has a crash-looping sandbox. The cardinality is
+	// bounded by the number of pods that fit on the node.
+	SandboxChurn = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      SandboxChurnKey,
+			Help:      "Cumulative number of pod sandbox recreations by pod.",
+		},
+		[]string{"pod"},
+	)
+	// ImagePullsCoalesced counts pulls that piggybacked on an in-flight
+	// pull of the same image instead of reaching the registry themselves.
+	ImagePullsCoalesced = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      ImagePullsCoalescedKey,
+			Help:      "Cumulative number of image pulls coalesced onto an in-flight pull of the same image.",
+		},
+	)
+	// ImagePullWaitLatency collects how long coalesced pulls waited for the
+	// pull they piggybacked on.
+	ImagePullWaitLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      ImagePullWaitLatencyKey,
+			Help:      "Latency in seconds coalesced image pulls spent waiting for the in-flight pull they joined.",
+			Buckets:   prometheus.DefBuckets,
+		},
+	)
+	// NetworkCheckFailures counts failed verifications of live pod network
+	// attachments by pod. The cardinality is bounded by the number of pods
+	// that fit on the node.
+	NetworkCheckFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      NetworkCheckFailuresKey,
+			Help:      "Cumulative number of failed pod network attachment checks by pod.",
+		},
+		[]string{"pod"},
+	)
+	// SandboxOverheadCPU reports the cumulative CPU consumed by all sandbox
+	// (pause) containers on the node, in core-nanoseconds.
+	SandboxOverheadCPU = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      SandboxOverheadCPUKey,
+			Help:      "Cumulative CPU usage in core-nanoseconds of all sandbox containers on the node.",
+		},
+	)
+	// SandboxOverheadMemory reports the total working set memory of all
+	// sandbox (pause) containers on the node.
+	SandboxOverheadMemory = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      SandboxOverheadMemoryKey,
+			Help:      "Total working set memory in bytes of all sandbox containers on the node.",
+		},
+	)
+	// SandboxOverheadCount reports how many sandbox containers contributed
+	// to the overhead aggregates.
+	SandboxOverheadCount = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      SandboxOverheadCountKey,
+			Help:      "Number of sandbox containers included in the overhead aggregates.",
+		},
+	)
+	// OperationsInFlight tracks the number of dockershim operations
+	// currently in progress by operation type.
+	OperationsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: DockershimSubsystem,
+			Name:      OperationsInFlightKey,
+			Help:      "Number of dockershim operations currently in progress. Broken down by operation type.",
+		},
+		[]string{"operation_type"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register registers all dockershim metrics with the default prometheus
+// registry, which the kubelet exposes on its metrics endpoint.
+func Register() {
+	registerMetrics.Do(func() {
+		prometheus.MustRegister(OperationsLatency)
+		prometheus.MustRegister(OperationsErrors)
+		prometheus.MustRegister(OperationsInFlight)
+		prometheus.MustRegister(SandboxChurn)
+		prometheus.MustRegister(ImagePullsCoalesced)
+		prometheus.MustRegister(ImagePullWaitLatency)
+		prometheus.MustRegister(NetworkCheckFailures)
+		prometheus.MustRegister(SandboxOverheadCPU)
+		prometheus.MustRegister(SandboxOverheadMemory)
+		prometheus.MustRegister(SandboxOverheadCount)
+	})
+}
+
+// SinceInSeconds gets the time since the specified start in seconds.
+func SinceInSeconds(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// This file translates per-mount propagation requests into docker bind
+// options. CSI node plugins and device drivers running as containers need
+// mounts they create under e.g. /var/lib/kubelet to propagate back to the
+// host (Bidirectional), and monitoring agents need host mounts to show up
+// inside a running container (HostToContainer). Bidirectional propagation
+// only works when the docker daemon itself runs on a shared mount; a daemon
+// started with systemd MountFlags=slave (a common hardening default) lives
+// in a slave mount namespace and rshared binds created there never reach
+// the host, so the shim detects this at startup and rejects Bidirectional
+// requests outright instead of letting them fail silently.
+
+const (
+	// mountPropagationAnnotationKey carries per-mount propagation requests
+	// on the sandbox config, comma separated, each entry
+	// "<containerPath>=<mode>" with mode one of None, HostToContainer or
+	// Bidirectional (e.g. "/var/lib/kubelet=Bidirectional").
+	mountPropagationAnnotationKey = "mount.alpha.kubernetes.io/propagation"
+)
+
+// mountPropagationModes maps the propagation mode names accepted in the
+// annotation to the docker bind option implementing them.
+var mountPropagationModes = map[string]string{
+	"None":            "rprivate",
+	"HostToContainer": "rslave",
+	"Bidirectional":   "rshared",
+}
+
+// parseMountPropagation parses the mount propagation annotation into a map
+// of container path to docker bind option.
+func parseMountPropagation(annotations map[string]string) (map[string]string, error) {
+	value, ok := annotations[mountPropagationAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	result := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") {
+			return nil, fmt.Errorf("mount propagation entry %q must be \"<containerPath>=<mode>\"", entry)
+		}
+		opt, known := mountPropagationModes[parts[1]]
+		if !known {
+			return nil, fmt.Errorf("unknown mount propagation mode %q for %q (known modes: None, HostToContainer, Bidirectional)", parts[1], parts[0])
+		}
+		result[parts[0]] = opt
+	}
+	return result, nil
+}
+
+// validateMountPropagation rejects propagation requests the node cannot
+// honor, so the kubelet reports a clear event instead of the mount silently
+// not propagating.
+func (ds *dockerService) validateMountPropagation(propagation map[string]string) error {
+	for path, opt := range propagation {
+		if opt == "rshared" && !ds.sharedMountSupported {
+			return fmt.Errorf("bidirectional mount propagation requested for %q, but the node does not support it: %s", path, ds.sharedMountReason)
+		}
+	}
+	return nil
+}
+
+// sharedMountSupport returns whether the docker root directory lives on a
+// shared mount, and a reason when it does not.
+func sharedMountSupport(dockerRootDir string) (bool, string) {
+	return sharedMountSupportFromFile(dockerRootDir, "/proc/self/mountinfo")
+}
+
+func sharedMountSupportFromFile(dockerRootDir, mountinfoPath string) (bool, string) {
+	if dockerRootDir == "" {
+		return false, "docker root directory is unknown"
+	}
+	data, err := ioutil.ReadFile(mountinfoPath)
+	if err != nil {
+		return false, fmt.Sprintf("failed to read %s: %v", mountinfoPath, err)
+	}
+	// Find the longest mount point containing the docker root; its peer
+	// group tags decide the propagation mode the daemon inherited.
+	best := ""
+	shared := false
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 7 {
+			continue
+		}
+		mountPoint := fields[4]
+		if mountPoint != "/" && !strings.HasPrefix(dockerRootDir+"/", mountPoint+"/") {
+			continue
+		}
+		if len(mountPoint) < len(best) {
+			continue
+		}
+		best = mountPoint
+		// Optional fields sit between the mount point and the "-"
+		// separator; shared mounts carry a "shared:N" peer group tag.
+		shared = false
+		for _, field := range fields[6:] {
+			if field == "-" {
+				break
+			}
+			if strings.HasPrefix(field, "shared:") {
+				shared = true
+			}
+		}
+	}
+	if best == "" {
+		return false, fmt.Sprintf("no mount found for the docker root directory %q", dockerRootDir)
+	}
+	if !shared {
+		return false, fmt.Sprintf("the docker root directory %q is not on a shared mount (is the docker daemon running with MountFlags=slave?)", dockerRootDir)
+	}
+	return true, ""
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestParseMountPropagation tests the mount propagation annotation format.
+func TestParseMountPropagation(t *testing.T) {
+	// No annotation means no propagation requests.
+	propagation, err := parseMountPropagation(map[string]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, propagation)
+
+	propagation, err = parseMountPropagation(map[string]string{
+		mountPropagationAnnotationKey: "/var/lib/kubelet=Bidirectional, /host=HostToContainer,/data=None",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/var/lib/kubelet": "rshared",
+		"/host":            "rslave",
+		"/data":            "rprivate",
+	}, propagation)
+
+	// Invalid entries are rejected.
+	for _, value := range []string{"/var/lib/kubelet", "/var/lib/kubelet=Shared", "kubelet=Bidirectional"} {
+		_, err = parseMountPropagation(map[string]string{mountPropagationAnnotationKey: value})
+		assert.Error(t, err, "value %q", value)
+	}
+}
+
+// TestGenerateMountBindingsPropagation tests that propagation modes are
+// appended to the docker bind options.
+func TestGenerateMountBindingsPropagation(t *testing.T) {
+	mounts := []*runtimeapi.Mount{
+		{HostPath: "/var/lib/kubelet", ContainerPath: "/var/lib/kubelet"},
+		{HostPath: "/proc", ContainerPath: "/host/proc", Readonly: true},
+		{HostPath: "/data", ContainerPath: "/data", SelinuxRelabel: true},
+		{HostPath: "/tmp", ContainerPath: "/tmp"},
+	}
+	propagation := map[string]string{
+		"/var/lib/kubelet": "rshared",
+		"/host/proc":       "rslave",
+		"/data":            "rprivate",
+	}
+	assert.Equal(t, []string{
+		"/var/lib/kubelet:/var/lib/kubelet:rshared",
+		"/proc:/host/proc:ro,rslave",
+		"/data:/data:Z,rprivate",
+		"/tmp:/tmp",
+	}, generateMountBindings(mounts, propagation))
+}
+
+// TestSharedMountSupport tests the mountinfo parsing behind the shared
+// mount detection.
+func TestSharedMountSupport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mountinfo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mountinfo")
+
+	// The docker root is covered by a dedicated shared mount.
+	sharedInfo := "22 1 8:1 / / rw,relatime - ext4 /dev/sda1 rw\n" +
+		"42 22 8:2 / /var/lib/docker rw,relatime shared:1 - ext4 /dev/sda2 rw\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(sharedInfo), 0644))
+	supported, reason := sharedMountSupportFromFile("/var/lib/docker", path)
+	assert.True(t, supported, reason)
+
+	// The root mount is shared but the docker root mount is a slave, as
+	// left behind by a daemon started with MountFlags=slave.
+	slaveInfo := "22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n" +
+		"42 22 8:2 / /var/lib/docker rw,relatime master:1 - ext4 /dev/sda2 rw\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(slaveInfo), 0644))
+	supported, reason = sharedMountSupportFromFile("/var/lib/docker", path)
+	assert.False(t, supported)
+	assert.Contains(t, reason, "MountFlags=slave")
+
+	// Without a dedicated mount the root mount's propagation decides.
+	require.NoError(t, ioutil.WriteFile(path, []byte("22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n"), 0644))
+	supported, _ = sharedMountSupportFromFile("/var/lib/docker", path)
+	assert.True(t, supported)
+
+	supported, _ = sharedMountSupportFromFile("", path)
+	assert.False(t, supported)
+}
+
+// TestCreateContainerMountPropagation tests that bidirectional propagation
+// is rejected when the node does not support it and honored when it does.
+func TestCreateContainerMountPropagation(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	sConfig.Annotations = map[string]string{
+		mountPropagationAnnotationKey: "/var/lib/kubelet=Bidirectional",
+	}
+	config := makeContainerConfig(sConfig, "pause", "iamimage", 0, nil, nil)
+	config.Mounts = []*runtimeapi.Mount{{HostPath: "/var/lib/kubelet", ContainerPath: "/var/lib/kubelet"}}
+
+	// The test service detects no shared mount support.
+	_, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bidirectional mount propagation")
+
+	ds.sharedMountSupported = true
+	createConfig, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/var/lib/kubelet:/var/lib/kubelet:rshared"}, createConfig.HostConfig.Binds)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// Hard multi-tenant nodes host pods of mutually distrusting namespaces and
+// want different runtime defaults per tenant without requiring every pod to
+// carry annotations. The namespace policy file (configured via
+// --experimental-dockershim-namespace-policy-file) maps namespaces to such
+// defaults, e.g.:
+//
+//	{"tenant-a": {"sandboxImage": "registry.tenant-a/pause:3.0",
+//	              "seccompProfile": "localhost/tenant-a.json",
+//	              "allowedSysctls": ["net.ipv4.*"],
+//	              "runtime": "runsc"}}
+//
+// The sandbox image, seccomp profile and runtime are defaults: a pod
+// choosing its own (via its annotations) still wins. The sysctl allowlist is
+// a restriction: sandboxes in the namespace may only request the listed
+// sysctls. Namespaces absent from the file keep the node-level defaults.
+
+// namespacePolicy holds the runtime defaults of one namespace.
+type namespacePolicy struct {
+	// SandboxImage overrides the node's sandbox (pause) image.
+	SandboxImage string `json:"sandboxImage,omitempty"`
+	// SeccompProfile is the default seccomp profile of pods that do not
+	// choose one, in the usual annotation syntax (e.g. "docker/default").
+	SeccompProfile string `json:"seccompProfile,omitempty"`
+	// AllowedSysctls limits the sysctls pods may request. Entries ending in
+	// '*' are prefix patterns. Empty means no namespace-level limit.
+	AllowedSysctls []string `json:"allowedSysctls,omitempty"`
+	// Runtime is the default OCI runtime of sandboxes that do not choose
+	// one through the runtime annotation.
+	Runtime string `json:"runtime,omitempty"`
+}
+
+// namespacePolicies maps namespaces to their policies. Nil in most tests
+// and when no policy file is configured, which keeps the node-level
+// defaults for every namespace.
+type namespacePolicies map[string]*namespacePolicy
+
+// newNamespacePolicies loads the namespace policy file. An empty path
+// yields nil policies.
+func newNamespacePolicies(path string) (namespacePolicies, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read namespace policy file %q: %v", path, err)
+	}
+	policies := namespacePolicies{}
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("failed to parse namespace policy file %q: %v", path, err)
+	}
+	for namespace, policy := range policies {
+		if policy == nil {
+			return nil, fmt.Errorf("namespace policy file %q: namespace %q has no policy", path, namespace)
+		}
+		if p := policy.SeccompProfile; p != "" && p != "unconfined" && p != "docker/default" && !strings.HasPrefix(p, "localhost/") {
+			return nil, fmt.Errorf("namespace policy file %q: namespace %q has unknown seccomp profile %q", path, namespace, p)
+		}
+	}
+	return policies, nil
+}
+
+// get returns the policy of the namespace, or nil when it has none.
+func (p namespacePolicies) get(namespace string) *namespacePolicy {
+	if p == nil {
+		return nil
+	}
+	return p[namespace]
+}
+
+// sandboxImage returns the namespace's sandbox image, or "" when the
+// namespace keeps the node-level one.
+func (p namespacePolicies) sandboxImage(namespace string) string {
+	if policy := p.get(namespace); policy != nil {
+		return policy.SandboxImage
+	}
+	return ""
+}
+
+// seccompProfile returns the namespace's default seccomp profile, or ""
+// when the namespace keeps the node-level default.
+func (p namespacePolicies) seccompProfile(namespace string) string {
+	if policy := p.get(namespace); policy != nil {
+		return policy.SeccompProfile
+	}
+	return ""
+}
+
+// runtime returns the namespace's default OCI runtime, or "" when the
+// namespace keeps docker's default.
+func (p namespacePolicies) runtime(namespace string) string {
+	if policy := p.get(namespace); policy != nil {
+		return policy.Runtime
+	}
+	return ""
+}
+
+// validateSysctls rejects sysctls outside the namespace's allowlist. A
+// namespace without an allowlist may request any sysctl the node allows.
+func (p namespacePolicies) validateSysctls(namespace string, sysctls map[string]string) error {
+	policy := p.get(namespace)
+	if policy == nil || len(policy.AllowedSysctls) == 0 {
+		return nil
+	}
+	for name := range sysctls {
+		allowed := false
+		for _, pattern := range policy.AllowedSysctls {
+			if pattern == name || (strings.HasSuffix(pattern, "*") && strings.HasPrefix(name, strings.TrimSuffix(pattern, "*"))) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("sysctl %q is not allowed in namespace %q", name, namespace)
+		}
+	}
+	return nil
+}
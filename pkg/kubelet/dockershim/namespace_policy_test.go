@@ -0,0 +1,133 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeNamespacePolicyFile writes the content to a temporary policy file and
+// returns its path.
+func writeNamespacePolicyFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "namespace-policy")
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
+
+// TestNewNamespacePolicies tests the policy file loading and validation.
+func TestNewNamespacePolicies(t *testing.T) {
+	policies, err := newNamespacePolicies("")
+	require.NoError(t, err)
+	assert.Nil(t, policies)
+
+	_, err = newNamespacePolicies("/does/not/exist")
+	assert.Error(t, err)
+
+	path := writeNamespacePolicyFile(t, `{"tenant-a": {"sandboxImage": "registry.tenant-a/pause:3.0", "seccompProfile": "localhost/tenant-a.json", "allowedSysctls": ["net.ipv4.*", "kernel.shm_rmid_forced"], "runtime": "runsc"}}`)
+	defer os.Remove(path)
+	policies, err = newNamespacePolicies(path)
+	require.NoError(t, err)
+	assert.Equal(t, "registry.tenant-a/pause:3.0", policies.sandboxImage("tenant-a"))
+	assert.Equal(t, "localhost/tenant-a.json", policies.seccompProfile("tenant-a"))
+	assert.Equal(t, "runsc", policies.runtime("tenant-a"))
+	// A namespace absent from the file keeps the node-level defaults.
+	assert.Equal(t, "", policies.sandboxImage("other"))
+	assert.Equal(t, "", policies.seccompProfile("other"))
+	assert.Equal(t, "", policies.runtime("other"))
+
+	for _, content := range []string{
+		"not json",
+		`{"tenant-a": null}`,
+		`{"tenant-a": {"seccompProfile": "bogus"}}`,
+	} {
+		path := writeNamespacePolicyFile(t, content)
+		defer os.Remove(path)
+		_, err := newNamespacePolicies(path)
+		assert.Error(t, err, "content %q", content)
+	}
+}
+
+// TestNamespacePoliciesValidateSysctls tests the sysctl allowlist matching.
+func TestNamespacePoliciesValidateSysctls(t *testing.T) {
+	policies := namespacePolicies{
+		"tenant-a": {AllowedSysctls: []string{"net.ipv4.*", "kernel.shm_rmid_forced"}},
+		"tenant-b": {},
+	}
+	sysctls := map[string]string{"net.ipv4.ip_local_port_range": "1024 65535"}
+
+	assert.NoError(t, policies.validateSysctls("tenant-a", sysctls))
+	assert.NoError(t, policies.validateSysctls("tenant-a", map[string]string{"kernel.shm_rmid_forced": "1"}))
+	err := policies.validateSysctls("tenant-a", map[string]string{"kernel.msgmax": "65536"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kernel.msgmax")
+
+	// A namespace without an allowlist, an unlisted namespace and nil
+	// policies impose no limit.
+	assert.NoError(t, policies.validateSysctls("tenant-b", sysctls))
+	assert.NoError(t, policies.validateSysctls("other", sysctls))
+	var nilPolicies namespacePolicies
+	assert.NoError(t, nilPolicies.validateSysctls("tenant-a", sysctls))
+}
+
+// TestNamespacePolicyDefaults tests that the namespace policy steers the
+// sandbox image and OCI runtime, with pod annotations taking precedence.
+func TestNamespacePolicyDefaults(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	ds.namespacePolicies = namespacePolicies{
+		"bar": {SandboxImage: "registry.bar/pause:3.0", Runtime: "runsc"},
+	}
+	fakeDocker.Information = dockertypes.Info{
+		Runtimes: map[string]dockertypes.Runtime{
+			"runsc":        {Path: "/usr/bin/runsc"},
+			"kata-runtime": {Path: "/usr/bin/kata-runtime"},
+		},
+	}
+
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+	id, err := ds.RunPodSandbox(config)
+	require.NoError(t, err)
+	c, err := fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.Equal(t, "registry.bar/pause:3.0", c.Config.Image)
+	assert.Equal(t, "runsc", c.HostConfig.Runtime)
+
+	// The pod's own runtime annotation wins over the namespace default.
+	config = makeSandboxConfig("foo2", "bar", "2", 0)
+	config.Annotations = map[string]string{sandboxRuntimeAnnotationKey: "kata-runtime"}
+	id, err = ds.RunPodSandbox(config)
+	require.NoError(t, err)
+	c, err = fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.Equal(t, "kata-runtime", c.HostConfig.Runtime)
+
+	// A namespace without a policy keeps the node-level defaults.
+	id, err = ds.RunPodSandbox(makeSandboxConfig("foo3", "other", "3", 0))
+	require.NoError(t, err)
+	c, err = fakeDocker.InspectContainer(id)
+	require.NoError(t, err)
+	assert.Equal(t, defaultSandboxImage(), c.Config.Image)
+	assert.Equal(t, "", c.HostConfig.Runtime)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+)
+
+// UnsupportedNamespaceError reports a pod namespace option the node cannot
+// satisfy. It is returned instead of silently degrading, so the kubelet can
+// surface a clear pod event naming the option and the reason.
+type UnsupportedNamespaceError struct {
+	// Option names the namespace option, e.g. "shared PID".
+	Option string
+	// Reason explains what the daemon or node configuration is missing.
+	Reason string
+}
+
+func (e UnsupportedNamespaceError) Error() string {
+	return fmt.Sprintf("cannot satisfy the %s namespace option: %s", e.Option, e.Reason)
+}
+
+// IsUnsupportedNamespaceError checks whether the error is an
+// UnsupportedNamespaceError.
+func IsUnsupportedNamespaceError(err error) bool {
+	_, ok := err.(UnsupportedNamespaceError)
+	return ok
+}
+
+// verifySharedPIDSupport checks that the node can place a container in its
+// sandbox's PID namespace.
+func (ds *dockerService) verifySharedPIDSupport(apiVersion *semver.Version) error {
+	if !ds.featureGate.Enabled(sharedPIDNamespaceFeature) {
+		return UnsupportedNamespaceError{
+			Option: "shared PID",
+			Reason: fmt.Sprintf("the %s dockershim feature gate is disabled on this node", sharedPIDNamespaceFeature),
+		}
+	}
+	if apiVersion.LT(sharedPidNamespaceVersion) {
+		return UnsupportedNamespaceError{
+			Option: "shared PID",
+			Reason: fmt.Sprintf("docker API version %v does not support joining another container's PID namespace (requires %v)", apiVersion, sharedPidNamespaceVersion),
+		}
+	}
+	return nil
+}
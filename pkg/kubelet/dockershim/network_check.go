@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/metrics"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+)
+
+// networkCheckPeriod is how often live pod network attachments are verified.
+const networkCheckPeriod = 5 * time.Minute
+
+// networkCheckPlugin is implemented by network plugins that can verify an
+// existing pod network attachment (the CNI CHECK verb). Plugins advertise
+// the ability via NET_PLUGIN_CAPABILITY_CHECK.
+type networkCheckPlugin interface {
+	CheckPod(namespace, name string, id kubecontainer.ContainerID) error
+}
+
+// startNetworkChecker starts periodically verifying the network attachments
+// of ready sandboxes, when the plugin can check them. Pod networking can
+// break silently long after a successful setup (stale ARP entries, routes
+// removed behind the plugin's back); the periodic check surfaces the
+// breakage via events and metrics before users notice.
+func (ds *dockerService) startNetworkChecker() {
+	checker, ok := ds.networkPlugin.(networkCheckPlugin)
+	if !ok || !ds.networkPlugin.Capabilities().Has(network.NET_PLUGIN_CAPABILITY_CHECK) {
+		return
+	}
+	go wait.Forever(func() { ds.checkSandboxNetworks(checker) }, networkCheckPeriod)
+}
+
+// checkSandboxNetworks runs one verification pass over the ready sandboxes.
+func (ds *dockerService) checkSandboxNetworks(checker networkCheckPlugin) {
+	sandboxes, err := ds.ListPodSandbox(&runtimeapi.PodSandboxFilter{
+		State: &runtimeapi.PodSandboxStateValue{State: runtimeapi.PodSandboxState_SANDBOX_READY},
+	})
+	if err != nil {
+		glog.Warningf("Network check skipped: failed to list sandboxes: %v", err)
+		return
+	}
+	for _, s := range sandboxes {
+		// Host network sandboxes have no plugin-managed attachment to verify.
+		if checkpoint, err := ds.checkpointHandler.GetCheckpoint(s.Id); err == nil && checkpoint.Data != nil && checkpoint.Data.HostNetwork {
+			continue
+		}
+		m := s.GetMetadata()
+		cID := kubecontainer.BuildContainerID(runtimeName, s.Id)
+		if err := checker.CheckPod(m.Namespace, m.Name, cID); err != nil {
+			podFullName := fmt.Sprintf("%s/%s", m.Namespace, m.Name)
+			glog.Warningf("Network check of sandbox %q for pod %s failed: %v", s.Id, podFullName, err)
+			metrics.NetworkCheckFailures.WithLabelValues(podFullName).Inc()
+			// The kubelet turns the event into a pod event, so the breakage
+			// shows up on the pod instead of only in the node logs.
+			ds.events.publish(&runtimeapi.ContainerEvent{
+				ContainerId: s.Id,
+				EventType:   containerEventNetworkUnhealthy,
+				Timestamp:   time.Now().UnixNano(),
+			})
+		}
+	}
+}
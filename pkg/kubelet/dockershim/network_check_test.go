@@ -0,0 +1,93 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	utilsets "k8s.io/apimachinery/pkg/util/sets"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+)
+
+// fakeCheckablePlugin is a no-op plugin that records the pods whose
+// attachments were checked and fails them with a scripted error.
+type fakeCheckablePlugin struct {
+	network.NetworkPlugin
+	checkErr error
+	checked  []string
+}
+
+func newFakeCheckablePlugin() *fakeCheckablePlugin {
+	return &fakeCheckablePlugin{NetworkPlugin: &network.NoopNetworkPlugin{}}
+}
+
+func (p *fakeCheckablePlugin) Capabilities() utilsets.Int {
+	return utilsets.NewInt(network.NET_PLUGIN_CAPABILITY_CHECK)
+}
+
+func (p *fakeCheckablePlugin) CheckPod(namespace, name string, id kubecontainer.ContainerID) error {
+	p.checked = append(p.checked, fmt.Sprintf("%s/%s", namespace, name))
+	return p.checkErr
+}
+
+// TestCheckSandboxNetworks tests one verification pass over the sandboxes.
+func TestCheckSandboxNetworks(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	plugin := newFakeCheckablePlugin()
+	ds.networkPlugin = plugin
+
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.NoError(t, err)
+
+	events, cancel := ds.SubscribeContainerEvents()
+	defer cancel()
+
+	// A healthy attachment produces no event.
+	ds.checkSandboxNetworks(plugin)
+	assert.Equal(t, []string{"bar/foo"}, plugin.checked)
+	assert.Len(t, events, 0)
+
+	// A failing check is surfaced as an event on the sandbox.
+	plugin.checkErr = fmt.Errorf("eth0 lost its address")
+	ds.checkSandboxNetworks(plugin)
+	select {
+	case ev := <-events:
+		assert.Equal(t, id, ev.ContainerId)
+		assert.Equal(t, containerEventNetworkUnhealthy, ev.EventType)
+	default:
+		t.Fatalf("expected a %s event", containerEventNetworkUnhealthy)
+	}
+
+	// Host network sandboxes have no attachment and are skipped.
+	hostConfig := makeSandboxConfig("host", "bar", "2", 0)
+	hostConfig.Linux = &runtimeapi.LinuxPodSandboxConfig{
+		SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+			NamespaceOptions: &runtimeapi.NamespaceOption{HostNetwork: true},
+		},
+	}
+	_, err = ds.RunPodSandbox(hostConfig)
+	require.NoError(t, err)
+	plugin.checked = nil
+	ds.checkSandboxNetworks(plugin)
+	assert.Equal(t, []string{"bar/foo"}, plugin.checked)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+
+	"github.com/golang/glog"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+)
+
+// This file implements the best-effort basic isolation mode of the shim. For
+// clusters without a NetworkPolicy-capable CNI plugin, the shim can program
+// per-sandbox iptables rules after the network plugin has set the pod up,
+// dropping forwarded traffic to the pod unless it originates from one of the
+// allowed source CIDRs listed in the pod's annotations.
+//
+// This is explicitly NOT a NetworkPolicy implementation. The rules only
+// cover forwarded traffic, are lost if an external entity flushes the filter
+// table, and depend on whoever writes the annotations (e.g. a controller
+// resolving namespace allow lists to pod CIDRs) keeping them up to date.
+
+const (
+	// sandboxIsolationAnnotationKey is the annotation used to request basic
+	// isolation for a sandbox.
+	sandboxIsolationAnnotationKey = "network.alpha.kubernetes.io/isolation"
+	// sandboxIsolationModeBasic is the only supported isolation mode.
+	sandboxIsolationModeBasic = "basic"
+	// sandboxIsolationAllowedCIDRsAnnotationKey lists the source CIDRs
+	// (comma-separated) that are allowed to reach the sandbox.
+	sandboxIsolationAllowedCIDRsAnnotationKey = "network.alpha.kubernetes.io/allowed-source-cidrs"
+
+	// sandboxIsolationChain is the parent chain holding one jump rule per
+	// isolated sandbox.
+	sandboxIsolationChain utiliptables.Chain = "KUBE-SHIM-ISO"
+	// chainForward is the builtin FORWARD chain of the filter table.
+	chainForward utiliptables.Chain = "FORWARD"
+)
+
+// sandboxNeedsIsolation returns whether the annotations request basic
+// isolation for the sandbox.
+func sandboxNeedsIsolation(annotations map[string]string) bool {
+	return annotations[sandboxIsolationAnnotationKey] == sandboxIsolationModeBasic
+}
+
+// sandboxIsolationChainName returns the name of the per-sandbox chain. Chain
+// names are limited to 28 characters, so the sandbox ID is hashed.
+func sandboxIsolationChainName(podSandboxID string) utiliptables.Chain {
+	hash := fnv.New32a()
+	hash.Write([]byte(podSandboxID))
+	return utiliptables.Chain(fmt.Sprintf("%s-%08X", sandboxIsolationChain, hash.Sum32()))
+}
+
+// parseAllowedSourceCIDRs parses the allowed source CIDRs from the sandbox
+// annotations.
+func parseAllowedSourceCIDRs(annotations map[string]string) ([]string, error) {
+	value := annotations[sandboxIsolationAllowedCIDRsAnnotationKey]
+	if value == "" {
+		return nil, nil
+	}
+	var cidrs []string
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q in %s annotation: %v", cidr, sandboxIsolationAllowedCIDRsAnnotationKey, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+// setUpSandboxIsolation programs the iptables rules isolating the sandbox.
+// It must be called after the network plugin has set up the pod, since it
+// needs the pod IP.
+func (ds *dockerService) setUpSandboxIsolation(podSandboxID string, annotations map[string]string) error {
+	r, err := ds.client.InspectContainer(podSandboxID)
+	if err != nil {
+		return err
+	}
+	podIP, err := ds.getIP(r)
+	if err != nil {
+		return err
+	}
+	if podIP == "" {
+		return fmt.Errorf("no IP found for sandbox %q", podSandboxID)
+	}
+	cidrs, err := parseAllowedSourceCIDRs(annotations)
+	if err != nil {
+		return err
+	}
+
+	chain := sandboxIsolationChainName(podSandboxID)
+	if _, err := ds.iptables.EnsureChain(utiliptables.TableFilter, sandboxIsolationChain); err != nil {
+		return err
+	}
+	if _, err := ds.iptables.EnsureChain(utiliptables.TableFilter, chain); err != nil {
+		return err
+	}
+	// Rebuild the per-sandbox chain from scratch to drop stale rules from a
+	// previous attempt.
+	if err := ds.iptables.FlushChain(utiliptables.TableFilter, chain); err != nil {
+		return err
+	}
+	// Only traffic to the pod IP is subject to the sandbox's rules.
+	if _, err := ds.iptables.EnsureRule(utiliptables.Append, utiliptables.TableFilter, chain, "!", "-d", podIP, "-j", "RETURN"); err != nil {
+		return err
+	}
+	for _, cidr := range cidrs {
+		if _, err := ds.iptables.EnsureRule(utiliptables.Append, utiliptables.TableFilter, chain, "-s", cidr, "-j", "ACCEPT"); err != nil {
+			return err
+		}
+	}
+	if _, err := ds.iptables.EnsureRule(utiliptables.Append, utiliptables.TableFilter, chain,
+		"-m", "comment", "--comment", "dockershim basic isolation", "-j", "DROP"); err != nil {
+		return err
+	}
+	if _, err := ds.iptables.EnsureRule(utiliptables.Append, utiliptables.TableFilter, sandboxIsolationChain, "-j", string(chain)); err != nil {
+		return err
+	}
+	if _, err := ds.iptables.EnsureRule(utiliptables.Prepend, utiliptables.TableFilter, chainForward, "-j", string(sandboxIsolationChain)); err != nil {
+		return err
+	}
+	glog.V(2).Infof("Set up basic isolation for sandbox %q (allowed CIDRs: %v)", podSandboxID, cidrs)
+	return nil
+}
+
+// tearDownSandboxIsolation removes the iptables rules of the sandbox. Errors
+// for rules or chains that no longer exist are ignored.
+func (ds *dockerService) tearDownSandboxIsolation(podSandboxID string) error {
+	chain := sandboxIsolationChainName(podSandboxID)
+	errList := []error{}
+	if err := ds.iptables.DeleteRule(utiliptables.TableFilter, sandboxIsolationChain, "-j", string(chain)); err != nil {
+		errList = append(errList, err)
+	}
+	if err := ds.iptables.FlushChain(utiliptables.TableFilter, chain); err == nil {
+		if err := ds.iptables.DeleteChain(utiliptables.TableFilter, chain); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return utilerrors.NewAggregate(errList)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxNeedsIsolation(t *testing.T) {
+	assert.False(t, sandboxNeedsIsolation(nil))
+	assert.False(t, sandboxNeedsIsolation(map[string]string{"annotation": "abc"}))
+	assert.False(t, sandboxNeedsIsolation(map[string]string{sandboxIsolationAnnotationKey: "strict"}))
+	assert.True(t, sandboxNeedsIsolation(map[string]string{sandboxIsolationAnnotationKey: sandboxIsolationModeBasic}))
+}
+
+func TestParseAllowedSourceCIDRs(t *testing.T) {
+	tests := []struct {
+		annotations   map[string]string
+		expectedCIDRs []string
+		expectError   bool
+	}{{
+		annotations:   nil,
+		expectedCIDRs: nil,
+	}, {
+		annotations:   map[string]string{sandboxIsolationAllowedCIDRsAnnotationKey: "10.0.0.0/8"},
+		expectedCIDRs: []string{"10.0.0.0/8"},
+	}, {
+		annotations:   map[string]string{sandboxIsolationAllowedCIDRsAnnotationKey: "10.0.0.0/8, 192.168.1.0/24"},
+		expectedCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}, {
+		annotations: map[string]string{sandboxIsolationAllowedCIDRsAnnotationKey: "10.0.0.0/8,not-a-cidr"},
+		expectError: true,
+	}}
+
+	for i, test := range tests {
+		cidrs, err := parseAllowedSourceCIDRs(test.annotations)
+		if test.expectError {
+			assert.Error(t, err, "TestCase[%d]", i)
+			continue
+		}
+		assert.NoError(t, err, "TestCase[%d]", i)
+		assert.Equal(t, test.expectedCIDRs, cidrs, "TestCase[%d]", i)
+	}
+}
+
+func TestSandboxIsolationChainName(t *testing.T) {
+	chain := sandboxIsolationChainName("0123456789abcdef0123456789abcdef")
+	// iptables chain names are limited to 28 characters.
+	assert.True(t, len(chain) <= 28, "chain name %q is too long", chain)
+	// The name must be stable for the same sandbox and unique across sandboxes.
+	assert.Equal(t, chain, sandboxIsolationChainName("0123456789abcdef0123456789abcdef"))
+	assert.NotEqual(t, chain, sandboxIsolationChainName("anothersandboxid"))
+}
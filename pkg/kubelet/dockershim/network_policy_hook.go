@@ -0,0 +1,128 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+// NetworkPolicy enforcement normally trails pod startup: the enforcing agent
+// watches the API server and programs its rules some time after the pod's
+// containers are already running, leaving a window in which the pod is
+// reachable without policy. The network policy hook closes that window by
+// invoking the agent synchronously during sandbox setup, after the network
+// plugin attached the pod and before any workload container starts.
+//
+// The hook is configured as "<path>[,fail-open|fail-closed]" (via
+// --experimental-dockershim-network-policy-hook). The binary is invoked as
+// "<path> setup" with a JSON description of the pod on stdin. In fail-closed
+// mode a hook failure fails the sandbox; the default fail-open mode logs the
+// failure and lets the pod start without policy, trading the enforcement
+// guarantee for availability.
+
+// networkPolicyModes are the accepted failure modes of the hook.
+const (
+	networkPolicyFailOpen   = "fail-open"
+	networkPolicyFailClosed = "fail-closed"
+)
+
+// networkPolicyRequest is the JSON document handed to the hook binary.
+type networkPolicyRequest struct {
+	// PodSandboxID is the sandbox holding the pod's network namespace.
+	PodSandboxID string `json:"podSandboxID"`
+	// PodNamespace and PodName identify the pod.
+	PodNamespace string `json:"podNamespace"`
+	PodName      string `json:"podName"`
+	// Labels are the pod's labels, which NetworkPolicy selectors match on.
+	Labels map[string]string `json:"labels,omitempty"`
+	// NetNSPath is the stable path of the pod's network namespace.
+	NetNSPath string `json:"netnsPath,omitempty"`
+}
+
+// networkPolicyHook invokes the configured NetworkPolicy agent during
+// sandbox setup. Nil in most tests and when no hook is configured.
+type networkPolicyHook struct {
+	path       string
+	failClosed bool
+	exec       utilexec.Interface
+}
+
+// newNetworkPolicyHook parses the "<path>[,fail-open|fail-closed]" hook
+// configuration. An empty configuration yields a nil hook.
+func newNetworkPolicyHook(config string) (*networkPolicyHook, error) {
+	if config == "" {
+		return nil, nil
+	}
+	hook := &networkPolicyHook{exec: utilexec.New()}
+	parts := strings.Split(config, ",")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("malformed network policy hook %q, expect \"<path>[,fail-open|fail-closed]\"", config)
+	}
+	hook.path = strings.TrimSpace(parts[0])
+	if hook.path == "" {
+		return nil, fmt.Errorf("malformed network policy hook %q: empty path", config)
+	}
+	if len(parts) == 2 {
+		switch mode := strings.TrimSpace(parts[1]); mode {
+		case networkPolicyFailOpen:
+		case networkPolicyFailClosed:
+			hook.failClosed = true
+		default:
+			return nil, fmt.Errorf("unknown network policy hook mode %q, expect %q or %q", mode, networkPolicyFailOpen, networkPolicyFailClosed)
+		}
+	}
+	return hook, nil
+}
+
+// setup invokes the hook for a freshly networked sandbox. In fail-open mode
+// hook failures are logged and swallowed.
+func (h *networkPolicyHook) setup(config *runtimeapi.PodSandboxConfig, podSandboxID string) error {
+	if h == nil {
+		return nil
+	}
+	request := networkPolicyRequest{
+		PodSandboxID: podSandboxID,
+		PodNamespace: config.GetMetadata().Namespace,
+		PodName:      config.GetMetadata().Name,
+		Labels:       config.GetLabels(),
+		NetNSPath:    netnsPath(podSandboxID),
+	}
+	payload, err := json.Marshal(&request)
+	if err != nil {
+		return err
+	}
+	cmd := h.exec.Command(h.path, "setup")
+	cmd.SetStdin(bytes.NewReader(payload))
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("network policy hook %q failed for pod %s/%s: %v: %s", h.path, request.PodNamespace, request.PodName, err, out)
+	if h.failClosed {
+		return err
+	}
+	glog.Warningf("%v (fail-open: the pod starts without policy)", err)
+	return nil
+}
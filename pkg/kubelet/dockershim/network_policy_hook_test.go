@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+// TestNewNetworkPolicyHook tests the hook configuration parsing.
+func TestNewNetworkPolicyHook(t *testing.T) {
+	h, err := newNetworkPolicyHook("")
+	require.NoError(t, err)
+	assert.Nil(t, h)
+
+	h, err = newNetworkPolicyHook("/opt/bin/np-agent")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/bin/np-agent", h.path)
+	assert.False(t, h.failClosed)
+
+	h, err = newNetworkPolicyHook("/opt/bin/np-agent, fail-closed")
+	require.NoError(t, err)
+	assert.True(t, h.failClosed)
+
+	for _, config := range []string{",fail-closed", "/opt/bin/np-agent,maybe", "/a,fail-open,extra"} {
+		_, err = newNetworkPolicyHook(config)
+		assert.Error(t, err, "config %q", config)
+	}
+}
+
+// policyHookWithScript returns a hook whose invocations run the script.
+func policyHookWithScript(failClosed bool, script ...utilexec.FakeCombinedOutputAction) (*networkPolicyHook, *utilexec.FakeCmd, *utilexec.FakeExec) {
+	fcmd := &utilexec.FakeCmd{CombinedOutputScript: script}
+	actions := make([]utilexec.FakeCommandAction, len(script))
+	for i := range actions {
+		actions[i] = func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(fcmd, cmd, args...) }
+	}
+	fexec := &utilexec.FakeExec{CommandScript: actions}
+	return &networkPolicyHook{path: "/opt/bin/np-agent", failClosed: failClosed, exec: fexec}, fcmd, fexec
+}
+
+// TestNetworkPolicyHookSetup tests the hook invocation and failure modes.
+func TestNetworkPolicyHookSetup(t *testing.T) {
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+
+	h, fcmd, _ := policyHookWithScript(false,
+		func() ([]byte, error) { return nil, nil },
+		func() ([]byte, error) { return []byte("no such pod"), fmt.Errorf("exit status 1") },
+	)
+	require.NoError(t, h.setup(config, "sandboxid"))
+	assert.Equal(t, []string{"/opt/bin/np-agent", "setup"}, fcmd.CombinedOutputLog[0])
+
+	// In fail-open mode a hook failure lets the sandbox proceed.
+	assert.NoError(t, h.setup(config, "sandboxid"))
+
+	// In fail-closed mode the failure is surfaced.
+	h, _, _ = policyHookWithScript(true,
+		func() ([]byte, error) { return []byte("no such pod"), fmt.Errorf("exit status 1") },
+	)
+	assert.Error(t, h.setup(config, "sandboxid"))
+
+	// A nil hook is inert.
+	var nilHook *networkPolicyHook
+	assert.NoError(t, nilHook.setup(config, "sandboxid"))
+}
+
+// TestRunPodSandboxNetworkPolicyHook tests that a failing fail-closed hook
+// fails sandbox creation.
+func TestRunPodSandboxNetworkPolicyHook(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	hook, _, _ := policyHookWithScript(true,
+		func() ([]byte, error) { return []byte("agent unavailable"), fmt.Errorf("exit status 1") },
+	)
+	ds.networkPolicyHook = hook
+	_, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "network policy hook")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/clock"
+)
+
+const (
+	// networkSetupWindow is how far back setup outcomes count towards the
+	// network readiness condition.
+	networkSetupWindow = 5 * time.Minute
+	// networkSetupMinAttempts is the minimum number of recent setups before
+	// their failure rate can mark the network not ready; a single flake on
+	// an otherwise idle node should not flip the condition.
+	networkSetupMinAttempts = 3
+)
+
+// networkSetupMonitor tracks the outcome of recent network plugin setups so
+// the runtime status can report NetworkReady=false when pod networking is
+// consistently failing, letting the kubelet mark the node network
+// unavailable instead of failing pods one by one.
+type networkSetupMonitor struct {
+	sync.Mutex
+	// clock is left nil outside of tests and defaults to the real clock.
+	clock   clock.Clock
+	results []networkSetupResult
+}
+
+type networkSetupResult struct {
+	at      time.Time
+	success bool
+}
+
+func (m *networkSetupMonitor) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock.Now()
+}
+
+// record notes the outcome of one network plugin setup.
+func (m *networkSetupMonitor) record(success bool) {
+	m.Lock()
+	defer m.Unlock()
+	m.results = append(m.results, networkSetupResult{at: m.now(), success: success})
+	m.prune()
+}
+
+// prune drops outcomes older than the readiness window. Callers must hold
+// the lock.
+func (m *networkSetupMonitor) prune() {
+	cutoff := m.now().Add(-networkSetupWindow)
+	for len(m.results) > 0 && m.results[0].at.Before(cutoff) {
+		m.results = m.results[1:]
+	}
+}
+
+// status returns an error when the majority of recent network setups failed,
+// and nil while too few outcomes are recorded to judge.
+func (m *networkSetupMonitor) status() error {
+	m.Lock()
+	defer m.Unlock()
+	m.prune()
+	attempts := len(m.results)
+	if attempts < networkSetupMinAttempts {
+		return nil
+	}
+	failures := 0
+	for _, r := range m.results {
+		if !r.success {
+			failures++
+		}
+	}
+	if failures*2 > attempts {
+		return fmt.Errorf("%d of the last %d network setups failed", failures, attempts)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/clock"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+func TestNetworkSetupMonitor(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	m := &networkSetupMonitor{clock: fakeClock}
+
+	// Too few outcomes to judge, even if all of them failed.
+	m.record(false)
+	m.record(false)
+	assert.NoError(t, m.status())
+
+	// A majority of failures marks the network not ready.
+	m.record(false)
+	assert.Error(t, m.status())
+
+	// Successes restore the balance.
+	m.record(true)
+	m.record(true)
+	m.record(true)
+	assert.NoError(t, m.status())
+
+	// Outcomes age out of the window; fresh failures dominate again.
+	fakeClock.Step(networkSetupWindow + time.Second)
+	m.record(false)
+	m.record(false)
+	m.record(false)
+	assert.Error(t, m.status())
+}
+
+func TestStatusNetworkSetupFailing(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	for i := 0; i < networkSetupMinAttempts; i++ {
+		ds.netMonitor.record(false)
+	}
+
+	status, err := ds.Status()
+	assert.NoError(t, err)
+	for _, c := range status.GetConditions() {
+		if c.Type != runtimeapi.NetworkReady {
+			continue
+		}
+		assert.False(t, c.Status)
+		assert.Equal(t, "NetworkSetupFailing", c.Reason)
+	}
+}
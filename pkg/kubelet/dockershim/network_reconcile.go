@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"github.com/golang/glog"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+// reconcileNetworkResources tears down the networks of sandboxes that have a
+// checkpoint but no container. After a node crash docker may lose containers
+// the shim checkpointed, leaving their IPAM allocations and veth pairs
+// behind; the checkpoint is the only remaining record of the pod they
+// belonged to. Reconciliation is best-effort and runs once on startup.
+func (ds *dockerService) reconcileNetworkResources() {
+	live, err := ds.sandboxContainerIDs()
+	if err != nil {
+		glog.Errorf("Failed to reconcile sandbox network resources: %v", err)
+		return
+	}
+	ids, err := ds.checkpointHandler.ListCheckpoints()
+	if err != nil {
+		glog.Errorf("Failed to reconcile sandbox network resources: failed to list checkpoints: %v", err)
+		return
+	}
+	for _, id := range ids {
+		if live.Has(id) {
+			continue
+		}
+		checkpoint, err := ds.checkpointHandler.GetCheckpoint(id)
+		if err != nil {
+			// Without the pod's namespace and name the plugin cannot be
+			// invoked; leave the checkpoint to the garbage collector.
+			glog.Warningf("Failed to read checkpoint of removed sandbox %q: %v", id, err)
+			continue
+		}
+		glog.V(2).Infof("Tearing down network of removed sandbox %q for pod %s/%s", id, checkpoint.Namespace, checkpoint.Name)
+		cID := kubecontainer.BuildContainerID(runtimeName, id)
+		if err := ds.networkTearDownPod(checkpoint.Namespace, checkpoint.Name, cID); err != nil {
+			// Keep the checkpoint so another restart retries the teardown.
+			glog.Warningf("Failed to teardown network of removed sandbox %q, will retry: %v", id, err)
+			ds.teardownQueue.enqueue(checkpoint.Namespace, checkpoint.Name, id)
+			continue
+		}
+		if err := ds.checkpointHandler.RemoveCheckpoint(id); err != nil {
+			glog.Warningf("Failed to remove checkpoint of removed sandbox %q: %v", id, err)
+		}
+	}
+}
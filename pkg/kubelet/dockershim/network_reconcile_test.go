@@ -0,0 +1,78 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/errors"
+)
+
+// TestReconcileNetworkResources verifies that startup reconciliation tears
+// down the network of checkpoints without a sandbox container, and leaves
+// live sandboxes alone.
+func TestReconcileNetworkResources(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	mockPlugin := newTestNetworkPlugin(t)
+	ds.networkPlugin = mockPlugin
+	defer mockPlugin.Finish()
+
+	name := "foo0"
+	ns := "bar0"
+	c := makeSandboxConfig(name, ns, "0", 0)
+	cID := kubecontainer.ContainerID{Type: runtimeName, ID: fmt.Sprintf("/%v", makeSandboxName(c))}
+	mockPlugin.EXPECT().SetUpPod(ns, name, cID)
+
+	id, err := ds.RunPodSandbox(c)
+	assert.NoError(t, err)
+	assert.NoError(t, ds.checkpointHandler.CreateCheckpoint("removedsandbox", NewPodSandboxCheckpoint("gonens", "gone")))
+
+	orphanID := kubecontainer.BuildContainerID(runtimeName, "removedsandbox")
+	mockPlugin.EXPECT().TearDownPod("gonens", "gone", orphanID)
+
+	ds.reconcileNetworkResources()
+
+	// The orphaned checkpoint is gone, the live sandbox's remains.
+	_, err = ds.checkpointHandler.GetCheckpoint("removedsandbox")
+	assert.Equal(t, errors.CheckpointNotFoundError, err)
+	_, err = ds.checkpointHandler.GetCheckpoint(id)
+	assert.NoError(t, err)
+}
+
+// TestReconcileNetworkResourcesTeardownFailure verifies that a checkpoint is
+// kept for retry when the network teardown of its orphaned sandbox fails.
+func TestReconcileNetworkResourcesTeardownFailure(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	mockPlugin := newTestNetworkPlugin(t)
+	ds.networkPlugin = mockPlugin
+	defer mockPlugin.Finish()
+
+	assert.NoError(t, ds.checkpointHandler.CreateCheckpoint("removedsandbox", NewPodSandboxCheckpoint("gonens", "gone")))
+
+	orphanID := kubecontainer.BuildContainerID(runtimeName, "removedsandbox")
+	// The teardown queue retries asynchronously through the same plugin.
+	mockPlugin.EXPECT().TearDownPod("gonens", "gone", orphanID).Return(fmt.Errorf("plugin failure")).AnyTimes()
+
+	ds.reconcileNetworkResources()
+
+	_, err := ds.checkpointHandler.GetCheckpoint("removedsandbox")
+	assert.NoError(t, err)
+}
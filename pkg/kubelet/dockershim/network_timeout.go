@@ -0,0 +1,141 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// A hung CNI plugin would otherwise block RunPodSandbox (and the sandbox
+// teardown path) forever. Calls into the network plugin are bounded by a
+// configurable timeout; on expiry the plugin binary is killed and a typed
+// error is returned, so the kubelet fails the sandbox and retries with
+// backoff instead of wedging.
+
+// defaultNetworkCallTimeout bounds each call into the network plugin unless
+// the plugin settings override it.
+const defaultNetworkCallTimeout = 1 * time.Minute
+
+// NetworkSetupTimeoutError is returned when the network plugin does not
+// complete an operation within the configured timeout.
+type NetworkSetupTimeoutError struct {
+	// Operation is the plugin operation that timed out.
+	Operation string
+	// Timeout is the bound the operation exceeded.
+	Timeout time.Duration
+}
+
+func (e *NetworkSetupTimeoutError) Error() string {
+	return fmt.Sprintf("network plugin did not complete %s within %v", e.Operation, e.Timeout)
+}
+
+// IsNetworkSetupTimeoutError checks whether an error is a
+// NetworkSetupTimeoutError.
+func IsNetworkSetupTimeoutError(err error) bool {
+	_, ok := err.(*NetworkSetupTimeoutError)
+	return ok
+}
+
+// callPluginWithTimeout runs fn, bounded by the configured network call
+// timeout. On expiry any hung plugin binaries are killed and a typed
+// NetworkSetupTimeoutError is returned. The goroutine running fn drains on
+// its own once the killed plugin call returns.
+func (ds *dockerService) callPluginWithTimeout(operation string, fn func() error) error {
+	timeout := ds.networkCallTimeout
+	if timeout <= 0 {
+		return fn()
+	}
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		ds.killHungPluginProcesses()
+		return &NetworkSetupTimeoutError{Operation: operation, Timeout: timeout}
+	}
+}
+
+// killHungPluginProcesses kills still-running plugin binaries spawned from
+// the plugin bin dir. Plugin binaries are direct children of the kubelet
+// and share its process group, so each matching child is killed
+// individually instead of signalling the group.
+func (ds *dockerService) killHungPluginProcesses() {
+	if ds.pluginBinDir == "" {
+		return
+	}
+	pids, err := childPIDsOf(os.Getpid())
+	if err != nil {
+		glog.Warningf("Unable to enumerate child processes: %v", err)
+		return
+	}
+	prefix := ds.pluginBinDir + string(filepath.Separator)
+	for _, pid := range pids {
+		exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+		if err != nil || !strings.HasPrefix(exe, prefix) {
+			continue
+		}
+		glog.Warningf("Killing hung network plugin process %d (%s)", pid, exe)
+		if p, err := os.FindProcess(pid); err == nil {
+			if err := p.Kill(); err != nil {
+				glog.Warningf("Failed to kill network plugin process %d: %v", pid, err)
+			}
+		}
+	}
+}
+
+// childPIDsOf returns the PIDs of the direct children of the given process,
+// read from procfs.
+func childPIDsOf(ppid int) ([]int, error) {
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			continue
+		}
+		// The command name in the second stat field may contain spaces and
+		// parentheses; the ppid is the second field after the closing paren.
+		rest := string(stat)
+		if i := strings.LastIndex(rest, ")"); i >= 0 {
+			rest = rest[i+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			continue
+		}
+		if statPpid, err := strconv.Atoi(fields[1]); err == nil && statPpid == ppid {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
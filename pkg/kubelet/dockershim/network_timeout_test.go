@@ -0,0 +1,69 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallPluginWithTimeout(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.networkCallTimeout = 10 * time.Millisecond
+
+	// A call that never returns times out with the typed error.
+	block := make(chan struct{})
+	defer close(block)
+	err := ds.callPluginWithTimeout("network setup", func() error {
+		<-block
+		return nil
+	})
+	assert.Error(t, err)
+	assert.True(t, IsNetworkSetupTimeoutError(err))
+	timeoutErr := err.(*NetworkSetupTimeoutError)
+	assert.Equal(t, "network setup", timeoutErr.Operation)
+	assert.Equal(t, ds.networkCallTimeout, timeoutErr.Timeout)
+
+	// A call that finishes in time passes its result through unchanged.
+	pluginErr := errors.New("plugin failed")
+	assert.Equal(t, pluginErr, ds.callPluginWithTimeout("network setup", func() error { return pluginErr }))
+	assert.NoError(t, ds.callPluginWithTimeout("network setup", func() error { return nil }))
+}
+
+func TestCallPluginWithTimeoutDisabled(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.networkCallTimeout = -1
+
+	// With the bound disabled the call runs synchronously, however long it
+	// takes.
+	started := time.Now()
+	err := ds.callPluginWithTimeout("network setup", func() error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, time.Since(started) >= 20*time.Millisecond)
+}
+
+func TestIsNetworkSetupTimeoutError(t *testing.T) {
+	assert.True(t, IsNetworkSetupTimeoutError(&NetworkSetupTimeoutError{Operation: "network setup", Timeout: time.Minute}))
+	assert.False(t, IsNetworkSetupTimeoutError(errors.New("network plugin failed")))
+	assert.False(t, IsNetworkSetupTimeoutError(nil))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+const (
+	// sandboxRuntimeAnnotationKey is the annotation used to run the sandbox
+	// and its containers with an alternative OCI runtime (e.g. runsc or
+	// kata-runtime) registered in the docker daemon. An empty or absent
+	// value selects the daemon's default runtime.
+	sandboxRuntimeAnnotationKey = "runtime.alpha.kubernetes.io/oci-runtime"
+)
+
+// sandboxRuntime returns the OCI runtime requested by the sandbox
+// annotations, or the empty string for the daemon default.
+func sandboxRuntime(annotations map[string]string) string {
+	return annotations[sandboxRuntimeAnnotationKey]
+}
+
+// sandboxRuntimeFor returns the OCI runtime of the sandbox: the pod's
+// annotation wins, then the namespace policy's default, then the empty
+// string for the daemon default.
+func (ds *dockerService) sandboxRuntimeFor(c *runtimeapi.PodSandboxConfig) string {
+	if runtime := sandboxRuntime(c.GetAnnotations()); runtime != "" {
+		return runtime
+	}
+	return ds.namespacePolicies.runtime(c.GetMetadata().Namespace)
+}
+
+// validateSandboxRuntime checks that the requested OCI runtime is registered
+// in the docker daemon. Without the check, docker would accept the create
+// call and only fail at start time with a less actionable error.
+func (ds *dockerService) validateSandboxRuntime(runtime string) error {
+	info, err := ds.client.Info()
+	if err != nil {
+		return fmt.Errorf("failed to get docker info: %v", err)
+	}
+	if _, ok := info.Runtimes[runtime]; !ok {
+		return fmt.Errorf("OCI runtime %q is not registered in the docker daemon", runtime)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxRuntimeAnnotation(t *testing.T) {
+	assert.Equal(t, "", sandboxRuntime(nil))
+	assert.Equal(t, "", sandboxRuntime(map[string]string{"foo": "bar"}))
+	assert.Equal(t, "runsc", sandboxRuntime(map[string]string{sandboxRuntimeAnnotationKey: "runsc"}))
+}
+
+func TestMakeSandboxDockerConfigRuntime(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	fDocker.Information = dockertypes.Info{
+		Runtimes: map[string]dockertypes.Runtime{
+			"runc":  {Path: "/usr/bin/runc"},
+			"runsc": {Path: "/usr/bin/runsc"},
+		},
+	}
+
+	// Without the annotation the daemon default is used.
+	c := makeSandboxConfig("foo", "bar", "1", 0)
+	config, err := ds.makeSandboxDockerConfig(c, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Equal(t, "", config.HostConfig.Runtime)
+
+	// The annotation selects a registered runtime.
+	c = makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		nil, map[string]string{sandboxRuntimeAnnotationKey: "runsc"})
+	config, err = ds.makeSandboxDockerConfig(c, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Equal(t, "runsc", config.HostConfig.Runtime)
+
+	// Runtimes not registered in the daemon are rejected.
+	c = makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		nil, map[string]string{sandboxRuntimeAnnotationKey: "kata-runtime"})
+	_, err = ds.makeSandboxDockerConfig(c, defaultSandboxImage())
+	assert.Error(t, err)
+
+	// Daemons that do not report runtimes reject any selection.
+	fDocker.Information = dockertypes.Info{}
+	c = makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		nil, map[string]string{sandboxRuntimeAnnotationKey: "runsc"})
+	_, err = ds.makeSandboxDockerConfig(c, defaultSandboxImage())
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
+)
+
+// The names of the built-in OOM policies, selectable through the kubelet's
+// --experimental-dockershim-oom-policy flag.
+const (
+	oomPolicyDefault       = "default"
+	oomPolicyProtectSystem = "protect-system"
+	oomPolicyInvert        = "invert"
+)
+
+// systemNamespace holds the pods the protect-system policy shields from the
+// OOM killer.
+const systemNamespace = "kube-system"
+
+// oomPolicy decides the oom_score_adj the shim assigns to sandboxes and
+// containers. The kubelet selects an implementation by name, letting
+// operators deviate from the QoS-derived defaults on special-purpose nodes.
+type oomPolicy interface {
+	// sandboxOOMScoreAdj returns the score for the pod's sandbox.
+	sandboxOOMScoreAdj(config *runtimeapi.PodSandboxConfig) int
+	// containerOOMScoreAdj returns the score for a container. The QoS-derived
+	// value computed by the kubelet is available in the container config.
+	containerOOMScoreAdj(config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) int
+}
+
+// newOOMPolicy returns the policy registered under the given name; the empty
+// name selects the default policy.
+func newOOMPolicy(name string) (oomPolicy, error) {
+	switch name {
+	case "", oomPolicyDefault:
+		return defaultOOMPolicy{}, nil
+	case oomPolicyProtectSystem:
+		return protectSystemOOMPolicy{}, nil
+	case oomPolicyInvert:
+		return invertOOMPolicy{}, nil
+	}
+	return nil, fmt.Errorf("unknown OOM policy %q", name)
+}
+
+// defaultOOMPolicy keeps the historical behavior: sandboxes are nearly
+// unkillable and containers use the QoS-derived score as-is.
+type defaultOOMPolicy struct{}
+
+func (defaultOOMPolicy) sandboxOOMScoreAdj(*runtimeapi.PodSandboxConfig) int {
+	return qos.PodInfraOOMAdj
+}
+
+func (defaultOOMPolicy) containerOOMScoreAdj(config *runtimeapi.ContainerConfig, _ *runtimeapi.PodSandboxConfig) int {
+	if r := config.GetLinux().GetResources(); r != nil {
+		return int(r.OomScoreAdj)
+	}
+	return 0
+}
+
+// protectSystemOOMPolicy gives every container of a kube-system pod the same
+// protection as a sandbox, regardless of its QoS class, so node add-ons
+// survive memory pressure caused by workload pods.
+type protectSystemOOMPolicy struct {
+	defaultOOMPolicy
+}
+
+func (p protectSystemOOMPolicy) containerOOMScoreAdj(config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) int {
+	if sandboxConfig.GetMetadata() != nil && sandboxConfig.GetMetadata().Namespace == systemNamespace {
+		return qos.PodInfraOOMAdj
+	}
+	return p.defaultOOMPolicy.containerOOMScoreAdj(config, sandboxConfig)
+}
+
+// invertOOMPolicy negates the QoS-derived container score. On batch nodes
+// this makes the long-running service pods the preferred OOM victims while
+// best-effort batch work survives. Sandboxes keep their protection.
+type invertOOMPolicy struct {
+	defaultOOMPolicy
+}
+
+func (p invertOOMPolicy) containerOOMScoreAdj(config *runtimeapi.ContainerConfig, sandboxConfig *runtimeapi.PodSandboxConfig) int {
+	return -p.defaultOOMPolicy.containerOOMScoreAdj(config, sandboxConfig)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/qos"
+)
+
+func TestNewOOMPolicy(t *testing.T) {
+	// The empty name selects the default policy.
+	policy, err := newOOMPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, defaultOOMPolicy{}, policy)
+
+	for _, name := range []string{oomPolicyDefault, oomPolicyProtectSystem, oomPolicyInvert} {
+		_, err := newOOMPolicy(name)
+		assert.NoError(t, err, name)
+	}
+
+	_, err = newOOMPolicy("bogus")
+	assert.Error(t, err)
+}
+
+func TestOOMPolicies(t *testing.T) {
+	container := &runtimeapi.ContainerConfig{
+		Linux: &runtimeapi.LinuxContainerConfig{
+			Resources: &runtimeapi.LinuxContainerResources{OomScoreAdj: 900},
+		},
+	}
+	workload := &runtimeapi.PodSandboxConfig{
+		Metadata: &runtimeapi.PodSandboxMetadata{Name: "foo", Namespace: "default"},
+	}
+	system := &runtimeapi.PodSandboxConfig{
+		Metadata: &runtimeapi.PodSandboxMetadata{Name: "kube-dns", Namespace: "kube-system"},
+	}
+
+	assert.Equal(t, qos.PodInfraOOMAdj, defaultOOMPolicy{}.sandboxOOMScoreAdj(workload))
+	assert.Equal(t, 900, defaultOOMPolicy{}.containerOOMScoreAdj(container, workload))
+	// A container without resources defaults to a neutral score.
+	assert.Equal(t, 0, defaultOOMPolicy{}.containerOOMScoreAdj(&runtimeapi.ContainerConfig{}, workload))
+
+	// protect-system shields kube-system containers and leaves the rest alone.
+	assert.Equal(t, qos.PodInfraOOMAdj, protectSystemOOMPolicy{}.containerOOMScoreAdj(container, system))
+	assert.Equal(t, 900, protectSystemOOMPolicy{}.containerOOMScoreAdj(container, workload))
+
+	// invert negates the QoS-derived score but keeps sandboxes protected.
+	assert.Equal(t, -900, invertOOMPolicy{}.containerOOMScoreAdj(container, workload))
+	assert.Equal(t, qos.PodInfraOOMAdj, invertOOMPolicy{}.sandboxOOMScoreAdj(workload))
+}
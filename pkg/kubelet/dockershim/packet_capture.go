@@ -0,0 +1,147 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/util/ioutils"
+)
+
+// maxCaptureDuration bounds how long a packet capture may run. Captures
+// without a duration, or with one beyond the bound, are clamped to it so a
+// forgotten capture cannot run on a pod indefinitely.
+const maxCaptureDuration = 10 * time.Minute
+
+// CapturePackets attaches a packet capture to an interface in the sandbox's
+// network namespace and streams the raw pcap output to the stream, for live
+// network debugging without node SSH. The capture ends when the client
+// closes the stream or the (clamped) duration expires. An empty device
+// captures on the pod's default interface; filter is a tcpdump filter
+// expression. The capability is only available when the
+// ExperimentalSandboxPacketCapture feature gate is enabled.
+func (ds *dockerService) CapturePackets(podSandboxID, device, filter string, duration time.Duration, stream io.WriteCloser) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.ExperimentalSandboxPacketCapture) {
+		return fmt.Errorf("packet capture in sandbox %q is disabled; enable the %s feature gate to use it", podSandboxID, features.ExperimentalSandboxPacketCapture)
+	}
+	container, err := checkContainerStatus(ds.client, podSandboxID)
+	if err != nil {
+		return err
+	}
+	if container.Config == nil || container.Config.Labels[containerTypeLabelKey] != containerTypeLabelSandbox {
+		return fmt.Errorf("container %q is not a sandbox container", podSandboxID)
+	}
+	if device == "" {
+		device = network.DefaultInterfaceName
+	}
+	return capturePackets(container.State.Pid, device, filter, duration, stream)
+}
+
+// capturePacketsPath is the path of the node-local admin endpoint that
+// streams a packet capture from a pod's network namespace.
+const capturePacketsPath = "/capture-packets"
+
+// serveCapturePackets handles GET requests with podSandboxId, device,
+// filter and durationSeconds query parameters, streaming the raw pcap
+// output until the capture ends or the client disconnects.
+func (ds *dockerService) serveCapturePackets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	podSandboxID := query.Get("podSandboxId")
+	if podSandboxID == "" {
+		http.Error(w, "podSandboxId is required", http.StatusBadRequest)
+		return
+	}
+	var duration time.Duration
+	if value := query.Get("durationSeconds"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid durationSeconds %q: %v", value, err), http.StatusBadRequest)
+			return
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+	w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+	if err := ds.CapturePackets(podSandboxID, query.Get("device"), query.Get("filter"), duration, ioutils.WriteCloserWrapper(w)); err != nil {
+		glog.Errorf("Packet capture in sandbox %q failed: %v", podSandboxID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// capturePackets runs tcpdump in the sandbox's network namespace and relays
+// its pcap output to the stream.
+func capturePackets(containerPid int, device, filter string, duration time.Duration, stream io.WriteCloser) error {
+	tcpdumpPath, lookupErr := exec.LookPath("tcpdump")
+	if lookupErr != nil {
+		return fmt.Errorf("unable to capture packets: tcpdump not found.")
+	}
+	nsenterPath, lookupErr := exec.LookPath("nsenter")
+	if lookupErr != nil {
+		return fmt.Errorf("unable to capture packets: nsenter not found.")
+	}
+	if duration <= 0 || duration > maxCaptureDuration {
+		duration = maxCaptureDuration
+	}
+
+	// -U flushes each packet to the stream as it is captured, so the client
+	// sees traffic live rather than in buffer-sized bursts.
+	args := []string{"-t", fmt.Sprintf("%d", containerPid), "-n", tcpdumpPath, "-i", device, "-U", "-w", "-"}
+	args = append(args, strings.Fields(filter)...)
+	glog.V(4).Infof("executing packet capture command: %s %v", nsenterPath, args)
+
+	command := exec.Command(nsenterPath, args...)
+	stderr := new(bytes.Buffer)
+	command.Stderr = stderr
+	outPipe, err := command.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("unable to capture packets: error creating stdout pipe: %v", err)
+	}
+	if err := command.Start(); err != nil {
+		return fmt.Errorf("unable to capture packets: %v: %s", err, stderr.String())
+	}
+
+	// Stop the capture when the time box expires; the client closing the
+	// stream stops it earlier.
+	timer := time.AfterFunc(duration, func() { command.Process.Kill() })
+
+	_, copyErr := io.Copy(stream, outPipe)
+	if copyErr != nil {
+		// The client went away; there is nobody left to stream to.
+		command.Process.Kill()
+	}
+	err = command.Wait()
+	expired := !timer.Stop()
+	if err != nil && copyErr == nil && !expired {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
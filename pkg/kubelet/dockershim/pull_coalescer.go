@@ -0,0 +1,93 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockershim/metrics"
+)
+
+// When several pods that use the same image start at once (a daemonset
+// rollout, a node coming back after a reboot), the kubelet issues one
+// PullImage per pod and the daemon pulls the image once per request. The
+// daemon's layer store downloads each layer only once, but the redundant
+// pulls still cost registry round trips and manifest fetches, and they
+// compete for the pull QPS budget. The pullCoalescer deduplicates them: the
+// first pull of an image becomes the leader, later pulls of the same image
+// wait for the leader and share its result. Because the leader finishes
+// before any follower proceeds, shared base layers are in the layer store by
+// the time a follower's pod starts.
+
+// inflightPull is a pull in progress. Followers wait on done and then read
+// the leader's result.
+type inflightPull struct {
+	done chan struct{}
+	ref  string
+	err  error
+	// waiters counts the pulls coalesced onto this one, guarded by the
+	// coalescer lock.
+	waiters int
+}
+
+// pullCoalescer deduplicates concurrent pulls of the same image. It is keyed
+// on the image string as requested, so two spellings of the same image (tag
+// vs. digest) pull independently.
+type pullCoalescer struct {
+	lock     sync.Mutex
+	inflight map[string]*inflightPull
+}
+
+func newPullCoalescer() *pullCoalescer {
+	return &pullCoalescer{inflight: make(map[string]*inflightPull)}
+}
+
+// pull runs fn at most once per image among concurrent callers and returns
+// its result to all of them. A nil coalescer runs fn unconditionally.
+func (c *pullCoalescer) pull(image string, fn func() (string, error)) (string, error) {
+	if c == nil {
+		return fn()
+	}
+	c.lock.Lock()
+	if in, ok := c.inflight[image]; ok {
+		in.waiters++
+		c.lock.Unlock()
+		metrics.ImagePullsCoalesced.Inc()
+		start := time.Now()
+		<-in.done
+		metrics.ImagePullWaitLatency.Observe(metrics.SinceInSeconds(start))
+		return in.ref, in.err
+	}
+	in := &inflightPull{done: make(chan struct{})}
+	c.inflight[image] = in
+	c.lock.Unlock()
+
+	in.ref, in.err = fn()
+
+	c.lock.Lock()
+	delete(c.inflight, image)
+	waiters := in.waiters
+	c.lock.Unlock()
+	close(in.done)
+	if waiters > 0 {
+		glog.V(4).Infof("Pull of image %q was shared by %d coalesced pulls", image, waiters)
+	}
+	return in.ref, in.err
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// TestPullCoalescer tests that concurrent pulls of the same image are served
+// by a single pull while unrelated images proceed independently.
+func TestPullCoalescer(t *testing.T) {
+	c := newPullCoalescer()
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	refs := make(chan string, 3)
+	var wg sync.WaitGroup
+	pull := func() {
+		defer wg.Done()
+		ref, err := c.pull("busybox", func() (string, error) {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			<-release
+			return "sha256:abc", nil
+		})
+		assert.NoError(t, err)
+		refs <- ref
+	}
+
+	wg.Add(1)
+	go pull()
+	<-started
+
+	// Two more pulls of the same image join the one in flight.
+	wg.Add(2)
+	go pull()
+	go pull()
+	require.NoError(t, wait.Poll(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.inflight["busybox"].waiters == 2, nil
+	}))
+
+	// A pull of an unrelated image is not held up by the in-flight one.
+	ref, err := c.pull("nginx", func() (string, error) { return "sha256:other", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:other", ref)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "sha256:abc", <-refs)
+	}
+
+	// The in-flight entry is gone, so a later pull runs on its own.
+	ref, err = c.pull("busybox", func() (string, error) { return "sha256:new", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:new", ref)
+}
+
+// TestPullCoalescerError tests that followers share the leader's error and
+// that a nil coalescer runs every pull.
+func TestPullCoalescerError(t *testing.T) {
+	c := newPullCoalescer()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	errs := make(chan error, 2)
+	var wg sync.WaitGroup
+	pull := func() {
+		defer wg.Done()
+		_, err := c.pull("busybox", func() (string, error) {
+			close(started)
+			<-release
+			return "", fmt.Errorf("registry unavailable")
+		})
+		errs <- err
+	}
+
+	wg.Add(1)
+	go pull()
+	<-started
+	wg.Add(1)
+	go pull()
+	require.NoError(t, wait.Poll(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.inflight["busybox"].waiters == 1, nil
+	}))
+	close(release)
+	wg.Wait()
+	for i := 0; i < 2; i++ {
+		assert.EqualError(t, <-errs, "registry unavailable")
+	}
+
+	var nilCoalescer *pullCoalescer
+	ref, err := nilCoalescer.pull("busybox", func() (string, error) { return "sha256:abc", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", ref)
+}
@@ -40,10 +40,14 @@ type DockerService interface {
 type dockerService struct {
 	runtimeService internalapi.RuntimeService
 	imageService   internalapi.ImageManagerService
+	eventService   dockershim.ContainerEventService
+	listService    dockershim.PaginatedListService
+	imageFsService dockershim.ImageFsInfoService
+	statsService   dockershim.ContainerStatsService
 }
 
 func NewDockerService(s dockershim.DockerService) DockerService {
-	return &dockerService{runtimeService: s, imageService: s}
+	return &dockerService{runtimeService: s, imageService: s, eventService: s, listService: s, imageFsService: s, statsService: s}
 }
 
 func (d *dockerService) Version(ctx context.Context, r *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
@@ -58,6 +62,42 @@ func (d *dockerService) Status(ctx context.Context, r *runtimeapi.StatusRequest)
 	return &runtimeapi.StatusResponse{Status: status}, nil
 }
 
+func (d *dockerService) ContainerStats(ctx context.Context, r *runtimeapi.ContainerStatsRequest) (*runtimeapi.ContainerStatsResponse, error) {
+	stats, err := d.statsService.ContainerStats(r.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.ContainerStatsResponse{Stats: stats}, nil
+}
+
+func (d *dockerService) ListContainerStats(ctx context.Context, r *runtimeapi.ListContainerStatsRequest) (*runtimeapi.ListContainerStatsResponse, error) {
+	stats, err := d.statsService.ListContainerStats(r.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.ListContainerStatsResponse{Stats: stats}, nil
+}
+
+// GetContainerEvents streams container lifecycle events to the client until
+// it disconnects.
+func (d *dockerService) GetContainerEvents(r *runtimeapi.GetEventsRequest, stream runtimeapi.RuntimeService_GetContainerEventsServer) error {
+	events, cancel := d.eventService.SubscribeContainerEvents()
+	defer cancel()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (d *dockerService) RunPodSandbox(ctx context.Context, r *runtimeapi.RunPodSandboxRequest) (*runtimeapi.RunPodSandboxResponse, error) {
 	podSandboxId, err := d.runtimeService.RunPodSandbox(r.GetConfig())
 	if err != nil {
@@ -91,6 +131,13 @@ func (d *dockerService) PodSandboxStatus(ctx context.Context, r *runtimeapi.PodS
 }
 
 func (d *dockerService) ListPodSandbox(ctx context.Context, r *runtimeapi.ListPodSandboxRequest) (*runtimeapi.ListPodSandboxResponse, error) {
+	if r.Limit > 0 || r.Continue != "" {
+		items, next, err := d.listService.ListPodSandboxPaged(r.GetFilter(), r.Limit, r.Continue)
+		if err != nil {
+			return nil, err
+		}
+		return &runtimeapi.ListPodSandboxResponse{Items: items, Continue: next}, nil
+	}
 	items, err := d.runtimeService.ListPodSandbox(r.GetFilter())
 	if err != nil {
 		return nil, err
@@ -131,6 +178,13 @@ func (d *dockerService) RemoveContainer(ctx context.Context, r *runtimeapi.Remov
 }
 
 func (d *dockerService) ListContainers(ctx context.Context, r *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
+	if r.Limit > 0 || r.Continue != "" {
+		containers, next, err := d.listService.ListContainersPaged(r.GetFilter(), r.Limit, r.Continue)
+		if err != nil {
+			return nil, err
+		}
+		return &runtimeapi.ListContainersResponse{Containers: containers, Continue: next}, nil
+	}
 	containers, err := d.runtimeService.ListContainers(r.GetFilter())
 	if err != nil {
 		return nil, err
@@ -214,3 +268,11 @@ func (d *dockerService) RemoveImage(ctx context.Context, r *runtimeapi.RemoveIma
 	}
 	return &runtimeapi.RemoveImageResponse{}, nil
 }
+
+func (d *dockerService) ImageFsInfo(ctx context.Context, r *runtimeapi.ImageFsInfoRequest) (*runtimeapi.ImageFsInfoResponse, error) {
+	usage, err := d.imageFsService.ImageFsInfo()
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeapi.ImageFsInfoResponse{ImageFilesystem: usage}, nil
+}
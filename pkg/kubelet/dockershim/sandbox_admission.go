@@ -0,0 +1,89 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// Node-local admission runs compiled-in plugins over every sandbox config
+// before anything is created, the shim-side analogue of apiserver admission.
+// It covers policies that only the node can decide — e.g. enforcing cgroup
+// parent patterns of the local cgroup layout, or forbidding hostNetwork on
+// nodes tainted for untrusted workloads — without a round trip to an
+// external policy service. Plugins are registered at init() time via
+// registerSandboxAdmissionPlugin.
+
+// sandboxAdmissionPlugin can inspect and mutate a sandbox config before the
+// sandbox container is created, or reject the sandbox outright.
+type sandboxAdmissionPlugin interface {
+	// Name identifies the plugin in logs and rejection reasons.
+	Name() string
+	// Admit may mutate the config in place. Returning an error rejects the
+	// sandbox; the error becomes the Reason of a sandboxRejectedError.
+	Admit(config *runtimeapi.PodSandboxConfig) error
+}
+
+// sandboxRejectedError is the structured rejection returned when an
+// admission plugin refuses a sandbox, so callers can tell a policy decision
+// apart from a runtime failure.
+type sandboxRejectedError struct {
+	// Plugin is the name of the rejecting plugin.
+	Plugin string
+	// Pod is the "namespace/name" of the rejected pod.
+	Pod string
+	// Reason is the plugin's explanation of the rejection.
+	Reason string
+}
+
+func (e *sandboxRejectedError) Error() string {
+	return fmt.Sprintf("sandbox for pod %q rejected by admission plugin %q: %s", e.Pod, e.Plugin, e.Reason)
+}
+
+// isSandboxRejected returns whether the error is an admission rejection.
+func isSandboxRejected(err error) bool {
+	_, ok := err.(*sandboxRejectedError)
+	return ok
+}
+
+// defaultSandboxAdmissionPlugins holds the compiled-in admission plugins in
+// registration order.
+var defaultSandboxAdmissionPlugins []sandboxAdmissionPlugin
+
+// registerSandboxAdmissionPlugin registers a compiled-in admission plugin;
+// it is meant to be called from the plugin's init().
+func registerSandboxAdmissionPlugin(plugin sandboxAdmissionPlugin) {
+	defaultSandboxAdmissionPlugins = append(defaultSandboxAdmissionPlugins, plugin)
+}
+
+// admitSandbox runs the admission plugins over the sandbox config in
+// registration order, stopping at the first rejection. Mutations made by
+// earlier plugins are visible to later ones.
+func (ds *dockerService) admitSandbox(config *runtimeapi.PodSandboxConfig) error {
+	pod := fmt.Sprintf("%s/%s", config.GetMetadata().Namespace, config.GetMetadata().Name)
+	for _, plugin := range ds.sandboxAdmission {
+		if err := plugin.Admit(config); err != nil {
+			return &sandboxRejectedError{Plugin: plugin.Name(), Pod: pod, Reason: err.Error()}
+		}
+		glog.V(5).Infof("Sandbox admission plugin %q admitted pod %q", plugin.Name(), pod)
+	}
+	return nil
+}
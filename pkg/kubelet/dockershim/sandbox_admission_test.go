@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// fakeAdmissionPlugin admits or rejects by a fixed verdict and can mutate
+// the config's annotations.
+type fakeAdmissionPlugin struct {
+	name     string
+	annotate map[string]string
+	err      error
+	admitted []string
+}
+
+func (p *fakeAdmissionPlugin) Name() string { return p.name }
+
+func (p *fakeAdmissionPlugin) Admit(config *runtimeapi.PodSandboxConfig) error {
+	if p.err != nil {
+		return p.err
+	}
+	for k, v := range p.annotate {
+		if config.Annotations == nil {
+			config.Annotations = map[string]string{}
+		}
+		config.Annotations[k] = v
+	}
+	p.admitted = append(p.admitted, fmt.Sprintf("%s/%s", config.GetMetadata().Namespace, config.GetMetadata().Name))
+	return nil
+}
+
+// TestAdmitSandbox tests plugin ordering, mutation and structured rejection.
+func TestAdmitSandbox(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+
+	// No plugins admits everything.
+	require.NoError(t, ds.admitSandbox(config))
+
+	mutator := &fakeAdmissionPlugin{name: "mutator", annotate: map[string]string{"admitted": "true"}}
+	witness := &fakeAdmissionPlugin{name: "witness"}
+	ds.sandboxAdmission = []sandboxAdmissionPlugin{mutator, witness}
+	require.NoError(t, ds.admitSandbox(config))
+	// The mutation is applied and later plugins run after earlier ones.
+	assert.Equal(t, "true", config.Annotations["admitted"])
+	assert.Equal(t, []string{"bar/foo"}, witness.admitted)
+
+	rejector := &fakeAdmissionPlugin{name: "taint-guard", err: fmt.Errorf("hostNetwork is forbidden on this node")}
+	ds.sandboxAdmission = []sandboxAdmissionPlugin{rejector, witness}
+	err := ds.admitSandbox(config)
+	require.Error(t, err)
+	assert.True(t, isSandboxRejected(err))
+	rejection := err.(*sandboxRejectedError)
+	assert.Equal(t, "taint-guard", rejection.Plugin)
+	assert.Equal(t, "bar/foo", rejection.Pod)
+	assert.Equal(t, "hostNetwork is forbidden on this node", rejection.Reason)
+	// The rejection stops the chain.
+	assert.Len(t, witness.admitted, 1)
+}
+
+// TestRunPodSandboxAdmission tests that a rejection fails sandbox creation
+// before anything is created.
+func TestRunPodSandboxAdmission(t *testing.T) {
+	ds, fakeDocker, _ := newTestDockerService()
+	ds.sandboxAdmission = []sandboxAdmissionPlugin{
+		&fakeAdmissionPlugin{name: "taint-guard", err: fmt.Errorf("rejected")},
+	}
+	_, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.Error(t, err)
+	assert.True(t, isSandboxRejected(err))
+	containers, err := fakeDocker.ListContainers(dockertypes.ContainerListOptions{All: true})
+	require.NoError(t, err)
+	assert.Empty(t, containers)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/clock"
+)
+
+const (
+	// Annotations surfaced on the status of recreated sandboxes, so the
+	// kubelet can spot pods whose sandboxes crash loop and back off
+	// recreating them.
+	sandboxAttemptAnnotationKey   = "io.kubernetes.docker.sandbox-attempt"
+	sandboxCrashLoopAnnotationKey = "io.kubernetes.docker.sandbox-crash-loop"
+
+	// sandboxCrashLoopWindow is the sliding window over which sandbox
+	// recreations are counted.
+	sandboxCrashLoopWindow = 10 * time.Minute
+	// sandboxCrashLoopThreshold is the number of recreations within the
+	// window at which a pod's sandbox is considered to be crash looping.
+	sandboxCrashLoopThreshold = 5
+)
+
+// sandboxChurn counts sandbox recreations per pod over a sliding window.
+// A sandbox recreation (a RunPodSandbox with a non-zero attempt) means the
+// previous sandbox died or was evicted; a pod racking them up quickly is in
+// a crash loop. A nil *sandboxChurn counts nothing and never reports a
+// crash loop.
+type sandboxChurn struct {
+	lock  sync.Mutex
+	clock clock.Clock
+	// recreations maps the pod's namespace/name to the times its sandbox
+	// was recreated within the window.
+	recreations map[string][]time.Time
+}
+
+func newSandboxChurn(c clock.Clock) *sandboxChurn {
+	return &sandboxChurn{clock: c, recreations: map[string][]time.Time{}}
+}
+
+// recordRecreation records one sandbox recreation for the pod and returns
+// the number of recreations observed within the window.
+func (s *sandboxChurn) recordRecreation(pod string) int {
+	if s == nil {
+		return 0
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.recreations[pod] = append(s.pruned(pod), s.clock.Now())
+	return len(s.recreations[pod])
+}
+
+// inCrashLoop reports whether the pod's sandbox recreations within the
+// window have reached the crash loop threshold.
+func (s *sandboxChurn) inCrashLoop(pod string) bool {
+	if s == nil {
+		return false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	recent := s.pruned(pod)
+	if len(recent) == 0 {
+		delete(s.recreations, pod)
+		return false
+	}
+	s.recreations[pod] = recent
+	return len(recent) >= sandboxCrashLoopThreshold
+}
+
+// pruned returns the pod's recreations still within the window. The caller
+// must hold the lock.
+func (s *sandboxChurn) pruned(pod string) []time.Time {
+	var recent []time.Time
+	for _, t := range s.recreations[pod] {
+		if s.clock.Since(t) < sandboxCrashLoopWindow {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
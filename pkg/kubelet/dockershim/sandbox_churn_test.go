@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/clock"
+)
+
+// TestSandboxChurnCrashLoop tests that recreations are counted per pod over
+// the sliding window and the crash loop verdict flips at the threshold.
+func TestSandboxChurnCrashLoop(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	churn := newSandboxChurn(fakeClock)
+
+	pod := "bar/foo"
+	for i := 1; i < sandboxCrashLoopThreshold; i++ {
+		assert.Equal(t, i, churn.recordRecreation(pod))
+		assert.False(t, churn.inCrashLoop(pod))
+		fakeClock.Step(time.Minute)
+	}
+	assert.Equal(t, sandboxCrashLoopThreshold, churn.recordRecreation(pod))
+	assert.True(t, churn.inCrashLoop(pod))
+	// Other pods are unaffected.
+	assert.False(t, churn.inCrashLoop("bar/other"))
+
+	// Recreations age out of the window.
+	fakeClock.Step(sandboxCrashLoopWindow)
+	assert.False(t, churn.inCrashLoop(pod))
+	assert.Equal(t, 1, churn.recordRecreation(pod))
+}
+
+// TestSandboxChurnNil tests that a nil tracker counts nothing and never
+// reports a crash loop.
+func TestSandboxChurnNil(t *testing.T) {
+	var churn *sandboxChurn
+	assert.Equal(t, 0, churn.recordRecreation("bar/foo"))
+	assert.False(t, churn.inCrashLoop("bar/foo"))
+}
+
+// TestSandboxStatusChurnAnnotations tests that recreated sandboxes carry the
+// attempt annotation and crash-looping ones are flagged, while first-attempt
+// sandboxes carry neither (covered by TestSandboxStatus's exact match).
+func TestSandboxStatusChurnAnnotations(t *testing.T) {
+	ds, _, fClock := newTestDockerService()
+	ds.sandboxChurn = newSandboxChurn(fClock)
+
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 1))
+	require.NoError(t, err)
+	status, err := ds.PodSandboxStatus(id)
+	require.NoError(t, err)
+	assert.Equal(t, "1", status.Annotations[sandboxAttemptAnnotationKey])
+	assert.NotContains(t, status.Annotations, sandboxCrashLoopAnnotationKey)
+
+	// Crossing the crash loop threshold flags the sandbox status.
+	for i := 2; i <= sandboxCrashLoopThreshold; i++ {
+		id, err = ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", uint32(i)))
+		require.NoError(t, err)
+	}
+	status, err = ds.PodSandboxStatus(id)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(sandboxCrashLoopThreshold), status.Annotations[sandboxAttemptAnnotationKey])
+	assert.Equal(t, "true", status.Annotations[sandboxCrashLoopAnnotationKey])
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// The shim manages the pod's resolv.conf itself instead of passing the DNS
+// config to docker: docker caps search domains at six and rejects longer
+// lists, and for host-network sandboxes it ignores the settings entirely
+// (leaving pods that ask for cluster DNS via ClusterFirstWithHostNet on the
+// host's resolvers). The resolv.conf docker generated for the sandbox is
+// overwritten with the DNS config passed through CRI; workload containers
+// join the sandbox's network namespace and get the same file mounted at
+// /etc/resolv.conf, so the whole pod resolves through it.
+
+// applySandboxDNS overwrites the sandbox's resolv.conf with the given DNS
+// config.
+func (ds *dockerService) applySandboxDNS(podSandboxID string, dnsConfig *runtimeapi.DNSConfig) error {
+	r, err := ds.client.InspectContainer(podSandboxID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect sandbox %q: %v", podSandboxID, err)
+	}
+	if r.ResolvConfPath == "" {
+		return fmt.Errorf("sandbox %q has no resolv.conf path", podSandboxID)
+	}
+	return rewriteResolvFile(r.ResolvConfPath, dnsConfig.Servers, dnsConfig.Searches, dnsConfig.Options)
+}
+
+// rewriteResolvFile rewrites the resolv.conf file at the given path from the
+// given DNS servers, search domains and options. An empty DNS config leaves
+// the file untouched.
+func rewriteResolvFile(resolvFilePath string, dns []string, dnsSearch []string, dnsOptions []string) error {
+	var entries []string
+	for _, server := range dns {
+		entries = append(entries, "nameserver "+server)
+	}
+	if len(dnsSearch) > 0 {
+		entries = append(entries, "search "+strings.Join(dnsSearch, " "))
+	}
+	if len(dnsOptions) > 0 {
+		entries = append(entries, "options "+strings.Join(dnsOptions, " "))
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	content := strings.Join(entries, "\n") + "\n"
+	if err := ioutil.WriteFile(resolvFilePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %q: %v", resolvFilePath, err)
+	}
+	return nil
+}
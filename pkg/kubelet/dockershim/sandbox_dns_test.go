@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestRewriteResolvFile tests the rendering of the managed resolv.conf.
+func TestRewriteResolvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resolv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "resolv.conf")
+	require.NoError(t, ioutil.WriteFile(path, []byte("nameserver 8.8.8.8\n"), 0644))
+
+	require.NoError(t, rewriteResolvFile(path,
+		[]string{"10.0.0.10", "10.0.0.11"},
+		[]string{"default.svc.cluster.local", "svc.cluster.local"},
+		[]string{"ndots:5"}))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 10.0.0.10\nnameserver 10.0.0.11\n"+
+		"search default.svc.cluster.local svc.cluster.local\noptions ndots:5\n",
+		string(content))
+
+	// An empty DNS config leaves the file untouched.
+	require.NoError(t, rewriteResolvFile(path, nil, nil, nil))
+	content, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "10.0.0.10")
+}
+
+// TestSandboxDNSNotPassedToDocker tests that the DNS config is no longer
+// handed to docker, which caps search domains at six; the shim writes the
+// resolv.conf itself and can carry longer lists.
+func TestSandboxDNSNotPassedToDocker(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+	config.DnsConfig = &runtimeapi.DNSConfig{
+		Servers:  []string{"10.0.0.10"},
+		Searches: []string{"s1", "s2", "s3", "s4", "s5", "s6", "s7", "s8"},
+	}
+
+	createConfig, err := ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Empty(t, createConfig.HostConfig.DNS)
+	assert.Empty(t, createConfig.HostConfig.DNSSearch)
+	assert.Empty(t, createConfig.HostConfig.DNSOptions)
+
+	// The managed resolv.conf carries all eight search domains.
+	dir, err := ioutil.TempDir("", "resolv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "resolv.conf")
+	require.NoError(t, rewriteResolvFile(path, config.DnsConfig.Servers, config.DnsConfig.Searches, nil))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "search s1 s2 s3 s4 s5 s6 s7 s8\n")
+}
+
+// TestApplySandboxDNS tests that the DNS config is written to the sandbox's
+// docker-generated resolv.conf.
+func TestApplySandboxDNS(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	id, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "resolv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "resolv.conf")
+
+	dnsConfig := &runtimeapi.DNSConfig{Servers: []string{"10.0.0.10"}, Options: []string{"ndots:5"}}
+
+	// The fake client does not report a resolv.conf path.
+	assert.Error(t, ds.applySandboxDNS(id, dnsConfig))
+
+	// The fake returns a shared struct; point its resolv.conf at a real file.
+	c, err := fDocker.InspectContainer(id)
+	require.NoError(t, err)
+	c.ResolvConfPath = path
+
+	require.NoError(t, ds.applySandboxDNS(id, dnsConfig))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 10.0.0.10\noptions ndots:5\n", string(content))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+const (
+	// hostnameMaxLen is the RFC 1123 limit of a single DNS label, which is
+	// also what the kernel accepts as a hostname.
+	hostnameMaxLen = 63
+
+	// fqdnMaxLen is the kernel's limit on the hostname field (HOST_NAME_MAX
+	// is 64); a pod opting into an FQDN hostname must fit within it.
+	fqdnMaxLen = 64
+
+	// defaultClusterDomain is the pod FQDN suffix root used when the
+	// kubelet did not configure a cluster domain.
+	defaultClusterDomain = "cluster.local"
+
+	// sandboxSubdomainAnnotationKey carries the pod's subdomain (the pod
+	// spec's Subdomain, e.g. "my-svc"); the shim expands it to
+	// "<subdomain>.<namespace>.svc.<cluster-domain>". A value that already
+	// contains dots is taken as fully expanded. It is only consulted when
+	// the pod opts into an FQDN hostname.
+	sandboxSubdomainAnnotationKey = "hostname.alpha.kubernetes.io/subdomain"
+
+	// sandboxFQDNAnnotationKey opts the pod into using
+	// "<hostname>.<subdomain>" as the kernel hostname instead of the bare
+	// hostname, for workloads that require the FQDN from gethostname()
+	// (e.g. kerberized services).
+	sandboxFQDNAnnotationKey = "hostname.alpha.kubernetes.io/set-fqdn"
+)
+
+// truncateHostname deterministically truncates a hostname to the RFC 1123
+// label limit, trimming any dash or dot left dangling by the cut.
+func truncateHostname(hostname string) string {
+	if len(hostname) <= hostnameMaxLen {
+		return hostname
+	}
+	return strings.TrimRight(hostname[:hostnameMaxLen], "-.")
+}
+
+// sandboxFQDN expands the pod's subdomain to the pod FQDN suffix and
+// appends it to the hostname. A subdomain that already contains dots is
+// taken as fully expanded and used verbatim.
+func (ds *dockerService) sandboxFQDN(hostname, subdomain, namespace string) string {
+	if strings.Contains(subdomain, ".") {
+		return hostname + "." + subdomain
+	}
+	clusterDomain := ds.clusterDomain
+	if clusterDomain == "" {
+		clusterDomain = defaultClusterDomain
+	}
+	return fmt.Sprintf("%s.%s.%s.svc.%s", hostname, subdomain, namespace, clusterDomain)
+}
+
+// sandboxHostname computes the hostname for the sandbox. The configured
+// hostname is validated and truncated to the RFC 1123 label limit before it
+// is handed to docker, which would otherwise fail the create with an opaque
+// daemon error. Pods that opt in via the FQDN annotation get
+// "<hostname>.<subdomain>.<namespace>.svc.<cluster-domain>" instead; the
+// FQDN must fit the kernel's hostname field, so an oversized one is
+// rejected rather than truncated. An empty hostname is passed through and
+// leaves docker's default in place.
+func (ds *dockerService) sandboxHostname(c *runtimeapi.PodSandboxConfig) (string, error) {
+	hostname := c.Hostname
+	if hostname == "" {
+		return "", nil
+	}
+	if truncated := truncateHostname(hostname); truncated != hostname {
+		glog.Warningf("Hostname %q of sandbox for pod %q exceeds %d characters, truncated to %q", hostname, c.GetMetadata().Name, hostnameMaxLen, truncated)
+		hostname = truncated
+	}
+	if msgs := utilvalidation.IsDNS1123Label(hostname); len(msgs) > 0 {
+		return "", fmt.Errorf("hostname %q is not a valid RFC 1123 DNS label: %s", hostname, strings.Join(msgs, "; "))
+	}
+	if c.GetAnnotations()[sandboxFQDNAnnotationKey] != "true" {
+		return hostname, nil
+	}
+	subdomain := c.GetAnnotations()[sandboxSubdomainAnnotationKey]
+	if subdomain == "" {
+		return "", fmt.Errorf("the %s annotation requires a subdomain in the %s annotation", sandboxFQDNAnnotationKey, sandboxSubdomainAnnotationKey)
+	}
+	fqdn := ds.sandboxFQDN(hostname, subdomain, c.GetMetadata().Namespace)
+	if msgs := utilvalidation.IsDNS1123Subdomain(fqdn); len(msgs) > 0 {
+		return "", fmt.Errorf("FQDN %q is not a valid RFC 1123 DNS subdomain: %s", fqdn, strings.Join(msgs, "; "))
+	}
+	if len(fqdn) > fqdnMaxLen {
+		return "", fmt.Errorf("FQDN %q is longer than the %d characters the kernel hostname field holds", fqdn, fqdnMaxLen)
+	}
+	return fqdn, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSandboxHostname tests the hostname validation, truncation and FQDN
+// opt-in.
+func TestSandboxHostname(t *testing.T) {
+	longName := strings.Repeat("a", 60) + "-b-" + strings.Repeat("c", 20)
+	tests := []struct {
+		hostname    string
+		annotations map[string]string
+		expected    string
+		expectError bool
+	}{
+		// An empty hostname keeps docker's default.
+		{hostname: "", expected: ""},
+		{hostname: "foo-host", expected: "foo-host"},
+		// An over-long hostname is deterministically truncated, trimming
+		// the dash left dangling by the cut.
+		{hostname: longName, expected: strings.Repeat("a", 60) + "-b"},
+		{hostname: "Not_A_Label", expectError: true},
+		{hostname: "-leading-dash", expectError: true},
+		// The FQDN opt-in expands a bare subdomain with the pod's namespace
+		// and the cluster domain.
+		{
+			hostname:    "foo-host",
+			annotations: map[string]string{sandboxFQDNAnnotationKey: "true", sandboxSubdomainAnnotationKey: "websvc"},
+			expected:    "foo-host.websvc.bar.svc.cluster.local",
+		},
+		// A dotted subdomain is taken as fully expanded.
+		{
+			hostname:    "foo-host",
+			annotations: map[string]string{sandboxFQDNAnnotationKey: "true", sandboxSubdomainAnnotationKey: "svc.ns.svc.cluster.local"},
+			expected:    "foo-host.svc.ns.svc.cluster.local",
+		},
+		// The opt-in requires the subdomain annotation.
+		{
+			hostname:    "foo-host",
+			annotations: map[string]string{sandboxFQDNAnnotationKey: "true"},
+			expectError: true,
+		},
+		// An FQDN beyond the kernel limit is rejected, not truncated.
+		{
+			hostname:    "foo-host",
+			annotations: map[string]string{sandboxFQDNAnnotationKey: "true", sandboxSubdomainAnnotationKey: strings.Repeat("s", 50) + ".cluster.local"},
+			expectError: true,
+		},
+	}
+	ds, _, _ := newTestDockerService()
+	for i, test := range tests {
+		config := makeSandboxConfig("foo", "bar", "1", 0)
+		config.Hostname = test.hostname
+		config.Annotations = test.annotations
+		hostname, err := ds.sandboxHostname(config)
+		if test.expectError {
+			assert.Error(t, err, "case %d", i)
+			continue
+		}
+		require.NoError(t, err, "case %d", i)
+		assert.Equal(t, test.expected, hostname, "case %d", i)
+	}
+
+	// The kubelet's cluster domain is used when configured.
+	ds.clusterDomain = "example.org"
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+	config.Hostname = "foo-host"
+	config.Annotations = map[string]string{sandboxFQDNAnnotationKey: "true", sandboxSubdomainAnnotationKey: "websvc"}
+	hostname, err := ds.sandboxHostname(config)
+	require.NoError(t, err)
+	assert.Equal(t, "foo-host.websvc.bar.svc.example.org", hostname)
+}
+
+// TestMakeSandboxDockerConfigHostname tests that the validated hostname
+// reaches the docker config and an invalid one fails the sandbox up front.
+func TestMakeSandboxDockerConfigHostname(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	c := makeSandboxConfig("foo", "bar", "1", 0)
+	c.Hostname = strings.Repeat("h", 70)
+	config, err := ds.makeSandboxDockerConfig(c, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("h", 63), config.Config.Hostname)
+
+	c.Hostname = "in..valid"
+	_, err = ds.makeSandboxDockerConfig(c, defaultSandboxImage())
+	assert.Error(t, err)
+}
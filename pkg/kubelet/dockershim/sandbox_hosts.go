@@ -0,0 +1,130 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+)
+
+// The shim manages the pod's /etc/hosts file the same way it manages the
+// resolv.conf: the hosts file docker generated for the sandbox is rewritten
+// with the loopback entries, the pod's own IP and any requested host
+// aliases. Workload containers join the sandbox's network namespace and get
+// the same file mounted at /etc/hosts by docker, so the file is written
+// once per pod. It is rewritten whenever the sandbox's networking changes,
+// since the pod IP entry must follow the current IP.
+
+const (
+	// hostAliasesAnnotationKey carries additional hosts entries for the
+	// pod, semicolon separated, each entry an IP followed by one or more
+	// hostnames (e.g. "10.1.2.3 foo foo.local;10.1.2.4 bar").
+	hostAliasesAnnotationKey = "hosts.alpha.kubernetes.io/host-aliases"
+
+	// managedHostsHeader marks the hosts file as shim-managed.
+	managedHostsHeader = "# Kubernetes-managed hosts file.\n"
+)
+
+// hostAlias is one additional hosts entry requested via the host aliases
+// annotation.
+type hostAlias struct {
+	ip        string
+	hostnames []string
+}
+
+// parseHostAliases parses the host aliases annotation.
+func parseHostAliases(annotations map[string]string) ([]hostAlias, error) {
+	value, ok := annotations[hostAliasesAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var aliases []hostAlias
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Fields(entry)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("host alias entry %q must be an IP followed by at least one hostname", entry)
+		}
+		if net.ParseIP(fields[0]) == nil {
+			return nil, fmt.Errorf("host alias entry %q has an invalid IP", entry)
+		}
+		aliases = append(aliases, hostAlias{ip: fields[0], hostnames: fields[1:]})
+	}
+	return aliases, nil
+}
+
+// applySandboxHosts rewrites the sandbox's docker-generated hosts file with
+// the pod's current IP and the host aliases from its annotations. Sandboxes
+// sharing the host's network namespace keep the host's hosts file.
+func (ds *dockerService) applySandboxHosts(podSandboxID string) error {
+	r, err := ds.client.InspectContainer(podSandboxID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect sandbox %q: %v", podSandboxID, err)
+	}
+	if sharesHostNetwork(r) {
+		return nil
+	}
+	if r.HostsPath == "" {
+		return fmt.Errorf("sandbox %q has no hosts file path", podSandboxID)
+	}
+	_, annotations := extractLabels(r.Config.Labels)
+	aliases, err := parseHostAliases(annotations)
+	if err != nil {
+		return err
+	}
+	podIP, err := ds.getIP(r)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(managedHostsHeader)
+	buffer.WriteString("127.0.0.1\tlocalhost\n")
+	buffer.WriteString("::1\tlocalhost ip6-localhost ip6-loopback\n")
+	buffer.WriteString("fe00::0\tip6-localnet\n")
+	buffer.WriteString("fe00::0\tip6-mcastprefix\n")
+	buffer.WriteString("fe00::1\tip6-allnodes\n")
+	buffer.WriteString("fe00::2\tip6-allhosts\n")
+	if podIP != "" {
+		hostname := r.Config.Hostname
+		if hostname == "" {
+			if metadata, err := parseSandboxName(r.Name); err == nil {
+				hostname = metadata.Name
+			}
+		}
+		if dot := strings.Index(hostname, "."); dot > 0 {
+			// An FQDN hostname (see sandboxHostname) resolves both as the
+			// FQDN and as the bare hostname.
+			buffer.WriteString(fmt.Sprintf("%s\t%s %s\n", podIP, hostname, hostname[:dot]))
+		} else {
+			buffer.WriteString(fmt.Sprintf("%s\t%s\n", podIP, hostname))
+		}
+	}
+	for _, alias := range aliases {
+		buffer.WriteString(fmt.Sprintf("%s\t%s\n", alias.ip, strings.Join(alias.hostnames, " ")))
+	}
+	if err := ioutil.WriteFile(r.HostsPath, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %q: %v", r.HostsPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseHostAliases tests the host aliases annotation format.
+func TestParseHostAliases(t *testing.T) {
+	// No annotation means no aliases.
+	aliases, err := parseHostAliases(map[string]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, aliases)
+
+	aliases, err = parseHostAliases(map[string]string{
+		hostAliasesAnnotationKey: "10.1.2.3 foo foo.local; 10.1.2.4 bar",
+	})
+	assert.NoError(t, err)
+	require.Len(t, aliases, 2)
+	assert.Equal(t, hostAlias{ip: "10.1.2.3", hostnames: []string{"foo", "foo.local"}}, aliases[0])
+	assert.Equal(t, hostAlias{ip: "10.1.2.4", hostnames: []string{"bar"}}, aliases[1])
+
+	// Invalid entries are rejected.
+	for _, value := range []string{"not-an-ip foo", "10.1.2.3"} {
+		_, err = parseHostAliases(map[string]string{hostAliasesAnnotationKey: value})
+		assert.Error(t, err, "value %q", value)
+	}
+}
+
+// TestApplySandboxHosts tests that the managed hosts file carries the pod IP
+// and the requested host aliases.
+func TestApplySandboxHosts(t *testing.T) {
+	ds, fDocker, _ := newTestDockerService()
+	config := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{},
+		map[string]string{hostAliasesAnnotationKey: "10.1.2.3 db db.local"})
+	config.Hostname = "foo-host"
+	id, err := ds.RunPodSandbox(config)
+	require.NoError(t, err)
+
+	// The fake client does not report a hosts file path.
+	assert.Error(t, ds.applySandboxHosts(id))
+
+	dir, err := ioutil.TempDir("", "hosts")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "hosts")
+
+	// The fake returns a shared struct; point its hosts file at a real one.
+	c, err := fDocker.InspectContainer(id)
+	require.NoError(t, err)
+	c.HostsPath = path
+
+	require.NoError(t, ds.applySandboxHosts(id))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), managedHostsHeader)
+	assert.Contains(t, string(content), "127.0.0.1\tlocalhost\n")
+	// The pod IP entry uses the fake client's IP and the pod's hostname.
+	assert.Contains(t, string(content), "2.3.4.5\tfoo-host\n")
+	assert.Contains(t, string(content), "10.1.2.3\tdb db.local\n")
+}
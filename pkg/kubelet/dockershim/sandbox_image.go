@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"runtime"
+
+	"github.com/golang/glog"
+)
+
+const (
+	defaultSandboxImageName    = "gcr.io/google_containers/pause"
+	defaultSandboxImageVersion = "3.0"
+)
+
+// defaultSandboxImages maps node architectures (as reported by GOARCH, which
+// is also what the kubelet publishes in the beta.kubernetes.io/arch node
+// label) to the published pause image for that architecture.
+var defaultSandboxImages = map[string]string{
+	"amd64":   defaultSandboxImageName + "-amd64:" + defaultSandboxImageVersion,
+	"arm":     defaultSandboxImageName + "-arm:" + defaultSandboxImageVersion,
+	"arm64":   defaultSandboxImageName + "-arm64:" + defaultSandboxImageVersion,
+	"ppc64le": defaultSandboxImageName + "-ppc64le:" + defaultSandboxImageVersion,
+	"s390x":   defaultSandboxImageName + "-s390x:" + defaultSandboxImageVersion,
+}
+
+// sandboxImageForArch returns the pause image for the given architecture.
+// Architectures without a published pause image fall back to the amd64 one,
+// preserving the old hardcoded behavior.
+func sandboxImageForArch(arch string) string {
+	if image, ok := defaultSandboxImages[arch]; ok {
+		return image
+	}
+	glog.Warningf("No sandbox image is published for architecture %q; falling back to the amd64 image", arch)
+	return defaultSandboxImages["amd64"]
+}
+
+// defaultSandboxImage returns the pause image matching the node's
+// architecture, so arm64 or ppc64le nodes work without a manual
+// --pod-infra-container-image override.
+func defaultSandboxImage() string {
+	return sandboxImageForArch(runtime.GOARCH)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSandboxImageForArch tests the per-architecture pause image selection.
+func TestSandboxImageForArch(t *testing.T) {
+	assert.Equal(t, "gcr.io/google_containers/pause-amd64:3.0", sandboxImageForArch("amd64"))
+	assert.Equal(t, "gcr.io/google_containers/pause-arm64:3.0", sandboxImageForArch("arm64"))
+	assert.Equal(t, "gcr.io/google_containers/pause-ppc64le:3.0", sandboxImageForArch("ppc64le"))
+	// Unknown architectures fall back to the amd64 image.
+	assert.Equal(t, "gcr.io/google_containers/pause-amd64:3.0", sandboxImageForArch("mips64"))
+	// The default matches the build architecture.
+	assert.Equal(t, sandboxImageForArch(runtime.GOARCH), defaultSandboxImage())
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/golang/glog"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+// The pid-based /proc/<pid>/ns/net path of a sandbox becomes invalid the
+// moment the pause container exits, which used to leave network teardown
+// with no namespace to enter: CNI DEL was invoked with an empty netns and
+// plugins had to fall back to best-effort cleanup. To close that gap the
+// shim bind-mounts the sandbox's network namespace at a stable path when the
+// sandbox starts. The bind mount pins the namespace past the life of the
+// pause container, and the path is recorded in the sandbox checkpoint so it
+// is recoverable even when docker has forgotten the container.
+
+// netnsMountDir is the directory holding the per-sandbox netns bind mounts.
+const netnsMountDir = "/var/run/dockershim/netns"
+
+// netnsPath returns the stable path the sandbox's network namespace is
+// bind-mounted at.
+func netnsPath(podSandboxID string) string {
+	return filepath.Join(netnsMountDir, podSandboxID)
+}
+
+// netnsManager maintains the netns bind mounts. Nil in most tests, which
+// turns the mount maintenance into a no-op.
+type netnsManager struct {
+	exec utilexec.Interface
+	os   kubecontainer.OSInterface
+}
+
+func newNetnsManager(osInterface kubecontainer.OSInterface) *netnsManager {
+	return &netnsManager{exec: utilexec.New(), os: osInterface}
+}
+
+// bindMount mounts procNetNS, the sandbox's pid-based /proc/<pid>/ns/net
+// path, at the sandbox's stable netns path. The mount keeps the namespace
+// alive after the pause container exits.
+func (m *netnsManager) bindMount(podSandboxID, procNetNS string) error {
+	if m == nil {
+		return nil
+	}
+	if procNetNS == "" {
+		return fmt.Errorf("sandbox has no network namespace to mount")
+	}
+	if err := m.os.MkdirAll(netnsMountDir, 0700); err != nil {
+		return fmt.Errorf("failed to create %q: %v", netnsMountDir, err)
+	}
+	// The mount point must exist before a file can be bind-mounted onto it.
+	target := netnsPath(podSandboxID)
+	if f, err := m.os.Create(target); err != nil {
+		return fmt.Errorf("failed to create mount point %q: %v", target, err)
+	} else if f != nil {
+		f.Close()
+	}
+	if out, err := m.exec.Command("mount", "--bind", procNetNS, target).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to bind mount %q at %q: %v: %s", procNetNS, target, err, out)
+	}
+	return nil
+}
+
+// unmount releases the bind mount that pinned the sandbox's network
+// namespace and removes the mount point. It is best-effort: a sandbox whose
+// namespace was never mounted is left alone, and failures only cost the
+// namespace staying pinned until the node reboots.
+func (m *netnsManager) unmount(podSandboxID string) {
+	if m == nil {
+		return
+	}
+	target := netnsPath(podSandboxID)
+	if _, err := m.os.Stat(target); err != nil {
+		// Nothing was mounted for this sandbox.
+		return
+	}
+	if out, err := m.exec.Command("umount", target).CombinedOutput(); err != nil {
+		glog.Warningf("Failed to unmount network namespace of sandbox %q: %v: %s", podSandboxID, err, out)
+		return
+	}
+	if err := m.os.Remove(target); err != nil {
+		glog.Warningf("Failed to remove netns mount point of sandbox %q: %v", podSandboxID, err)
+	}
+}
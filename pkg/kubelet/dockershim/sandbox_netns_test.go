@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+// fakeNetnsManager returns a netnsManager backed by the given fakes.
+func fakeNetnsManager(fcmd *utilexec.FakeCmd, fos *containertest.FakeOS) (*netnsManager, *utilexec.FakeExec) {
+	fexec := &utilexec.FakeExec{CommandScript: []utilexec.FakeCommandAction{
+		func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(fcmd, cmd, args...) },
+		func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(fcmd, cmd, args...) },
+	}}
+	return &netnsManager{exec: fexec, os: fos}, fexec
+}
+
+// TestNetnsBindMount tests the mount invocation and its error cases.
+func TestNetnsBindMount(t *testing.T) {
+	fcmd := utilexec.FakeCmd{CombinedOutputScript: []utilexec.FakeCombinedOutputAction{
+		func() ([]byte, error) { return nil, nil },
+	}}
+	m, fexec := fakeNetnsManager(&fcmd, containertest.NewFakeOS())
+
+	require.NoError(t, m.bindMount("sandboxid", "/proc/1234/ns/net"))
+	require.Equal(t, 1, fexec.CommandCalls)
+	assert.Equal(t, []string{"mount", "--bind", "/proc/1234/ns/net", netnsPath("sandboxid")}, fcmd.CombinedOutputLog[0])
+
+	// A sandbox without a live namespace cannot be mounted.
+	assert.Error(t, m.bindMount("sandboxid", ""))
+
+	// A nil manager is inert.
+	var nilManager *netnsManager
+	assert.NoError(t, nilManager.bindMount("sandboxid", "/proc/1234/ns/net"))
+}
+
+// TestNetnsUnmount tests that unmount only acts on mounted namespaces.
+func TestNetnsUnmount(t *testing.T) {
+	fcmd := utilexec.FakeCmd{CombinedOutputScript: []utilexec.FakeCombinedOutputAction{
+		func() ([]byte, error) { return nil, nil },
+	}}
+	fos := containertest.NewFakeOS()
+	m, fexec := fakeNetnsManager(&fcmd, fos)
+
+	// Nothing is mounted for the sandbox, so nothing is unmounted.
+	m.unmount("sandboxid")
+	assert.Equal(t, 0, fexec.CommandCalls)
+
+	// With the mount point present, the namespace is unmounted and the
+	// mount point removed.
+	fos.StatFn = func(string) (os.FileInfo, error) { return nil, nil }
+	m.unmount("sandboxid")
+	require.Equal(t, 1, fexec.CommandCalls)
+	assert.Equal(t, []string{"umount", netnsPath("sandboxid")}, fcmd.CombinedOutputLog[0])
+	assert.Equal(t, []string{netnsPath("sandboxid")}, fos.Removes)
+}
+
+// TestGetNetNSStablePath tests that GetNetNS prefers the bind-mounted path
+// and falls back to the checkpointed one for vanished sandboxes.
+func TestGetNetNSStablePath(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	// With the bind mount in place the stable path wins, whatever state the
+	// sandbox container is in.
+	ds.netns = &netnsManager{os: ds.os}
+	ds.os.(*containertest.FakeOS).StatFn = func(string) (os.FileInfo, error) { return nil, nil }
+	path, err := ds.GetNetNS("sandboxid")
+	require.NoError(t, err)
+	assert.Equal(t, netnsPath("sandboxid"), path)
+
+	// Without the mount, a vanished sandbox falls back to the path recorded
+	// in its checkpoint.
+	ds.netns = nil
+	checkpoint := NewPodSandboxCheckpoint("ns", "name")
+	checkpoint.Data.NetNSPath = netnsPath("sandboxid")
+	require.NoError(t, ds.checkpointHandler.CreateCheckpoint("sandboxid", checkpoint))
+	path, err = ds.GetNetNS("sandboxid")
+	require.NoError(t, err)
+	assert.Equal(t, netnsPath("sandboxid"), path)
+
+	// With neither, the inspect error surfaces as before.
+	require.NoError(t, ds.checkpointHandler.RemoveCheckpoint("sandboxid"))
+	_, err = ds.GetNetNS("sandboxid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSandboxOverhead tests that the overhead aggregation counts the ready
+// sandboxes. The cpu and memory sums are zero in tests because the fake
+// sandboxes have no cgroups to read from.
+func TestSandboxOverhead(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	count, cpu, memory := ds.sandboxOverhead()
+	assert.Equal(t, 0, count)
+
+	_, err := ds.RunPodSandbox(makeSandboxConfig("foo", "bar", "1", 0))
+	require.NoError(t, err)
+	id, err := ds.RunPodSandbox(makeSandboxConfig("baz", "bar", "2", 0))
+	require.NoError(t, err)
+
+	count, cpu, memory = ds.sandboxOverhead()
+	assert.Equal(t, 2, count)
+	assert.EqualValues(t, 0, cpu)
+	assert.EqualValues(t, 0, memory)
+
+	// Stopped sandboxes no longer contribute overhead.
+	require.NoError(t, ds.StopPodSandbox(id))
+	count, _, _ = ds.sandboxOverhead()
+	assert.Equal(t, 1, count)
+}
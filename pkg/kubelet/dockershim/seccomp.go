@@ -0,0 +1,199 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// seccompProfile is a localhost seccomp profile loaded from the seccomp
+// profile root.
+type seccompProfile struct {
+	// json is the compacted profile content passed to docker.
+	json string
+	// msg identifies the profile (name and md5sum) in logs.
+	msg string
+}
+
+// seccompProfileCache loads localhost/ seccomp profiles from the seccomp
+// profile root and caches the compacted results. When the profile root can be
+// watched with inotify, cached entries are invalidated as soon as the profile
+// files change on disk, so profile updates are picked up without restarting
+// the kubelet. Without a watcher nothing is cached and profiles are read from
+// disk on every request.
+type seccompProfileCache struct {
+	// root is the directory that holds localhost/ seccomp profiles.
+	root string
+
+	sync.RWMutex
+	// profiles maps profile names (the part after "localhost/") to their
+	// loaded content. The map is nil until the watcher is running; without
+	// the watcher we cannot tell when entries go stale.
+	profiles map[string]*seccompProfile
+	watcher  *fsnotify.Watcher
+}
+
+func newSeccompProfileCache(root string) *seccompProfileCache {
+	return &seccompProfileCache{root: root}
+}
+
+// start establishes the inotify watcher on the profile root and enables
+// caching. A missing profile root is not an error; the cache simply stays
+// disabled until the kubelet is restarted with an existing root.
+func (c *seccompProfileCache) start() error {
+	if c.root == "" {
+		return nil
+	}
+	if _, err := os.Stat(c.root); os.IsNotExist(err) {
+		glog.V(4).Infof("Seccomp profile root %q does not exist; not caching seccomp profiles", c.root)
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create seccomp profile watcher: %v", err)
+	}
+	if err := watcher.Add(c.root); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch seccomp profile root %q: %v", c.root, err)
+	}
+	c.Lock()
+	c.watcher = watcher
+	c.profiles = make(map[string]*seccompProfile)
+	c.Unlock()
+	go c.watch()
+	return nil
+}
+
+// watch invalidates cached profiles as their files change on disk.
+func (c *seccompProfileCache) watch() {
+	for {
+		select {
+		case event, ok := <-c.watcher.Events:
+			if !ok {
+				return
+			}
+			c.invalidate(event.Name)
+		case err, ok := <-c.watcher.Errors:
+			if !ok {
+				return
+			}
+			glog.Warningf("Seccomp profile watcher error: %v", err)
+		}
+	}
+}
+
+// invalidate drops the cache entry for the changed file. If the path cannot
+// be mapped back to a profile name, the whole cache is flushed.
+func (c *seccompProfileCache) invalidate(path string) {
+	name, err := filepath.Rel(c.root, path)
+	c.Lock()
+	defer c.Unlock()
+	if err != nil {
+		c.profiles = make(map[string]*seccompProfile)
+		return
+	}
+	delete(c.profiles, filepath.ToSlash(name))
+}
+
+// getProfile returns the profile content for the given localhost profile
+// name, loading and caching it on first use.
+func (c *seccompProfileCache) getProfile(name string) (*seccompProfile, error) {
+	c.RLock()
+	profile, ok := c.profiles[name]
+	c.RUnlock()
+	if ok {
+		return profile, nil
+	}
+
+	// By pod annotation validation, name is a valid subpath.
+	fname := filepath.Join(c.root, filepath.FromSlash(name))
+	file, err := ioutil.ReadFile(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("seccomp profile %q (referenced as %q) does not exist under the seccomp profile root %q", name, "localhost/"+name, c.root)
+		}
+		return nil, fmt.Errorf("cannot load seccomp profile %q: %v", name, err)
+	}
+	b := bytes.NewBuffer(nil)
+	if err := json.Compact(b, file); err != nil {
+		return nil, fmt.Errorf("cannot parse seccomp profile %q: %v", name, err)
+	}
+	// Rather than the full profile, just put the filename & md5sum in the log.
+	profile = &seccompProfile{
+		json: b.String(),
+		msg:  fmt.Sprintf("%s(md5:%x)", name, md5.Sum(file)),
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.profiles != nil {
+		c.profiles[name] = profile
+	}
+	return profile, nil
+}
+
+// getSeccompSecurityOpts resolves the seccomp annotations for the given
+// container to formatted docker security options. The resolution order
+// mirrors dockertools.GetSeccompOpts, but localhost/ profiles are read
+// through the cache. defaultProfile is the profile of containers that do not
+// choose one through annotations; "" selects the node-wide default.
+func (c *seccompProfileCache) getSeccompSecurityOpts(annotations map[string]string, ctrName string, separator rune, defaultProfile string) ([]string, error) {
+	profile, profileOK := annotations[v1.SeccompContainerAnnotationKeyPrefix+ctrName]
+	if !profileOK {
+		// try the pod profile
+		profile, profileOK = annotations[v1.SeccompPodAnnotationKey]
+		if !profileOK {
+			if defaultProfile != "" {
+				profile = defaultProfile
+			} else {
+				profile = defaultSeccompProfile
+			}
+		}
+	}
+
+	if profile == "unconfined" {
+		return []string{fmt.Sprintf("seccomp%c%s", separator, "unconfined")}, nil
+	}
+
+	if profile == "docker/default" {
+		// return nil so docker will load the default seccomp profile
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(profile, "localhost/") {
+		return nil, fmt.Errorf("unknown seccomp profile option: %s", profile)
+	}
+
+	p, err := c.getProfile(strings.TrimPrefix(profile, "localhost/"))
+	if err != nil {
+		return nil, err
+	}
+	glog.V(4).Infof("Using seccomp profile %s for container %q", p.msg, ctrName)
+	return []string{fmt.Sprintf("seccomp%c%s", separator, p.json)}, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// TestSeccompProfileCache tests that localhost/ profiles are loaded through
+// the cache and that on-disk updates are picked up by the watcher.
+func TestSeccompProfileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seccomp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	profilePath := filepath.Join(dir, "test.json")
+	require.NoError(t, ioutil.WriteFile(profilePath, []byte(`{"defaultAction": "SCMP_ACT_ALLOW"}`), 0644))
+
+	c := newSeccompProfileCache(dir)
+	require.NoError(t, c.start())
+
+	annotations := map[string]string{v1.SeccompPodAnnotationKey: "localhost/test.json"}
+	opts, err := c.getSeccompSecurityOpts(annotations, "container", '=', "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`seccomp={"defaultAction":"SCMP_ACT_ALLOW"}`}, opts)
+
+	// An updated profile must be served once the watcher sees the change.
+	require.NoError(t, ioutil.WriteFile(profilePath, []byte(`{"defaultAction": "SCMP_ACT_ERRNO"}`), 0644))
+	err = wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		opts, err := c.getSeccompSecurityOpts(annotations, "container", '=', "")
+		if err != nil {
+			return false, err
+		}
+		return opts[0] == `seccomp={"defaultAction":"SCMP_ACT_ERRNO"}`, nil
+	})
+	assert.NoError(t, err, "expected the updated profile to be picked up")
+}
+
+// TestSeccompProfileCacheMissingProfile tests that a missing localhost/
+// profile produces an error naming the profile and the profile root.
+func TestSeccompProfileCacheMissingProfile(t *testing.T) {
+	c := newSeccompProfileCache("/nonexistent/seccomp/root")
+	_, err := c.getSeccompSecurityOpts(map[string]string{v1.SeccompPodAnnotationKey: "localhost/missing.json"}, "container", '=', "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "localhost/missing.json")
+	assert.Contains(t, err.Error(), "/nonexistent/seccomp/root")
+}
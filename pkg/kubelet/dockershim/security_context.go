@@ -19,6 +19,7 @@ package dockershim
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	dockercontainer "github.com/docker/engine-api/types/container"
 
@@ -51,6 +52,42 @@ func applySandboxSecurityContext(lc *runtimeapi.LinuxPodSandboxConfig, config *d
 
 }
 
+// sandboxCapabilitiesAnnotation lists the capabilities to grant the sandbox
+// container, comma separated (e.g. "NET_ADMIN,NET_RAW"). CNI plugins that
+// configure routes from inside the netns need them on the pause container,
+// which otherwise would have to run privileged.
+const sandboxCapabilitiesAnnotation = "security.alpha.kubernetes.io/sandbox-capabilities"
+
+// allowedSandboxCapabilities is the set of capabilities the annotation may
+// grant. Only the network-related ones are allowed; anything beyond that is
+// what privileged mode is for.
+var allowedSandboxCapabilities = map[string]bool{
+	"NET_ADMIN":        true,
+	"NET_RAW":          true,
+	"NET_BIND_SERVICE": true,
+}
+
+// sandboxCapabilities parses the sandbox capabilities annotation and rejects
+// capabilities outside the allowed set.
+func sandboxCapabilities(annotations map[string]string) ([]string, error) {
+	value, ok := annotations[sandboxCapabilitiesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var capabilities []string
+	for _, capability := range strings.Split(value, ",") {
+		capability = strings.TrimSpace(capability)
+		if capability == "" {
+			continue
+		}
+		if !allowedSandboxCapabilities[capability] {
+			return nil, fmt.Errorf("capability %q may not be granted to a sandbox", capability)
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, nil
+}
+
 // applyContainerSecurityContext updates docker container options according to security context.
 func applyContainerSecurityContext(lc *runtimeapi.LinuxContainerConfig, sandboxID string, config *dockercontainer.Config, hc *dockercontainer.HostConfig, separator rune) {
 	if lc == nil {
@@ -358,3 +358,24 @@ func fullValidHostConfig() *dockercontainer.HostConfig {
 		},
 	}
 }
+
+func TestSandboxCapabilities(t *testing.T) {
+	// Without the annotation no capabilities are granted.
+	capabilities, err := sandboxCapabilities(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, capabilities)
+
+	// Network capabilities are granted; whitespace and empty entries are
+	// tolerated.
+	capabilities, err = sandboxCapabilities(map[string]string{
+		sandboxCapabilitiesAnnotation: "NET_ADMIN, NET_RAW,",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NET_ADMIN", "NET_RAW"}, capabilities)
+
+	// Capabilities outside the allowed set are rejected.
+	_, err = sandboxCapabilities(map[string]string{
+		sandboxCapabilitiesAnnotation: "NET_ADMIN,SYS_ADMIN",
+	})
+	assert.Error(t, err)
+}
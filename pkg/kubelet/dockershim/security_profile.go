@@ -0,0 +1,117 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+)
+
+// This file implements the shim's default security profile: a set of
+// hardening defaults applied to sandboxes and containers on top of docker's
+// own defaults. The profile is selected cluster-wide through the kubelet
+// configuration (like the OOM policy) and individual pods override it
+// through their security context: privileged pods are exempt entirely, and
+// a pod that specifies its own capabilities overrides the default drop
+// list.
+
+const (
+	// securityProfileDefault leaves docker's defaults untouched. This is
+	// the shim's historical behavior.
+	securityProfileDefault = "default"
+	// securityProfileHardened denies privilege escalation via
+	// no-new-privileges, drops rarely needed capabilities from docker's
+	// default set and masks additional /proc paths.
+	securityProfileHardened = "hardened"
+)
+
+// hardenedDroppedCapabilities lists the capabilities the hardened profile
+// removes from docker's default set. They are granted by docker but rarely
+// used by well-behaved workloads; a pod that needs one adds it back through
+// its security context, which takes precedence.
+var hardenedDroppedCapabilities = []string{
+	"AUDIT_WRITE",
+	"MKNOD",
+	"NET_RAW",
+	"SETFCAP",
+	"SYS_CHROOT",
+}
+
+// hardenedMaskedPaths lists /proc paths the hardened profile masks in
+// addition to docker's own defaults. They leak kernel state (addresses,
+// timers, keys) useful for escalation.
+var hardenedMaskedPaths = []string{
+	"/proc/kcore",
+	"/proc/keys",
+	"/proc/sched_debug",
+	"/proc/timer_list",
+	"/proc/timer_stats",
+}
+
+// securityProfile is a parsed security profile. The nil profile (and the
+// default one) applies nothing.
+type securityProfile struct {
+	name             string
+	noNewPrivileges  bool
+	dropCapabilities []string
+	maskedPaths      []string
+}
+
+// newSecurityProfile parses the configured profile name. An empty name
+// selects the default profile.
+func newSecurityProfile(name string) (*securityProfile, error) {
+	switch name {
+	case "", securityProfileDefault:
+		return &securityProfile{name: securityProfileDefault}, nil
+	case securityProfileHardened:
+		return &securityProfile{
+			name:             securityProfileHardened,
+			noNewPrivileges:  true,
+			dropCapabilities: hardenedDroppedCapabilities,
+			maskedPaths:      hardenedMaskedPaths,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown dockershim security profile %q (known profiles: %q, %q)", name, securityProfileDefault, securityProfileHardened)
+}
+
+// securityOpts returns the docker security options the profile adds.
+func (p *securityProfile) securityOpts() []string {
+	if p == nil || !p.noNewPrivileges {
+		return nil
+	}
+	return []string{"no-new-privileges"}
+}
+
+// applyHostConfig adds the profile's capability drops and masked paths to
+// the host config. It must run after the pod's security context has been
+// applied: privileged containers are exempt, and explicit capabilities in
+// the security context override the default drop list.
+func (p *securityProfile) applyHostConfig(hc *dockercontainer.HostConfig) {
+	if p == nil || hc.Privileged {
+		return
+	}
+	if len(p.dropCapabilities) > 0 && len(hc.CapAdd) == 0 && len(hc.CapDrop) == 0 {
+		hc.CapDrop = append(hc.CapDrop, p.dropCapabilities...)
+	}
+	// The docker API of this vintage has no masked-paths field, so the
+	// extra /proc paths are masked the way runc masks files: a read-only
+	// /dev/null bind mount over each of them.
+	for _, path := range p.maskedPaths {
+		hc.Binds = append(hc.Binds, fmt.Sprintf("/dev/null:%s:ro", path))
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestNewSecurityProfile tests the security profile names.
+func TestNewSecurityProfile(t *testing.T) {
+	for _, name := range []string{"", securityProfileDefault} {
+		profile, err := newSecurityProfile(name)
+		require.NoError(t, err, "name %q", name)
+		assert.Empty(t, profile.securityOpts())
+		assert.Empty(t, profile.dropCapabilities)
+	}
+
+	profile, err := newSecurityProfile(securityProfileHardened)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no-new-privileges"}, profile.securityOpts())
+	assert.NotEmpty(t, profile.dropCapabilities)
+	assert.NotEmpty(t, profile.maskedPaths)
+
+	_, err = newSecurityProfile("paranoid")
+	assert.Error(t, err)
+}
+
+// TestHardenedProfileContainer tests that the hardened profile is applied
+// to workload containers and overridden by the pod's security context.
+func TestHardenedProfileContainer(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	profile, err := newSecurityProfile(securityProfileHardened)
+	require.NoError(t, err)
+	ds.securityProfile = profile
+
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil, nil)
+
+	createConfig, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	hc := createConfig.HostConfig
+	assert.Contains(t, hc.SecurityOpt, "no-new-privileges")
+	assert.Contains(t, []string(hc.CapDrop), "MKNOD")
+	assert.Contains(t, hc.Binds, "/dev/null:/proc/kcore:ro")
+
+	// Privileged containers are exempt from the profile.
+	config.Linux = &runtimeapi.LinuxContainerConfig{
+		SecurityContext: &runtimeapi.LinuxContainerSecurityContext{Privileged: true},
+	}
+	createConfig, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	hc = createConfig.HostConfig
+	assert.NotContains(t, hc.SecurityOpt, "no-new-privileges")
+	assert.Empty(t, hc.CapDrop)
+	assert.Empty(t, hc.Binds)
+
+	// A pod that manages its own capabilities overrides the drop list.
+	config.Linux = &runtimeapi.LinuxContainerConfig{
+		SecurityContext: &runtimeapi.LinuxContainerSecurityContext{
+			Capabilities: &runtimeapi.Capability{DropCapabilities: []string{"CHOWN"}},
+		},
+	}
+	createConfig, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	hc = createConfig.HostConfig
+	assert.Contains(t, hc.SecurityOpt, "no-new-privileges")
+	assert.Equal(t, []string{"CHOWN"}, []string(hc.CapDrop))
+}
+
+// TestHardenedProfileSandbox tests that the hardened profile is applied to
+// the sandbox container as well.
+func TestHardenedProfileSandbox(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	profile, err := newSecurityProfile(securityProfileHardened)
+	require.NoError(t, err)
+	ds.securityProfile = profile
+
+	createConfig, err := ds.makeSandboxDockerConfig(makeSandboxConfig("foo", "bar", "1", 0), defaultSandboxImage())
+	require.NoError(t, err)
+	hc := createConfig.HostConfig
+	assert.Contains(t, hc.SecurityOpt, "no-new-privileges")
+	assert.Contains(t, []string(hc.CapDrop), "NET_RAW")
+	assert.Contains(t, hc.Binds, "/dev/null:/proc/kcore:ro")
+}
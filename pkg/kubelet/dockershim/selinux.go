@@ -0,0 +1,76 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"strings"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+
+	"k8s.io/kubernetes/pkg/api/v1"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools/securitycontext"
+)
+
+// SELinux label consistency within a pod. On an SELinux-enforcing node
+// docker gives every container a fresh MCS label and relabels ":Z" mounts
+// to it, so two containers sharing a volume end up fighting over its label:
+// the last relabel cuts the other off. When the pod pins its label through
+// SELinuxOptions, the sandbox already runs with it (via
+// applySandboxSecurityContext); the helpers here extend the same label to
+// containers that do not carry their own options and make sure
+// emptyDir-backed mounts are relabeled to it, so pod-private volumes stay
+// accessible within the pod and inaccessible to other pods.
+
+// emptyDirVolumePathFragment identifies emptyDir-backed host paths under
+// the kubelet's pod directory.
+const emptyDirVolumePathFragment = "/volumes/kubernetes.io~empty-dir/"
+
+// podSELinuxOptions returns the pod-level SELinux options from the sandbox
+// config, or nil when the pod does not pin a label.
+func podSELinuxOptions(sandboxConfig *runtimeapi.PodSandboxConfig) *runtimeapi.SELinuxOption {
+	return sandboxConfig.GetLinux().GetSecurityContext().GetSelinuxOptions()
+}
+
+// applySELinuxOptions appends the docker label options for the given SELinux
+// options to the host config.
+func applySELinuxOptions(hc *dockercontainer.HostConfig, so *runtimeapi.SELinuxOption, separator rune) {
+	if so == nil {
+		return
+	}
+	hc.SecurityOpt = securitycontext.ModifySecurityOptions(
+		hc.SecurityOpt,
+		&v1.SELinuxOptions{
+			User:  so.User,
+			Role:  so.Role,
+			Type:  so.Type,
+			Level: so.Level,
+		},
+		separator,
+	)
+}
+
+// relabelEmptyDirMounts marks emptyDir-backed mounts for SELinux
+// relabeling, so content written to them is labeled with the pod's label
+// instead of keeping whatever label the kubelet created the directory with.
+func relabelEmptyDirMounts(mounts []*runtimeapi.Mount) {
+	for _, m := range mounts {
+		if strings.Contains(m.HostPath, emptyDirVolumePathFragment) {
+			m.SelinuxRelabel = true
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestRelabelEmptyDirMounts tests that only emptyDir-backed mounts are
+// marked for relabeling.
+func TestRelabelEmptyDirMounts(t *testing.T) {
+	mounts := []*runtimeapi.Mount{
+		{HostPath: "/var/lib/kubelet/pods/1234/volumes/kubernetes.io~empty-dir/cache", ContainerPath: "/cache"},
+		{HostPath: "/var/lib/kubelet/pods/1234/volumes/kubernetes.io~secret/token", ContainerPath: "/token"},
+		{HostPath: "/etc/hosts", ContainerPath: "/etc/hosts"},
+	}
+	relabelEmptyDirMounts(mounts)
+	assert.True(t, mounts[0].SelinuxRelabel)
+	assert.False(t, mounts[1].SelinuxRelabel)
+	assert.False(t, mounts[2].SelinuxRelabel)
+}
+
+// TestContainerInheritsPodSELinuxOptions tests that a container without its
+// own SELinux options runs with the pod-level label and that its emptyDir
+// mounts are relabeled.
+func TestContainerInheritsPodSELinuxOptions(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	sConfig := makeSandboxConfig("foo", "bar", "1", 0)
+	sConfig.Linux = &runtimeapi.LinuxPodSandboxConfig{
+		SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+			SelinuxOptions: &runtimeapi.SELinuxOption{Level: "s0:c1,c2"},
+		},
+	}
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil, nil)
+	config.Mounts = []*runtimeapi.Mount{
+		{HostPath: "/var/lib/kubelet/pods/1234/volumes/kubernetes.io~empty-dir/cache", ContainerPath: "/cache"},
+	}
+
+	createConfig, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	// The fake daemon speaks a post-1.23 API, so the separator is '='.
+	assert.Contains(t, createConfig.HostConfig.SecurityOpt, "label=level:s0:c1,c2")
+	assert.Contains(t, createConfig.HostConfig.Binds, "/var/lib/kubelet/pods/1234/volumes/kubernetes.io~empty-dir/cache:/cache:Z")
+
+	// A container carrying its own SELinux options keeps them.
+	config.Linux = &runtimeapi.LinuxContainerConfig{
+		SecurityContext: &runtimeapi.LinuxContainerSecurityContext{
+			SelinuxOptions: &runtimeapi.SELinuxOption{Level: "s0:c9,c9"},
+		},
+	}
+	createConfig, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	assert.Contains(t, createConfig.HostConfig.SecurityOpt, "label=level:s0:c9,c9")
+	assert.NotContains(t, createConfig.HostConfig.SecurityOpt, "label=level:s0:c1,c2")
+}
+
+// TestSandboxSELinuxOptions tests that the sandbox container runs with the
+// pod-level SELinux label.
+func TestSandboxSELinuxOptions(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	config := makeSandboxConfig("foo", "bar", "1", 0)
+	config.Linux = &runtimeapi.LinuxPodSandboxConfig{
+		SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+			NamespaceOptions: &runtimeapi.NamespaceOption{},
+			SelinuxOptions:   &runtimeapi.SELinuxOption{Level: "s0:c1,c2"},
+		},
+	}
+	createConfig, err := ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Contains(t, createConfig.HostConfig.SecurityOpt, "label=level:s0:c1,c2")
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// The docker default of 64MiB for /dev/shm is too small for ML and browser
+// workloads. The pod-level shm size is applied to the sandbox; the workload
+// containers join the sandbox's IPC namespace and share its /dev/shm, so
+// the size is consistent across the pod. This started out as a gated
+// hostconfig extension and was promoted to a first-class pod setting.
+
+// shmSizeAnnotationKey sets the size of the pod's /dev/shm, as a resource
+// quantity (e.g. "1Gi").
+const shmSizeAnnotationKey = "docker.alpha.kubernetes.io/shm-size"
+
+// sandboxShmSize returns the requested /dev/shm size for the pod in bytes,
+// or zero when the pod does not request one.
+func sandboxShmSize(annotations map[string]string) (int64, error) {
+	value, ok := annotations[shmSizeAnnotationKey]
+	if !ok {
+		return 0, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid shm size %q: %v", value, err)
+	}
+	if quantity.Value() <= 0 {
+		return 0, fmt.Errorf("shm size %q must be positive", value)
+	}
+	return quantity.Value(), nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSandboxShmSize tests the shm size annotation parsing.
+func TestSandboxShmSize(t *testing.T) {
+	size, err := sandboxShmSize(map[string]string{})
+	assert.NoError(t, err)
+	assert.Zero(t, size)
+
+	size, err = sandboxShmSize(map[string]string{shmSizeAnnotationKey: "1Gi"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024*1024*1024), size)
+
+	for _, value := range []string{"lots", "-5", "0"} {
+		_, err = sandboxShmSize(map[string]string{shmSizeAnnotationKey: value})
+		assert.Error(t, err, "value %q", value)
+	}
+}
+
+// TestSandboxShmSizeApplied tests that the pod's shm size lands on the
+// sandbox without requiring a hostconfig extension allowlist.
+func TestSandboxShmSizeApplied(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	createConfig, err := ds.makeSandboxDockerConfig(makeSandboxConfig("foo", "bar", "1", 0), defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Zero(t, createConfig.HostConfig.ShmSize)
+
+	config := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{},
+		map[string]string{shmSizeAnnotationKey: "128Mi"})
+	createConfig, err = ds.makeSandboxDockerConfig(config, defaultSandboxImage())
+	require.NoError(t, err)
+	assert.Equal(t, int64(128*1024*1024), createConfig.HostConfig.ShmSize)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/glog"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// This file translates pod ephemeral storage limits into docker storage-opt
+// size constraints. Whether docker can actually enforce the size depends on
+// the graph driver; the shim detects support at startup and reports it as a
+// runtime condition, so the kubelet knows whether the limits on this node
+// are enforced or merely advisory.
+
+const (
+	// ephemeralStorageLimitAnnotation carries the pod-level ephemeral
+	// storage limit, as a resource quantity, on the sandbox config.
+	ephemeralStorageLimitAnnotation = "storage.alpha.kubernetes.io/ephemeral-storage-limit"
+	// storageLimitsReadyCondition is the runtime condition reporting whether
+	// the graph driver enforces per-container storage limits.
+	storageLimitsReadyCondition = "StorageLimitsReady"
+)
+
+// storageOptSupport returns whether the graph driver enforces the
+// storage-opt size constraint, and a reason when it does not.
+func storageOptSupport(driver string, driverStatus [][2]string) (bool, string) {
+	switch driver {
+	case "devicemapper", "zfs", "btrfs", "windowsfilter":
+		return true, ""
+	case "overlay2":
+		// overlay2 enforces the size only on xfs backing filesystems
+		// mounted with project quotas (pquota).
+		for _, pair := range driverStatus {
+			if pair[0] == "Backing Filesystem" && pair[1] == "xfs" {
+				return true, ""
+			}
+		}
+		return false, "overlay2 enforces storage limits only on an xfs backing filesystem with project quotas"
+	}
+	return false, fmt.Sprintf("graph driver %q cannot enforce storage limits", driver)
+}
+
+// containerStorageOpts translates the sandbox's ephemeral storage limit
+// annotation into docker storage options for a workload container. When the
+// graph driver cannot enforce the limit the annotation is ignored with a
+// warning; the StorageLimitsReady condition tells the kubelet enforcement is
+// unavailable on this node.
+func (ds *dockerService) containerStorageOpts(sandboxConfig *runtimeapi.PodSandboxConfig) (map[string]string, error) {
+	limit, ok := sandboxConfig.GetAnnotations()[ephemeralStorageLimitAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	quantity, err := resource.ParseQuantity(limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral storage limit %q: %v", limit, err)
+	}
+	if !ds.storageOptSupported {
+		glog.Warningf("Ignoring ephemeral storage limit %q for pod %s/%s: %s",
+			limit, sandboxConfig.GetMetadata().Namespace, sandboxConfig.GetMetadata().Name, ds.storageOptReason)
+		return nil, nil
+	}
+	return map[string]string{"size": strconv.FormatInt(quantity.Value(), 10)}, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageOptSupport(t *testing.T) {
+	tests := []struct {
+		driver       string
+		driverStatus [][2]string
+		expected     bool
+	}{
+		{driver: "devicemapper", expected: true},
+		{driver: "zfs", expected: true},
+		{driver: "btrfs", expected: true},
+		{driver: "overlay2", driverStatus: [][2]string{{"Backing Filesystem", "xfs"}}, expected: true},
+		{driver: "overlay2", driverStatus: [][2]string{{"Backing Filesystem", "extfs"}}, expected: false},
+		{driver: "overlay2", expected: false},
+		{driver: "aufs", expected: false},
+	}
+	for i, test := range tests {
+		supported, reason := storageOptSupport(test.driver, test.driverStatus)
+		assert.Equal(t, test.expected, supported, "TestCase[%d]", i)
+		if !supported {
+			assert.NotEmpty(t, reason, "TestCase[%d]", i)
+		}
+	}
+}
+
+func TestContainerStorageOpts(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	ds.storageOptSupported = true
+
+	// Without the annotation no storage options are generated.
+	config := makeSandboxConfig("foo", "default", "1", 0)
+	opts, err := ds.containerStorageOpts(config)
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+
+	// The limit is translated into a size option in bytes.
+	config.Annotations = map[string]string{ephemeralStorageLimitAnnotation: "1Gi"}
+	opts, err = ds.containerStorageOpts(config)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"size": "1073741824"}, opts)
+
+	// A malformed limit is rejected.
+	config.Annotations[ephemeralStorageLimitAnnotation] = "a-lot"
+	_, err = ds.containerStorageOpts(config)
+	assert.Error(t, err)
+
+	// Without driver support the limit is ignored rather than enforced;
+	// the runtime condition reports the gap.
+	ds.storageOptSupported = false
+	config.Annotations[ephemeralStorageLimitAnnotation] = "1Gi"
+	opts, err = ds.containerStorageOpts(config)
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+}
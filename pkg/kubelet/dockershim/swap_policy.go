@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+)
+
+// memorySwapPolicyAnnotation selects the swap behavior of the pod's
+// containers, overriding the platform default from DefaultMemorySwap().
+// Latency-sensitive pods use "disabled" to keep their pages resident.
+const memorySwapPolicyAnnotation = "memory.alpha.kubernetes.io/swap-policy"
+
+type swapPolicy string
+
+const (
+	// swapPolicyDisabled denies the container any swap: the memory+swap
+	// limit equals the memory limit and swappiness is forced to zero.
+	swapPolicyDisabled swapPolicy = "disabled"
+	// swapPolicyLimited grants the container swap up to its memory limit
+	// again, i.e. memory+swap is twice the memory limit.
+	swapPolicyLimited swapPolicy = "limited"
+	// swapPolicyUnlimited lifts the memory+swap limit entirely.
+	swapPolicyUnlimited swapPolicy = "unlimited"
+)
+
+// applySwapPolicy translates the pod's swap policy annotation into the
+// MemorySwap/MemorySwappiness fields of the container resources. Without the
+// annotation the resources are left at the platform default. The disabled
+// and limited policies are expressed relative to the memory limit, so they
+// require one.
+func applySwapPolicy(annotations map[string]string, resources *dockercontainer.Resources) error {
+	value, ok := annotations[memorySwapPolicyAnnotation]
+	if !ok {
+		return nil
+	}
+	policy := swapPolicy(value)
+	if policy == swapPolicyDisabled || policy == swapPolicyLimited {
+		if resources.Memory <= 0 {
+			return fmt.Errorf("swap policy %q requires a memory limit", value)
+		}
+	}
+	switch policy {
+	case swapPolicyDisabled:
+		resources.MemorySwap = resources.Memory
+		swappiness := int64(0)
+		resources.MemorySwappiness = &swappiness
+	case swapPolicyLimited:
+		resources.MemorySwap = 2 * resources.Memory
+	case swapPolicyUnlimited:
+		resources.MemorySwap = -1
+	default:
+		return fmt.Errorf("unknown memory swap policy %q", value)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+func TestApplySwapPolicy(t *testing.T) {
+	// Without the annotation the default swap setting is untouched.
+	resources := dockercontainer.Resources{Memory: 1024, MemorySwap: dockertools.DefaultMemorySwap()}
+	require.NoError(t, applySwapPolicy(nil, &resources))
+	assert.Equal(t, dockertools.DefaultMemorySwap(), resources.MemorySwap)
+	assert.Nil(t, resources.MemorySwappiness)
+
+	// Disabled pins memory+swap to the memory limit and zeroes swappiness.
+	resources = dockercontainer.Resources{Memory: 1024}
+	require.NoError(t, applySwapPolicy(map[string]string{memorySwapPolicyAnnotation: "disabled"}, &resources))
+	assert.Equal(t, int64(1024), resources.MemorySwap)
+	require.NotNil(t, resources.MemorySwappiness)
+	assert.Equal(t, int64(0), *resources.MemorySwappiness)
+
+	// Limited grants swap up to the memory limit again.
+	resources = dockercontainer.Resources{Memory: 1024}
+	require.NoError(t, applySwapPolicy(map[string]string{memorySwapPolicyAnnotation: "limited"}, &resources))
+	assert.Equal(t, int64(2048), resources.MemorySwap)
+
+	// Unlimited lifts the memory+swap limit.
+	resources = dockercontainer.Resources{Memory: 1024}
+	require.NoError(t, applySwapPolicy(map[string]string{memorySwapPolicyAnnotation: "unlimited"}, &resources))
+	assert.Equal(t, int64(-1), resources.MemorySwap)
+
+	// Disabled and limited require a memory limit; unknown policies are
+	// rejected.
+	resources = dockercontainer.Resources{}
+	assert.Error(t, applySwapPolicy(map[string]string{memorySwapPolicyAnnotation: "disabled"}, &resources))
+	assert.Error(t, applySwapPolicy(map[string]string{memorySwapPolicyAnnotation: "limited"}, &resources))
+	assert.Error(t, applySwapPolicy(map[string]string{memorySwapPolicyAnnotation: "sometimes"}, &resources))
+}
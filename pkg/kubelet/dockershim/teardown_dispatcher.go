@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"sync"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+// defaultTeardownWorkers is the number of network teardowns the dispatcher
+// runs in parallel. Large enough that a node drain is not serialized on
+// plugin latency, small enough not to thrash the plugin.
+const defaultTeardownWorkers = 8
+
+// teardownDispatcher runs network plugin teardowns on a bounded worker pool.
+// During a mass eviction many sandboxes are stopped at once; funnelling the
+// plugin calls through the pool keeps them parallel up to the bound instead
+// of paying single-pod plugin latency once per pod, while still capping the
+// number of plugin processes in flight. Both the synchronous teardown in
+// StopPodSandbox and the asynchronous retry queue dispatch through it.
+type teardownDispatcher struct {
+	teardown teardownFunc
+	requests chan *teardownRequest
+	workers  int
+	once     sync.Once
+}
+
+type teardownRequest struct {
+	namespace string
+	name      string
+	id        kubecontainer.ContainerID
+	result    chan error
+}
+
+func newTeardownDispatcher(teardown teardownFunc, workers int) *teardownDispatcher {
+	if workers <= 0 {
+		workers = defaultTeardownWorkers
+	}
+	return &teardownDispatcher{
+		teardown: teardown,
+		requests: make(chan *teardownRequest),
+		workers:  workers,
+	}
+}
+
+// dispatch runs one teardown on the worker pool and blocks until it
+// completes, returning the teardown's error. The workers are started lazily
+// on first use.
+func (d *teardownDispatcher) dispatch(namespace, name string, id kubecontainer.ContainerID) error {
+	d.once.Do(func() {
+		for i := 0; i < d.workers; i++ {
+			go d.worker()
+		}
+	})
+	req := &teardownRequest{
+		namespace: namespace,
+		name:      name,
+		id:        id,
+		result:    make(chan error, 1),
+	}
+	d.requests <- req
+	return <-req.result
+}
+
+func (d *teardownDispatcher) worker() {
+	for req := range d.requests {
+		req.result <- d.teardown(req.namespace, req.name, req.id)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+func TestTeardownDispatcherBoundedParallelism(t *testing.T) {
+	const workers = 4
+	var inFlight, maxInFlight int32
+	d := newTeardownDispatcher(func(namespace, name string, id kubecontainer.ContainerID) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return nil
+	}, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3*workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, d.dispatch("ns", "name", kubecontainer.ContainerID{Type: runtimeName, ID: "sandbox"}))
+		}()
+	}
+	wg.Wait()
+
+	// The pool runs teardowns in parallel, but never more than its bound.
+	assert.True(t, maxInFlight > 1, "expected teardowns to overlap, max in flight was %d", maxInFlight)
+	assert.True(t, maxInFlight <= workers, "expected at most %d teardowns in flight, saw %d", workers, maxInFlight)
+}
+
+func TestTeardownDispatcherPropagatesErrors(t *testing.T) {
+	teardownErr := errors.New("teardown failed")
+	d := newTeardownDispatcher(func(namespace, name string, id kubecontainer.ContainerID) error {
+		if name == "bad" {
+			return teardownErr
+		}
+		return nil
+	}, 2)
+	assert.NoError(t, d.dispatch("ns", "good", kubecontainer.ContainerID{}))
+	assert.Equal(t, teardownErr, d.dispatch("ns", "bad", kubecontainer.ContainerID{}))
+}
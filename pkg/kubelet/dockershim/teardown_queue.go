@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+const (
+	// teardownRetryInitialDelay is the delay before the first teardown retry.
+	teardownRetryInitialDelay = 500 * time.Millisecond
+	// teardownRetryFactor is the backoff factor between teardown retries.
+	teardownRetryFactor = 2.0
+	// teardownRetrySteps bounds the number of teardown retries. With the
+	// defaults above this covers roughly half a minute of backoff.
+	teardownRetrySteps = 6
+)
+
+// teardownFunc tears down the network of a sandbox. It matches the signature
+// of network.NetworkPlugin.TearDownPod.
+type teardownFunc func(namespace, name string, id kubecontainer.ContainerID) error
+
+// teardownRetryQueue retries failed network plugin teardowns asynchronously
+// with exponential backoff. StopPodSandbox enqueues a retry instead of
+// failing the stop, so transient plugin failures neither block sandbox stop
+// latency nor leak the pod's IP allocation. At most one retry sequence is in
+// flight per sandbox; retries that exhaust the backoff are dropped and left
+// to the out of band GC routines.
+type teardownRetryQueue struct {
+	teardown teardownFunc
+	backoff  wait.Backoff
+
+	lock sync.Mutex
+	// pending tracks the sandbox IDs with a retry sequence in flight.
+	pending map[string]bool
+}
+
+func newTeardownRetryQueue(teardown teardownFunc) *teardownRetryQueue {
+	return &teardownRetryQueue{
+		teardown: teardown,
+		backoff: wait.Backoff{
+			Duration: teardownRetryInitialDelay,
+			Factor:   teardownRetryFactor,
+			Steps:    teardownRetrySteps,
+		},
+		pending: make(map[string]bool),
+	}
+}
+
+// enqueue schedules an asynchronous teardown retry for the sandbox. It is a
+// no-op if a retry sequence for the sandbox is already in flight.
+func (q *teardownRetryQueue) enqueue(namespace, name, podSandboxID string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.pending[podSandboxID] {
+		return
+	}
+	q.pending[podSandboxID] = true
+	go q.retry(namespace, name, podSandboxID)
+}
+
+// forget drops the in-flight marker for the sandbox, allowing a new retry
+// sequence to be enqueued.
+func (q *teardownRetryQueue) forget(podSandboxID string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.pending, podSandboxID)
+}
+
+// retry tears down the sandbox network with exponential backoff until it
+// succeeds or the backoff is exhausted.
+func (q *teardownRetryQueue) retry(namespace, name, podSandboxID string) {
+	defer q.forget(podSandboxID)
+	cID := kubecontainer.BuildContainerID(runtimeName, podSandboxID)
+	err := wait.ExponentialBackoff(q.backoff, func() (bool, error) {
+		if err := q.teardown(namespace, name, cID); err != nil {
+			glog.V(4).Infof("Retried teardown of sandbox %q for pod %s/%s failed: %v", podSandboxID, namespace, name, err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		glog.Errorf("Giving up tearing down network for sandbox %q for pod %s/%s after %d attempts", podSandboxID, namespace, name, q.backoff.Steps)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+// fakeTeardown fails a configurable number of times before succeeding and
+// records the number of attempts.
+type fakeTeardown struct {
+	sync.Mutex
+	failures int
+	attempts int
+}
+
+func (f *fakeTeardown) teardown(namespace, name string, id kubecontainer.ContainerID) error {
+	f.Lock()
+	defer f.Unlock()
+	f.attempts++
+	if f.attempts <= f.failures {
+		return fmt.Errorf("transient teardown error")
+	}
+	return nil
+}
+
+func (f *fakeTeardown) attemptCount() int {
+	f.Lock()
+	defer f.Unlock()
+	return f.attempts
+}
+
+// TestTeardownRetryQueue tests that enqueued teardowns are retried until they
+// succeed and that the in-flight marker is dropped afterwards.
+func TestTeardownRetryQueue(t *testing.T) {
+	fake := &fakeTeardown{failures: 2}
+	q := newTeardownRetryQueue(fake.teardown)
+	q.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1.0, Steps: 5}
+
+	q.enqueue("bar", "foo", "sandboxid")
+	assert.NoError(t, wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		return fake.attemptCount() == 3, nil
+	}))
+	assert.NoError(t, wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		return len(q.pending) == 0, nil
+	}))
+}
+
+// TestTeardownRetryQueueDedup tests that at most one retry sequence is in
+// flight per sandbox.
+func TestTeardownRetryQueueDedup(t *testing.T) {
+	q := newTeardownRetryQueue(nil)
+	q.lock.Lock()
+	q.pending["sandboxid"] = true
+	q.lock.Unlock()
+
+	// A nil teardown func would panic if the retry goroutine were started.
+	q.enqueue("bar", "foo", "sandboxid")
+
+	q.forget("sandboxid")
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	assert.Empty(t, q.pending)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// Multi-step CRI operations (RunPodSandbox, CreateContainer) log each step
+// with a per-operation trace ID and key/value pairs, so operators can
+// correlate one pod's lifecycle across logs interleaved from many pods. With
+// the JSONTraceLogs feature gate the lines are emitted as one JSON object
+// per line instead, for log pipelines that parse structure.
+
+// traceLogVerbosity is the glog verbosity of trace step lines.
+const traceLogVerbosity = 2
+
+// opTrace carries the trace ID and the key/values shared by every log line
+// of one operation.
+type opTrace struct {
+	id string
+	op string
+	// kv holds ordered shared key/values, e.g. the pod and, once known, the
+	// sandbox ID.
+	kv       [][2]string
+	jsonLogs bool
+}
+
+// newOpTrace starts a trace for one invocation of the named operation, with
+// optional shared key/value pairs.
+func (ds *dockerService) newOpTrace(op string, kv ...string) *opTrace {
+	t := &opTrace{
+		// A UUID is overlong for log lines; the first chunk is unique enough
+		// to correlate operations on one node.
+		id:       string(uuid.NewUUID())[:8],
+		op:       op,
+		jsonLogs: ds.featureGate.Enabled(jsonTraceLogsFeature),
+	}
+	t.add(kv)
+	return t
+}
+
+// with records a shared key/value emitted on every subsequent line, e.g. the
+// sandbox ID once the sandbox container exists.
+func (t *opTrace) with(key, value string) {
+	t.kv = append(t.kv, [2]string{key, value})
+}
+
+func (t *opTrace) add(kv []string) {
+	for i := 0; i+1 < len(kv); i += 2 {
+		t.kv = append(t.kv, [2]string{kv[i], kv[i+1]})
+	}
+}
+
+// line renders one log line from the shared and extra key/values.
+func (t *opTrace) line(msg string, extra []string) string {
+	pairs := append([][2]string{{"op", t.op}, {"trace", t.id}}, t.kv...)
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, [2]string{extra[i], extra[i+1]})
+	}
+	if t.jsonLogs {
+		m := map[string]string{"msg": msg}
+		for _, p := range pairs {
+			m[p[0]] = p[1]
+		}
+		if b, err := json.Marshal(m); err == nil {
+			return string(b)
+		}
+	}
+	var b bytes.Buffer
+	for _, p := range pairs {
+		fmt.Fprintf(&b, "%s=%q ", p[0], p[1])
+	}
+	fmt.Fprintf(&b, "msg=%q", msg)
+	return b.String()
+}
+
+// step logs one completed step of the operation with optional extra
+// key/value pairs.
+func (t *opTrace) step(msg string, kv ...string) {
+	if glog.V(traceLogVerbosity) {
+		glog.InfoDepth(1, t.line(msg, kv))
+	}
+}
+
+// stepFailed logs a failed step at warning level; the operation's error is
+// still returned to the caller through the usual path.
+func (t *opTrace) stepFailed(err error, msg string, kv ...string) {
+	glog.WarningDepth(1, t.line(msg, append(kv, "err", err.Error())))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpTraceLine tests the key=value rendering of trace step lines.
+func TestOpTraceLine(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	trace := ds.newOpTrace("RunPodSandbox", "pod", "default/foo")
+	trace.with("sandbox", "abc123")
+
+	line := trace.line("started sandbox container", []string{"image", "pause"})
+	assert.Contains(t, line, `op="RunPodSandbox"`)
+	assert.Contains(t, line, fmt.Sprintf("trace=%q", trace.id))
+	assert.Contains(t, line, `pod="default/foo"`)
+	assert.Contains(t, line, `sandbox="abc123"`)
+	assert.Contains(t, line, `image="pause"`)
+	assert.Contains(t, line, `msg="started sandbox container"`)
+
+	// Distinct operations get distinct trace IDs.
+	assert.NotEqual(t, trace.id, ds.newOpTrace("RunPodSandbox").id)
+}
+
+// TestOpTraceJSON tests that the JSONTraceLogs gate switches the rendering to
+// one JSON object per line.
+func TestOpTraceJSON(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	require.NoError(t, ds.featureGate.set("JSONTraceLogs=true"))
+	trace := ds.newOpTrace("CreateContainer", "pod", "default/foo")
+
+	var m map[string]string
+	require.NoError(t, json.Unmarshal([]byte(trace.line("created container", []string{"id", "c1"})), &m))
+	assert.Equal(t, "CreateContainer", m["op"])
+	assert.Equal(t, trace.id, m["trace"])
+	assert.Equal(t, "default/foo", m["pod"])
+	assert.Equal(t, "c1", m["id"])
+	assert.Equal(t, "created container", m["msg"])
+}
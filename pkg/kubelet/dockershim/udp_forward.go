@@ -0,0 +1,140 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+
+	"github.com/golang/glog"
+)
+
+// PortForwardUDP relays UDP datagrams between the stream and a UDP port in
+// the sandbox's network namespace. Datagrams are framed on the stream with a
+// two byte big-endian length prefix in both directions, so datagram
+// boundaries survive the byte-oriented transport.
+func (r *streamingRuntime) PortForwardUDP(podSandboxID string, port int32, stream io.ReadWriteCloser) error {
+	if port < 1 || port > math.MaxUint16 {
+		return fmt.Errorf("invalid port %d", port)
+	}
+	container, err := checkContainerStatus(r.client, podSandboxID)
+	if err != nil {
+		return err
+	}
+	return forwardUDP(container.State.Pid, port, stream)
+}
+
+// forwardUDP runs socat in the sandbox's network namespace and relays framed
+// datagrams between the stream and the socat process. The UDP socket lives
+// for as long as the stream: socat creates it on the first datagram and
+// closes it when the client closes the stream.
+func forwardUDP(containerPid int, port int32, stream io.ReadWriteCloser) error {
+	socatPath, lookupErr := exec.LookPath("socat")
+	if lookupErr != nil {
+		return fmt.Errorf("unable to do port forwarding: socat not found.")
+	}
+	nsenterPath, lookupErr := exec.LookPath("nsenter")
+	if lookupErr != nil {
+		return fmt.Errorf("unable to do port forwarding: nsenter not found.")
+	}
+
+	args := []string{"-t", fmt.Sprintf("%d", containerPid), "-n", socatPath, "-", fmt.Sprintf("UDP4:localhost:%d", port)}
+	glog.V(4).Infof("executing UDP port forwarding command: %s %v", nsenterPath, args)
+
+	command := exec.Command(nsenterPath, args...)
+	stderr := new(bytes.Buffer)
+	command.Stderr = stderr
+
+	inPipe, err := command.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("unable to do port forwarding: error creating stdin pipe: %v", err)
+	}
+	outPipe, err := command.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("unable to do port forwarding: error creating stdout pipe: %v", err)
+	}
+	if err := command.Start(); err != nil {
+		return fmt.Errorf("unable to do port forwarding: %v: %s", err, stderr.String())
+	}
+
+	go func() {
+		// Unframe datagrams from the client and feed them to socat. Each
+		// write is relayed into the namespace as a single datagram. Closing
+		// stdin terminates socat, and with it the socket in the namespace.
+		buf := make([]byte, math.MaxUint16)
+		for {
+			n, err := readUDPFrame(stream, buf)
+			if err != nil {
+				break
+			}
+			if _, err := inPipe.Write(buf[:n]); err != nil {
+				break
+			}
+		}
+		inPipe.Close()
+	}()
+
+	// Frame replies from socat back onto the stream. Each read corresponds to
+	// one datagram as long as the relay keeps up with the traffic.
+	buf := make([]byte, math.MaxUint16)
+	for {
+		n, err := outPipe.Read(buf)
+		if n > 0 {
+			if werr := writeUDPFrame(stream, buf[:n]); werr != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if err := command.Wait(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// readUDPFrame reads one length-prefixed datagram from r into buf and returns
+// the payload length.
+func readUDPFrame(r io.Reader, buf []byte) (int, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+	n := int(binary.BigEndian.Uint16(header[:]))
+	if _, err := io.ReadFull(r, buf[:n]); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// writeUDPFrame writes payload to w as one length-prefixed datagram.
+func writeUDPFrame(w io.Writer, payload []byte) error {
+	if len(payload) > math.MaxUint16 {
+		return fmt.Errorf("datagram too large: %d bytes", len(payload))
+	}
+	frame := make([]byte, len(payload)+2)
+	binary.BigEndian.PutUint16(frame, uint16(len(payload)))
+	copy(frame[2:], payload)
+	_, err := w.Write(frame)
+	return err
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUDPFrameRoundTrip checks that the length-prefixed framing preserves
+// datagram boundaries, including empty datagrams.
+func TestUDPFrameRoundTrip(t *testing.T) {
+	datagrams := [][]byte{
+		[]byte("hello"),
+		{},
+		[]byte(strings.Repeat("x", 4096)),
+		[]byte("bye"),
+	}
+
+	stream := &bytes.Buffer{}
+	for _, d := range datagrams {
+		require.NoError(t, writeUDPFrame(stream, d))
+	}
+
+	buf := make([]byte, 65535)
+	for i, d := range datagrams {
+		n, err := readUDPFrame(stream, buf)
+		require.NoError(t, err, "datagram[%d]", i)
+		assert.Equal(t, d, append([]byte{}, buf[:n]...), "datagram[%d]", i)
+	}
+	_, err := readUDPFrame(stream, buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+// TestWriteUDPFrameTooLarge checks that oversized payloads are rejected
+// rather than silently truncated by the 16 bit length prefix.
+func TestWriteUDPFrameTooLarge(t *testing.T) {
+	err := writeUDPFrame(&bytes.Buffer{}, make([]byte, 65536))
+	assert.Error(t, err)
+}
+
+// TestPortForwardUDPValidatesPort checks the port range and container state
+// validation performed before the relay is started.
+func TestPortForwardUDPValidatesPort(t *testing.T) {
+	_, fDocker, _ := newTestDockerService()
+	r := &streamingRuntime{client: fDocker}
+	for _, port := range []int32{0, -1, 65536} {
+		err := r.PortForwardUDP("sandbox", port, nil)
+		assert.Error(t, err, "port %d", port)
+	}
+	// Unknown sandbox should fail the container status check.
+	err := r.PortForwardUDP("nosuchsandbox", 53, nil)
+	assert.Error(t, err)
+}
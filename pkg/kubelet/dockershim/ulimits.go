@@ -0,0 +1,92 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	dockerunits "github.com/docker/go-units"
+)
+
+// Per-container ulimits. The pod-level ulimits annotation (see
+// hostconfig_extensions.go) applies to every container in the pod; the
+// same annotation on an individual container overrides it, so a database
+// container can raise nofile without touching its sidecars. Node-level
+// caps configured by the operator bound both, so pods cannot grant
+// themselves limits that would let them exhaust the node.
+
+// parseUlimits parses a comma-separated ulimit list in docker's notation
+// (e.g. "nofile=65536:65536,memlock=-1").
+func parseUlimits(value string) ([]*dockerunits.Ulimit, error) {
+	var ulimits []*dockerunits.Ulimit
+	for _, s := range strings.Split(value, ",") {
+		ulimit, err := dockerunits.ParseUlimit(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		ulimits = append(ulimits, ulimit)
+	}
+	return ulimits, nil
+}
+
+// ulimitCaps maps ulimit names to the highest value pods may request for
+// them. The nil map does not bound anything.
+type ulimitCaps map[string]int64
+
+// newUlimitCaps parses the node-level ulimit caps from the kubelet
+// configuration, comma separated "<name>=<max>" pairs (e.g.
+// "nofile=1048576,nproc=65536"). A max of -1 explicitly allows unlimited
+// values.
+func newUlimitCaps(config string) (ulimitCaps, error) {
+	if config == "" {
+		return nil, nil
+	}
+	caps := ulimitCaps{}
+	for _, entry := range strings.Split(config, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("ulimit cap %q must be \"<name>=<max>\"", entry)
+		}
+		max, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ulimit cap %q: %v", entry, err)
+		}
+		caps[strings.TrimSpace(parts[0])] = max
+	}
+	return caps, nil
+}
+
+// enforce rejects ulimits above the node-level cap. Names without a
+// configured cap are not bounded.
+func (c ulimitCaps) enforce(ulimits []*dockerunits.Ulimit) error {
+	for _, ulimit := range ulimits {
+		max, ok := c[ulimit.Name]
+		if !ok || max == -1 {
+			continue
+		}
+		if ulimit.Hard == -1 || ulimit.Hard > max || ulimit.Soft == -1 || ulimit.Soft > max {
+			return fmt.Errorf("ulimit %q (%d:%d) exceeds the node cap of %d", ulimit.Name, ulimit.Soft, ulimit.Hard, max)
+		}
+	}
+	return nil
+}
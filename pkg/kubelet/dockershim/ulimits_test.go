@@ -0,0 +1,120 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestNewUlimitCaps tests the cap configuration parsing.
+func TestNewUlimitCaps(t *testing.T) {
+	caps, err := newUlimitCaps("")
+	require.NoError(t, err)
+	assert.Nil(t, caps)
+
+	caps, err = newUlimitCaps("nofile=1048576, nproc=65536,core=-1")
+	require.NoError(t, err)
+	assert.Equal(t, ulimitCaps{"nofile": 1048576, "nproc": 65536, "core": -1}, caps)
+
+	for _, config := range []string{"nofile", "nofile=lots"} {
+		_, err = newUlimitCaps(config)
+		assert.Error(t, err, "config %q", config)
+	}
+}
+
+// TestUlimitCapsEnforce tests the node-level bounds.
+func TestUlimitCapsEnforce(t *testing.T) {
+	caps, err := newUlimitCaps("nofile=1048576,core=-1")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		value       string
+		expectError bool
+	}{
+		{"nofile=65536:65536", false},
+		// Names without a cap are not bounded.
+		{"memlock=-1", false},
+		// An explicit -1 cap allows unlimited values.
+		{"core=-1", false},
+		{"nofile=1048576:2097152", true},
+		// Unlimited exceeds any finite cap.
+		{"nofile=-1", true},
+	} {
+		ulimits, err := parseUlimits(tc.value)
+		require.NoError(t, err, "value %q", tc.value)
+		err = caps.enforce(ulimits)
+		if tc.expectError {
+			assert.Error(t, err, "value %q", tc.value)
+		} else {
+			assert.NoError(t, err, "value %q", tc.value)
+		}
+	}
+
+	// The nil caps do not bound anything.
+	ulimits, err := parseUlimits("nofile=-1")
+	require.NoError(t, err)
+	assert.NoError(t, ulimitCaps(nil).enforce(ulimits))
+}
+
+// TestContainerUlimitOverride tests that a ulimits annotation on the
+// container overrides the pod-level one, and that the node caps bound both.
+func TestContainerUlimitOverride(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	extensions, err := newHostConfigExtensions("ulimits")
+	require.NoError(t, err)
+	ds.hostConfigExtensions = extensions
+
+	sConfig := makeSandboxConfigWithLabelsAndAnnotations("foo", "bar", "1", 0,
+		map[string]string{},
+		map[string]string{ulimitsAnnotationKey: "nofile=65536:65536"})
+	config := makeContainerConfig(sConfig, "c1", "iamimage", 0, nil,
+		map[string]string{ulimitsAnnotationKey: "nofile=262144:262144,nproc=4096"})
+	config.Linux = &runtimeapi.LinuxContainerConfig{}
+
+	containerConfig, err := ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.NoError(t, err)
+	require.Len(t, containerConfig.HostConfig.Ulimits, 2)
+	assert.Equal(t, "nofile", containerConfig.HostConfig.Ulimits[0].Name)
+	assert.Equal(t, int64(262144), containerConfig.HostConfig.Ulimits[0].Hard)
+	assert.Equal(t, "nproc", containerConfig.HostConfig.Ulimits[1].Name)
+
+	// The container annotation needs the same allowlist entry as the
+	// pod-level one.
+	ds.hostConfigExtensions = nil
+	_, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not enabled on this node")
+	ds.hostConfigExtensions = extensions
+
+	// Requests above the node cap fail the container.
+	ds.ulimitCaps, err = newUlimitCaps("nofile=131072")
+	require.NoError(t, err)
+	_, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the node cap")
+
+	// The pod-level annotation is bounded by the same caps.
+	config.Annotations = map[string]string{}
+	ds.ulimitCaps, err = newUlimitCaps("nofile=4096")
+	require.NoError(t, err)
+	_, err = ds.makeContainerDockerConfig("sandboxid", config, sConfig)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/golang/glog"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// usernsRemapEnabled returns whether the docker daemon is running with
+// userns-remap enabled, based on the security options reported by docker
+// info. Depending on the docker version the option is reported either as
+// "userns" or as "name=userns".
+func usernsRemapEnabled(info *dockertypes.Info) bool {
+	for _, opt := range info.SecurityOptions {
+		if opt == "userns" || strings.HasSuffix(opt, "=userns") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRemappedRootIDs derives the remapped root UID/GID from the docker
+// root directory, which the daemon suffixes with "<uid>.<gid>" when
+// userns-remap is enabled (e.g. /var/lib/docker/100000.100000).
+func parseRemappedRootIDs(dockerRootDir string) (int, int, error) {
+	ids := strings.SplitN(filepath.Base(dockerRootDir), ".", 2)
+	if len(ids) != 2 {
+		return 0, 0, fmt.Errorf("docker root dir %q does not encode a remapped root", dockerRootDir)
+	}
+	uid, err := strconv.Atoi(ids[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid remapped root uid in docker root dir %q: %v", dockerRootDir, err)
+	}
+	gid, err := strconv.Atoi(ids[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid remapped root gid in docker root dir %q: %v", dockerRootDir, err)
+	}
+	return uid, gid, nil
+}
+
+// applyUserNamespaceMode sets the userns mode for a container being created.
+// Pods opt out of the remapping via the host userns namespace option, e.g.
+// when they need privileged access to the host. In addition, docker refuses
+// to share any other host namespace with a remapped container, so requesting
+// one of those implies the host user namespace as well.
+func (ds *dockerService) applyUserNamespaceMode(nsOpts *runtimeapi.NamespaceOption, hc *dockercontainer.HostConfig) {
+	if !ds.usernsRemapped || nsOpts == nil {
+		return
+	}
+	if nsOpts.HostUserns || nsOpts.HostNetwork || nsOpts.HostPid || nsOpts.HostIpc {
+		hc.UsernsMode = namespaceModeHost
+	}
+}
+
+// translateHostPathOwnership chowns kubelet-managed volume directories to
+// the remapped root user so remapped containers can access them. Only paths
+// still owned by the host root are translated, and only the top-level
+// directory; volumes with explicit ownership are left alone. Failures are
+// logged but do not fail container creation.
+func (ds *dockerService) translateHostPathOwnership(mounts []*runtimeapi.Mount, hc *dockercontainer.HostConfig) {
+	if !ds.usernsRemapped || hc.UsernsMode.IsHost() || ds.remappedRootUID == 0 {
+		return
+	}
+	for _, m := range mounts {
+		if m.Readonly {
+			continue
+		}
+		info, err := os.Stat(m.HostPath)
+		if err != nil {
+			glog.Warningf("Cannot stat mount source %q for ownership translation: %v", m.HostPath, err)
+			continue
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok || stat.Uid != 0 {
+			continue
+		}
+		if err := os.Chown(m.HostPath, ds.remappedRootUID, ds.remappedRootGID); err != nil {
+			glog.Warningf("Failed to translate ownership of %q to remapped root %d:%d: %v", m.HostPath, ds.remappedRootUID, ds.remappedRootGID, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/stretchr/testify/assert"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+func TestUsernsRemapEnabled(t *testing.T) {
+	makeInfo := func(opts ...string) *dockertypes.Info {
+		return &dockertypes.Info{SecurityOptions: opts}
+	}
+
+	assert.False(t, usernsRemapEnabled(makeInfo()))
+	assert.False(t, usernsRemapEnabled(makeInfo("apparmor", "seccomp")))
+	assert.True(t, usernsRemapEnabled(makeInfo("apparmor", "userns")))
+	assert.True(t, usernsRemapEnabled(makeInfo("name=apparmor", "name=userns")))
+}
+
+func TestParseRemappedRootIDs(t *testing.T) {
+	uid, gid, err := parseRemappedRootIDs("/var/lib/docker/100000.100000")
+	assert.NoError(t, err)
+	assert.Equal(t, 100000, uid)
+	assert.Equal(t, 100000, gid)
+
+	for _, rootDir := range []string{"/var/lib/docker", "/var/lib/docker/foo.bar"} {
+		_, _, err := parseRemappedRootIDs(rootDir)
+		assert.Error(t, err, "expected error for %q", rootDir)
+	}
+}
+
+func TestApplyUserNamespaceMode(t *testing.T) {
+	tests := []struct {
+		msg          string
+		remapped     bool
+		nsOpts       *runtimeapi.NamespaceOption
+		expectedMode dockercontainer.UsernsMode
+	}{{
+		msg:          "No remapping",
+		remapped:     false,
+		nsOpts:       &runtimeapi.NamespaceOption{HostUserns: true},
+		expectedMode: "",
+	}, {
+		msg:          "Remapped, no opt out",
+		remapped:     true,
+		nsOpts:       &runtimeapi.NamespaceOption{},
+		expectedMode: "",
+	}, {
+		msg:          "Remapped, host userns requested",
+		remapped:     true,
+		nsOpts:       &runtimeapi.NamespaceOption{HostUserns: true},
+		expectedMode: "host",
+	}, {
+		msg:          "Remapped, host network implies host userns",
+		remapped:     true,
+		nsOpts:       &runtimeapi.NamespaceOption{HostNetwork: true},
+		expectedMode: "host",
+	}}
+
+	for i, test := range tests {
+		ds, _, _ := newTestDockerService()
+		ds.usernsRemapped = test.remapped
+		hc := &dockercontainer.HostConfig{}
+		ds.applyUserNamespaceMode(test.nsOpts, hc)
+		assert.Equal(t, test.expectedMode, hc.UsernsMode, "TestCase[%d]: %s", i, test.msg)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/golang/glog"
+)
+
+// defaultMinimumDockerAPIVersion is the oldest docker API version the shim
+// supports when the operator does not configure one.
+const defaultMinimumDockerAPIVersion = "1.22.0"
+
+// negotiatedDockerVersion holds the docker API version negotiated once at
+// startup together with the behaviors derived from it, so per-operation
+// paths neither query the daemon nor re-derive them.
+type negotiatedDockerVersion struct {
+	apiVersion           semver.Version
+	securityOptSeparator rune
+}
+
+// negotiateDockerVersion queries the daemon once, enforces the minimum
+// supported API version and computes the version-dependent behaviors. An
+// unreachable daemon only skips the negotiation: operations then fall back
+// to resolving the version lazily through the version cache, and the
+// runtime condition reports the daemon as not ready.
+func (ds *dockerService) negotiateDockerVersion(minimumAPIVersion string) error {
+	if minimumAPIVersion == "" {
+		minimumAPIVersion = defaultMinimumDockerAPIVersion
+	}
+	minimum, err := semver.Parse(minimumAPIVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum docker API version %q: %v", minimumAPIVersion, err)
+	}
+	v, err := ds.getDockerVersion()
+	if err != nil {
+		glog.Warningf("Skipping docker version negotiation, the daemon is unreachable: %v", err)
+		return nil
+	}
+	apiVersion, err := semver.Parse(v.APIVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse docker API version %q: %v", v.APIVersion, err)
+	}
+	if apiVersion.LT(minimum) {
+		return fmt.Errorf("docker %s reports API version %s, but the dockershim requires at least %s; upgrade the docker daemon or lower the configured minimum", v.Version, apiVersion, minimum)
+	}
+	ds.negotiatedVersion = &negotiatedDockerVersion{
+		apiVersion:           apiVersion,
+		securityOptSeparator: getSecurityOptSeparator(&apiVersion),
+	}
+	glog.Infof("Negotiated docker API version %s (security opt separator %q)", apiVersion, ds.negotiatedVersion.securityOptSeparator)
+	return nil
+}
+
+// securityOptSeparator returns the separator for docker security options,
+// computed once during version negotiation or derived lazily when the
+// negotiation was skipped.
+func (ds *dockerService) securityOptSeparator() (rune, error) {
+	if ds.negotiatedVersion != nil {
+		return ds.negotiatedVersion.securityOptSeparator, nil
+	}
+	apiVersion, err := ds.getDockerAPIVersion()
+	if err != nil {
+		return 0, err
+	}
+	return getSecurityOptSeparator(apiVersion), nil
+}
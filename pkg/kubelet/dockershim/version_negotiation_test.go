@@ -0,0 +1,59 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
+)
+
+// TestNegotiateDockerVersion tests that the version is negotiated once and
+// that the derived behaviors are cached.
+func TestNegotiateDockerVersion(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+	require.NoError(t, ds.negotiateDockerVersion(""))
+	require.NotNil(t, ds.negotiatedVersion)
+	assert.Equal(t, "1.23.0", ds.negotiatedVersion.apiVersion.String())
+
+	// The separator comes from the negotiated version without further
+	// daemon queries.
+	sep, err := ds.securityOptSeparator()
+	require.NoError(t, err)
+	assert.Equal(t, dockertools.SecurityOptSeparatorNew, sep)
+
+	v, err := ds.getDockerAPIVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "1.23.0", v.String())
+}
+
+// TestNegotiateDockerVersionMinimum tests the minimum version policy.
+func TestNegotiateDockerVersionMinimum(t *testing.T) {
+	// The fake daemon reports API version 1.23; a higher minimum is fatal.
+	ds, _, _ := newTestDockerService()
+	err := ds.negotiateDockerVersion("1.24.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires at least 1.24.0")
+	assert.Nil(t, ds.negotiatedVersion)
+
+	// An equal minimum passes, and malformed minimums are rejected.
+	require.NoError(t, ds.negotiateDockerVersion("1.23.0"))
+	require.Error(t, ds.negotiateDockerVersion("not-a-version"))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// Windows sandbox support. Windows containers know nothing of cgroup
+// parents, sysctls, seccomp or OOM scores; instead a sandbox picks an
+// isolation technology (shared-kernel process isolation or a Hyper-V
+// utility VM) and one of the Windows network modes. This file is compiled
+// everywhere so the helpers stay unit-testable on Linux; the platform
+// branch happens at runtime.
+
+const (
+	// windowsIsolationAnnotationKey picks the Windows isolation technology
+	// for the pod. Valid values are "process" (the default) and "hyperv".
+	windowsIsolationAnnotationKey = "experimental.windows.kubernetes.io/isolation-type"
+
+	windowsIsolationProcess = "process"
+	windowsIsolationHyperV  = "hyperv"
+
+	// windowsNetworkModeEnv overrides the docker network the sandboxes are
+	// attached to, mirroring the knob dockertools honors on Windows.
+	windowsNetworkModeEnv = "CONTAINER_NETWORK"
+	// defaultWindowsNetworkMode is docker's NAT network, the only mode
+	// available out of the box on Windows.
+	defaultWindowsNetworkMode = "nat"
+)
+
+// sandboxPlatformWindows returns whether sandboxes on this node are Windows
+// containers.
+func sandboxPlatformWindows() bool {
+	return runtime.GOOS == "windows"
+}
+
+// applySandboxWindowsOptions configures the sandbox HostConfig for a Windows
+// node and rejects Linux-only requests instead of silently dropping them.
+func (ds *dockerService) applySandboxWindowsOptions(hc *dockercontainer.HostConfig, c *runtimeapi.PodSandboxConfig) error {
+	sysctls, err := getSandboxSysctls(c)
+	if err != nil {
+		return fmt.Errorf("failed to get sysctls for sandbox %q: %v", c.Metadata.Name, err)
+	}
+	if len(sysctls) > 0 {
+		return fmt.Errorf("sandbox %q requests sysctls, which are not supported on Windows", c.Metadata.Name)
+	}
+	isolation, err := windowsIsolation(c.GetAnnotations())
+	if err != nil {
+		return fmt.Errorf("failed to get isolation for sandbox %q: %v", c.Metadata.Name, err)
+	}
+	hc.Isolation = isolation
+	hc.NetworkMode = dockercontainer.NetworkMode(windowsNetworkMode())
+	return nil
+}
+
+// windowsIsolation maps the isolation annotation to the docker isolation
+// technology. Absent or "process" means shared-kernel process isolation;
+// "hyperv" runs the pod in a Hyper-V utility VM.
+func windowsIsolation(annotations map[string]string) (dockercontainer.Isolation, error) {
+	switch v := annotations[windowsIsolationAnnotationKey]; v {
+	case "", windowsIsolationProcess:
+		return dockercontainer.Isolation(windowsIsolationProcess), nil
+	case windowsIsolationHyperV:
+		return dockercontainer.Isolation(windowsIsolationHyperV), nil
+	default:
+		return "", fmt.Errorf("unknown isolation type %q, must be %q or %q", v, windowsIsolationProcess, windowsIsolationHyperV)
+	}
+}
+
+// windowsNetworkMode returns the docker network sandboxes are attached to,
+// overridable via the CONTAINER_NETWORK environment variable.
+func windowsNetworkMode() string {
+	if mode := os.Getenv(windowsNetworkModeEnv); mode != "" {
+		return mode
+	}
+	return defaultWindowsNetworkMode
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockershim
+
+import (
+	"os"
+	"testing"
+
+	dockercontainer "github.com/docker/engine-api/types/container"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+)
+
+// TestApplySandboxWindowsOptions tests the Windows sandbox configuration,
+// including the rejection of Linux-only requests.
+func TestApplySandboxWindowsOptions(t *testing.T) {
+	ds, _, _ := newTestDockerService()
+
+	// Defaults: process isolation on the NAT network.
+	hc := &dockercontainer.HostConfig{}
+	c := makeSandboxConfig("foo", "default", "1", 0)
+	require.NoError(t, ds.applySandboxWindowsOptions(hc, c))
+	assert.Equal(t, dockercontainer.Isolation(windowsIsolationProcess), hc.Isolation)
+	assert.Equal(t, dockercontainer.NetworkMode(defaultWindowsNetworkMode), hc.NetworkMode)
+
+	// Hyper-V isolation via the annotation.
+	hc = &dockercontainer.HostConfig{}
+	c = makeSandboxConfigWithLabelsAndAnnotations("foo", "default", "1", 0,
+		map[string]string{}, map[string]string{windowsIsolationAnnotationKey: windowsIsolationHyperV})
+	require.NoError(t, ds.applySandboxWindowsOptions(hc, c))
+	assert.Equal(t, dockercontainer.Isolation(windowsIsolationHyperV), hc.Isolation)
+
+	// Unknown isolation types are rejected.
+	c = makeSandboxConfigWithLabelsAndAnnotations("foo", "default", "1", 0,
+		map[string]string{}, map[string]string{windowsIsolationAnnotationKey: "kvm"})
+	err := ds.applySandboxWindowsOptions(&dockercontainer.HostConfig{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown isolation type")
+
+	// Sysctl requests cannot be honored on Windows.
+	c = makeSandboxConfig("foo", "default", "1", 0)
+	c.Linux = &runtimeapi.LinuxPodSandboxConfig{Sysctls: map[string]string{"kernel.shmmax": "100000000"}}
+	err = ds.applySandboxWindowsOptions(&dockercontainer.HostConfig{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported on Windows")
+}
+
+// TestWindowsNetworkMode tests the environment override for the sandbox
+// network.
+func TestWindowsNetworkMode(t *testing.T) {
+	assert.Equal(t, defaultWindowsNetworkMode, windowsNetworkMode())
+	os.Setenv(windowsNetworkModeEnv, "l2bridge")
+	defer os.Unsetenv(windowsNetworkModeEnv)
+	assert.Equal(t, "l2bridge", windowsNetworkMode())
+}
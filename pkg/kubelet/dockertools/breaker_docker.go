@@ -0,0 +1,410 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockertools
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	dockerapi "github.com/docker/engine-api/client"
+	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/golang/glog"
+	"k8s.io/client-go/util/clock"
+)
+
+const (
+	// Number of consecutive timeouts or connection failures after which the
+	// circuit breaker opens.
+	breakerFailureThreshold = 3
+	// How long the breaker stays open before the daemon is probed again.
+	breakerProbeInterval = 10 * time.Second
+)
+
+// daemonUnavailable is the error returned for all operations while the
+// circuit breaker is open.
+type daemonUnavailable struct {
+	err error
+}
+
+func (e daemonUnavailable) Error() string {
+	return fmt.Sprintf("docker daemon is unavailable: %v", e.err)
+}
+
+// IsDaemonUnavailableError checks whether the error was returned by the
+// circuit breaker because the docker daemon is considered unavailable.
+func IsDaemonUnavailableError(err error) bool {
+	_, ok := err.(daemonUnavailable)
+	return ok
+}
+
+// isDaemonFailure checks whether an operation error indicates the daemon
+// itself is unhealthy, as opposed to the operation merely failing.
+func isDaemonFailure(err error) bool {
+	if _, ok := err.(operationTimeout); ok {
+		return true
+	}
+	return err == dockerapi.ErrConnectionFailed
+}
+
+// circuitBreakerDockerInterface wraps the DockerInterface with a circuit
+// breaker. After breakerFailureThreshold consecutive timeouts or connection
+// failures the breaker opens and all operations fail fast with a
+// daemonUnavailable error, so that a hung daemon does not block callers such
+// as the kubelet's node status loop. While open, the daemon is probed with a
+// version call at most once per breakerProbeInterval; the breaker closes
+// when a probe succeeds.
+type circuitBreakerDockerInterface struct {
+	client DockerInterface
+
+	lock        sync.Mutex
+	clock       clock.Clock
+	failures    int
+	open        bool
+	lastFailure error
+	lastProbe   time.Time
+}
+
+// NewCircuitBreakerDockerInterface creates a circuit-breaking
+// DockerInterface from an existing DockerInterface.
+func NewCircuitBreakerDockerInterface(dockerClient DockerInterface) DockerInterface {
+	return &circuitBreakerDockerInterface{
+		client: dockerClient,
+		clock:  clock.RealClock{},
+	}
+}
+
+// allow decides whether an operation may proceed, probing the daemon when
+// the breaker has been open for at least breakerProbeInterval.
+func (b *circuitBreakerDockerInterface) allow() error {
+	b.lock.Lock()
+	if !b.open {
+		b.lock.Unlock()
+		return nil
+	}
+	if b.clock.Since(b.lastProbe) < breakerProbeInterval {
+		defer b.lock.Unlock()
+		return daemonUnavailable{err: b.lastFailure}
+	}
+	b.lastProbe = b.clock.Now()
+	b.lock.Unlock()
+
+	// Probe the daemon outside the lock; the version call has a timeout of
+	// its own.
+	if _, err := b.client.Version(); err != nil {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		b.lastFailure = err
+		return daemonUnavailable{err: err}
+	}
+	glog.Infof("Docker daemon responded to health check, closing circuit breaker")
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.open = false
+	b.failures = 0
+	return nil
+}
+
+// observe records the result of an operation, opening the breaker when the
+// daemon failed too many operations in a row.
+func (b *circuitBreakerDockerInterface) observe(err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err == nil || !isDaemonFailure(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	b.lastFailure = err
+	if !b.open && b.failures >= breakerFailureThreshold {
+		glog.Warningf("Docker daemon failed %d consecutive operations, opening circuit breaker: %v", b.failures, err)
+		b.open = true
+		b.lastProbe = b.clock.Now()
+	}
+}
+
+func (b *circuitBreakerDockerInterface) ListContainers(options dockertypes.ContainerListOptions) ([]dockertypes.Container, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.ListContainers(options)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) InspectContainer(id string) (*dockertypes.ContainerJSON, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.InspectContainer(id)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) InspectContainerWithSize(id string) (*dockertypes.ContainerJSON, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.InspectContainerWithSize(id)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) CreateContainer(opts dockertypes.ContainerCreateConfig) (*dockertypes.ContainerCreateResponse, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.CreateContainer(opts)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) StartContainer(id string) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.StartContainer(id)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) StopContainer(id string, timeout int) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.StopContainer(id, timeout)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) StartContainerFromCheckpoint(id string, checkpointID string, checkpointDir string) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.StartContainerFromCheckpoint(id, checkpointID, checkpointDir)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) CheckpointContainer(id string, opts dockertypes.CheckpointCreateOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.CheckpointContainer(id, opts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) ListCheckpoints(id string, opts dockertypes.CheckpointListOptions) ([]dockertypes.Checkpoint, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.ListCheckpoints(id, opts)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) RemoveCheckpoint(id string, opts dockertypes.CheckpointDeleteOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.RemoveCheckpoint(id, opts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) KillContainer(id string, signal string) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.KillContainer(id, signal)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) RemoveContainer(id string, opts dockertypes.ContainerRemoveOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.RemoveContainer(id, opts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) UpdateContainerResources(id string, updateConfig dockercontainer.UpdateConfig) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.UpdateContainerResources(id, updateConfig)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) InspectImageByRef(image string) (*dockertypes.ImageInspect, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.InspectImageByRef(image)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) InspectImageByID(image string) (*dockertypes.ImageInspect, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.InspectImageByID(image)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) ListImages(opts dockertypes.ImageListOptions) ([]dockertypes.Image, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.ListImages(opts)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) PullImage(image string, auth dockertypes.AuthConfig, opts dockertypes.ImagePullOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.PullImage(image, auth, opts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) TagImage(image string, ref string) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.TagImage(image, ref)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) RemoveImage(image string, opts dockertypes.ImageRemoveOptions) ([]dockertypes.ImageDelete, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.RemoveImage(image, opts)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) ImageHistory(id string) ([]dockertypes.ImageHistory, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.ImageHistory(id)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) Logs(id string, opts dockertypes.ContainerLogsOptions, sopts StreamOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.Logs(id, opts, sopts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) Events(options dockertypes.EventsOptions) (io.ReadCloser, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.Events(options)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) Version() (*dockertypes.Version, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.Version()
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) Info() (*dockertypes.Info, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.Info()
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) CreateExec(id string, opts dockertypes.ExecConfig) (*dockertypes.ContainerExecCreateResponse, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.CreateExec(id, opts)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) StartExec(startExec string, opts dockertypes.ExecStartCheck, sopts StreamOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.StartExec(startExec, opts, sopts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) InspectExec(id string) (*dockertypes.ContainerExecInspect, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	out, err := b.client.InspectExec(id)
+	b.observe(err)
+	return out, err
+}
+
+func (b *circuitBreakerDockerInterface) AttachToContainer(id string, opts dockertypes.ContainerAttachOptions, sopts StreamOptions) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.AttachToContainer(id, opts, sopts)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) ResizeContainerTTY(id string, height, width int) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.ResizeContainerTTY(id, height, width)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreakerDockerInterface) ResizeExecTTY(id string, height, width int) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := b.client.ResizeExecTTY(id, height, width)
+	b.observe(err)
+	return err
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockertools
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dockertypes "github.com/docker/engine-api/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/clock"
+)
+
+func newTestCircuitBreaker() (*circuitBreakerDockerInterface, *FakeDockerClient, *clock.FakeClock) {
+	fake := NewFakeDockerClient()
+	fakeClock := clock.NewFakeClock(time.Now())
+	b := NewCircuitBreakerDockerInterface(fake).(*circuitBreakerDockerInterface)
+	b.clock = fakeClock
+	return b, fake, fakeClock
+}
+
+// tripBreaker opens the circuit breaker with consecutive timeouts.
+func tripBreaker(t *testing.T, b *circuitBreakerDockerInterface, fake *FakeDockerClient) {
+	timeoutErr := operationTimeout{err: fmt.Errorf("context deadline exceeded")}
+	for i := 0; i < breakerFailureThreshold; i++ {
+		fake.InjectError("list", timeoutErr)
+		_, err := b.ListContainers(dockertypes.ContainerListOptions{})
+		assert.Equal(t, timeoutErr, err)
+	}
+	assert.True(t, b.open, "expected the breaker to be open")
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	b, fake, fakeClock := newTestCircuitBreaker()
+	tripBreaker(t, b, fake)
+
+	// While open, operations fail fast without reaching the daemon.
+	fake.ClearCalls()
+	_, err := b.ListContainers(dockertypes.ContainerListOptions{})
+	assert.True(t, IsDaemonUnavailableError(err), "expected daemon unavailable error, got %v", err)
+	assert.NoError(t, fake.AssertCalls([]string{}))
+
+	// After the probe interval the daemon is probed and, being responsive
+	// again, the breaker closes and the operation goes through.
+	fakeClock.Step(2 * breakerProbeInterval)
+	_, err = b.ListContainers(dockertypes.ContainerListOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, fake.AssertCalls([]string{"list"}))
+	assert.False(t, b.open, "expected the breaker to be closed")
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	b, fake, fakeClock := newTestCircuitBreaker()
+	tripBreaker(t, b, fake)
+
+	// The daemon is still down when the probe fires; the breaker stays open
+	// and the next operation fails fast without probing again.
+	fakeClock.Step(2 * breakerProbeInterval)
+	probeErr := operationTimeout{err: fmt.Errorf("context deadline exceeded")}
+	fake.InjectError("version", probeErr)
+	fake.ClearCalls()
+	_, err := b.ListContainers(dockertypes.ContainerListOptions{})
+	assert.True(t, IsDaemonUnavailableError(err), "expected daemon unavailable error, got %v", err)
+	assert.Equal(t, probeErr, b.lastFailure)
+	_, err = b.ListContainers(dockertypes.ContainerListOptions{})
+	assert.True(t, IsDaemonUnavailableError(err), "expected daemon unavailable error, got %v", err)
+	// The operations failed fast without reaching the daemon.
+	assert.NoError(t, fake.AssertCalls([]string{}))
+}
+
+func TestCircuitBreakerIgnoresOperationErrors(t *testing.T) {
+	b, fake, _ := newTestCircuitBreaker()
+
+	// Ordinary operation failures do not indicate daemon health and never
+	// open the breaker.
+	for i := 0; i < breakerFailureThreshold+1; i++ {
+		fake.InjectError("list", fmt.Errorf("random error"))
+		_, err := b.ListContainers(dockertypes.ContainerListOptions{})
+		assert.Error(t, err)
+		assert.False(t, IsDaemonUnavailableError(err))
+	}
+	assert.False(t, b.open, "expected the breaker to be closed")
+}
+
+func TestCircuitBreakerResetsOnSuccess(t *testing.T) {
+	b, fake, _ := newTestCircuitBreaker()
+
+	// A successful operation resets the consecutive failure count.
+	timeoutErr := operationTimeout{err: fmt.Errorf("context deadline exceeded")}
+	for i := 0; i < breakerFailureThreshold-1; i++ {
+		fake.InjectError("list", timeoutErr)
+		_, err := b.ListContainers(dockertypes.ContainerListOptions{})
+		assert.Error(t, err)
+	}
+	_, err := b.ListContainers(dockertypes.ContainerListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, b.failures)
+}
@@ -18,6 +18,7 @@ package dockertools
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"path"
@@ -30,6 +31,7 @@ import (
 	"github.com/docker/docker/pkg/jsonmessage"
 	dockerapi "github.com/docker/engine-api/client"
 	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
 	"github.com/golang/glog"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -52,17 +54,26 @@ const (
 type DockerInterface interface {
 	ListContainers(options dockertypes.ContainerListOptions) ([]dockertypes.Container, error)
 	InspectContainer(id string) (*dockertypes.ContainerJSON, error)
+	InspectContainerWithSize(id string) (*dockertypes.ContainerJSON, error)
 	CreateContainer(dockertypes.ContainerCreateConfig) (*dockertypes.ContainerCreateResponse, error)
 	StartContainer(id string) error
+	StartContainerFromCheckpoint(id string, checkpointID string, checkpointDir string) error
 	StopContainer(id string, timeout int) error
+	KillContainer(id string, signal string) error
 	RemoveContainer(id string, opts dockertypes.ContainerRemoveOptions) error
+	CheckpointContainer(id string, opts dockertypes.CheckpointCreateOptions) error
+	ListCheckpoints(id string, opts dockertypes.CheckpointListOptions) ([]dockertypes.Checkpoint, error)
+	RemoveCheckpoint(id string, opts dockertypes.CheckpointDeleteOptions) error
+	UpdateContainerResources(id string, updateConfig dockercontainer.UpdateConfig) error
 	InspectImageByRef(imageRef string) (*dockertypes.ImageInspect, error)
 	InspectImageByID(imageID string) (*dockertypes.ImageInspect, error)
 	ListImages(opts dockertypes.ImageListOptions) ([]dockertypes.Image, error)
 	PullImage(image string, auth dockertypes.AuthConfig, opts dockertypes.ImagePullOptions) error
+	TagImage(image string, ref string) error
 	RemoveImage(image string, opts dockertypes.ImageRemoveOptions) ([]dockertypes.ImageDelete, error)
 	ImageHistory(id string) ([]dockertypes.ImageHistory, error)
 	Logs(string, dockertypes.ContainerLogsOptions, StreamOptions) error
+	Events(options dockertypes.EventsOptions) (io.ReadCloser, error)
 	Version() (*dockertypes.Version, error)
 	Info() (*dockertypes.Info, error)
 	CreateExec(string, dockertypes.ExecConfig) (*dockertypes.ContainerExecCreateResponse, error)
@@ -19,6 +19,7 @@ package dockertools
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"reflect"
@@ -59,16 +60,24 @@ type FakeDockerClient struct {
 	EnableTrace          bool
 
 	// Created, Started, Stopped and Removed all contain container docker ID
-	Created         []string
-	Started         []string
-	Stopped         []string
-	Removed         []string
+	Created []string
+	Started []string
+	Stopped []string
+	Removed []string
+	// Signals maps container docker ID to the signals sent to it via KillContainer.
+	Signals map[string][]string
+	// Checkpoints maps container docker ID to the names of the checkpoints
+	// created from it.
+	Checkpoints     map[string][]string
 	VersionInfo     dockertypes.Version
 	Information     dockertypes.Info
 	ExecInspect     *dockertypes.ContainerExecInspect
 	execCmd         []string
 	EnableSleep     bool
 	ImageHistoryMap map[string][]dockertypes.ImageHistory
+	// EventsWriter is the write end of the stream returned by the last
+	// Events call. Tests can write raw docker events to it.
+	EventsWriter *io.PipeWriter
 }
 
 // We don't check docker version now, just set the docker version of fake docker client to 1.8.1.
@@ -396,6 +405,24 @@ func (f *FakeDockerClient) InspectContainer(id string) (*dockertypes.ContainerJS
 	return nil, fmt.Errorf("container %q not found", id)
 }
 
+// InspectContainerWithSize is a test-spy implementation of
+// DockerInterface.InspectContainerWithSize. It adds an entry
+// "inspect_container_withsize" to the internal method call record.
+func (f *FakeDockerClient) InspectContainerWithSize(id string) (*dockertypes.ContainerJSON, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "inspect_container_withsize"})
+	err := f.popError("inspect_container_withsize")
+	if container, ok := f.ContainerMap[id]; ok {
+		return container, err
+	}
+	if err != nil {
+		// Use the custom error if it exists.
+		return nil, err
+	}
+	return nil, fmt.Errorf("container %q not found", id)
+}
+
 // InspectImageByRef is a test-spy implementation of DockerInterface.InspectImageByRef.
 // It adds an entry "inspect" to the internal method call record.
 func (f *FakeDockerClient) InspectImageByRef(name string) (*dockertypes.ImageInspect, error) {
@@ -454,6 +481,25 @@ func (f *FakeDockerClient) CreateContainer(c dockertypes.ContainerCreateConfig)
 	return &dockertypes.ContainerCreateResponse{ID: id}, nil
 }
 
+// UpdateContainerResources is a test-spy implementation of
+// DockerInterface.UpdateContainerResources. It applies the cpuset fields of
+// the update to the stored container.
+func (f *FakeDockerClient) UpdateContainerResources(id string, updateConfig dockercontainer.UpdateConfig) error {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "update_container"})
+	if err := f.popError("update_container"); err != nil {
+		return err
+	}
+	container, ok := f.ContainerMap[id]
+	if !ok {
+		return fmt.Errorf("container %q not found", id)
+	}
+	container.HostConfig.CpusetCpus = updateConfig.CpusetCpus
+	container.HostConfig.CpusetMems = updateConfig.CpusetMems
+	return nil
+}
+
 // StartContainer is a test-spy implementation of DockerInterface.StartContainer.
 // It adds an entry "start" to the internal method call record.
 func (f *FakeDockerClient) StartContainer(id string) error {
@@ -478,6 +524,82 @@ func (f *FakeDockerClient) StartContainer(id string) error {
 	return nil
 }
 
+func (f *FakeDockerClient) StartContainerFromCheckpoint(id string, checkpointID string, checkpointDir string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "start_checkpoint"})
+	if err := f.popError("start_checkpoint"); err != nil {
+		return err
+	}
+	found := false
+	for _, name := range f.Checkpoints[id] {
+		if name == checkpointID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("checkpoint %s does not exist for container %s", checkpointID, id)
+	}
+	f.appendContainerTrace("Started", id)
+	container, ok := f.ContainerMap[id]
+	if !ok {
+		container = convertFakeContainer(&FakeContainer{ID: id, Name: id})
+	}
+	container.State.Running = true
+	container.State.Pid = os.Getpid()
+	container.State.StartedAt = dockerTimestampToString(f.Clock.Now())
+	container.NetworkSettings.IPAddress = "2.3.4.5"
+	f.ContainerMap[id] = container
+	f.updateContainerStatus(id, statusRunningPrefix)
+	return nil
+}
+
+func (f *FakeDockerClient) CheckpointContainer(id string, opts dockertypes.CheckpointCreateOptions) error {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "checkpoint_create"})
+	if err := f.popError("checkpoint_create"); err != nil {
+		return err
+	}
+	if f.Checkpoints == nil {
+		f.Checkpoints = make(map[string][]string)
+	}
+	f.Checkpoints[id] = append(f.Checkpoints[id], opts.CheckpointID)
+	return nil
+}
+
+func (f *FakeDockerClient) ListCheckpoints(id string, opts dockertypes.CheckpointListOptions) ([]dockertypes.Checkpoint, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "checkpoint_list"})
+	if err := f.popError("checkpoint_list"); err != nil {
+		return nil, err
+	}
+	var checkpoints []dockertypes.Checkpoint
+	for _, name := range f.Checkpoints[id] {
+		checkpoints = append(checkpoints, dockertypes.Checkpoint{Name: name})
+	}
+	return checkpoints, nil
+}
+
+func (f *FakeDockerClient) RemoveCheckpoint(id string, opts dockertypes.CheckpointDeleteOptions) error {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "checkpoint_delete"})
+	if err := f.popError("checkpoint_delete"); err != nil {
+		return err
+	}
+	var kept []string
+	for _, name := range f.Checkpoints[id] {
+		if name != opts.CheckpointID {
+			kept = append(kept, name)
+		}
+	}
+	f.Checkpoints[id] = kept
+	return nil
+}
+
 // StopContainer is a test-spy implementation of DockerInterface.StopContainer.
 // It adds an entry "stop" to the internal method call record.
 func (f *FakeDockerClient) StopContainer(id string, timeout int) error {
@@ -518,6 +640,20 @@ func (f *FakeDockerClient) StopContainer(id string, timeout int) error {
 	return nil
 }
 
+func (f *FakeDockerClient) KillContainer(id string, signal string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "kill"})
+	if err := f.popError("kill"); err != nil {
+		return err
+	}
+	if f.Signals == nil {
+		f.Signals = make(map[string][]string)
+	}
+	f.Signals[id] = append(f.Signals[id], signal)
+	return nil
+}
+
 func (f *FakeDockerClient) RemoveContainer(id string, opts dockertypes.ContainerRemoveOptions) error {
 	f.Lock()
 	defer f.Unlock()
@@ -548,6 +684,18 @@ func (f *FakeDockerClient) Logs(id string, opts dockertypes.ContainerLogsOptions
 	return f.popError("logs")
 }
 
+func (f *FakeDockerClient) Events(opts dockertypes.EventsOptions) (io.ReadCloser, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "events"})
+	if err := f.popError("events"); err != nil {
+		return nil, err
+	}
+	r, w := io.Pipe()
+	f.EventsWriter = w
+	return r, nil
+}
+
 // PullImage is a test-spy implementation of DockerInterface.PullImage.
 // It adds an entry "pull" to the internal method call record.
 func (f *FakeDockerClient) PullImage(image string, auth dockertypes.AuthConfig, opts dockertypes.ImagePullOptions) error {
@@ -566,6 +714,17 @@ func (f *FakeDockerClient) PullImage(image string, auth dockertypes.AuthConfig,
 	return err
 }
 
+func (f *FakeDockerClient) TagImage(image string, ref string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.appendCalled(calledDetail{name: "tag"})
+	err := f.popError("tag")
+	if err == nil && f.Image != nil {
+		f.Image.RepoTags = append(f.Image.RepoTags, ref)
+	}
+	return err
+}
+
 func (f *FakeDockerClient) Version() (*dockertypes.Version, error) {
 	f.Lock()
 	defer f.Unlock()
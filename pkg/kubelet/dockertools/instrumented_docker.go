@@ -17,9 +17,11 @@ limitations under the License.
 package dockertools
 
 import (
+	"io"
 	"time"
 
 	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
 )
 
@@ -71,6 +73,15 @@ func (in instrumentedDockerInterface) InspectContainer(id string) (*dockertypes.
 	return out, err
 }
 
+func (in instrumentedDockerInterface) InspectContainerWithSize(id string) (*dockertypes.ContainerJSON, error) {
+	const operation = "inspect_container_withsize"
+	defer recordOperation(operation, time.Now())
+
+	out, err := in.client.InspectContainerWithSize(id)
+	recordError(operation, err)
+	return out, err
+}
+
 func (in instrumentedDockerInterface) CreateContainer(opts dockertypes.ContainerCreateConfig) (*dockertypes.ContainerCreateResponse, error) {
 	const operation = "create_container"
 	defer recordOperation(operation, time.Now())
@@ -98,6 +109,51 @@ func (in instrumentedDockerInterface) StopContainer(id string, timeout int) erro
 	return err
 }
 
+func (in instrumentedDockerInterface) StartContainerFromCheckpoint(id string, checkpointID string, checkpointDir string) error {
+	const operation = "start_container_from_checkpoint"
+	defer recordOperation(operation, time.Now())
+
+	err := in.client.StartContainerFromCheckpoint(id, checkpointID, checkpointDir)
+	recordError(operation, err)
+	return err
+}
+
+func (in instrumentedDockerInterface) CheckpointContainer(id string, opts dockertypes.CheckpointCreateOptions) error {
+	const operation = "checkpoint_container"
+	defer recordOperation(operation, time.Now())
+
+	err := in.client.CheckpointContainer(id, opts)
+	recordError(operation, err)
+	return err
+}
+
+func (in instrumentedDockerInterface) ListCheckpoints(id string, opts dockertypes.CheckpointListOptions) ([]dockertypes.Checkpoint, error) {
+	const operation = "list_checkpoints"
+	defer recordOperation(operation, time.Now())
+
+	out, err := in.client.ListCheckpoints(id, opts)
+	recordError(operation, err)
+	return out, err
+}
+
+func (in instrumentedDockerInterface) RemoveCheckpoint(id string, opts dockertypes.CheckpointDeleteOptions) error {
+	const operation = "remove_checkpoint"
+	defer recordOperation(operation, time.Now())
+
+	err := in.client.RemoveCheckpoint(id, opts)
+	recordError(operation, err)
+	return err
+}
+
+func (in instrumentedDockerInterface) KillContainer(id string, signal string) error {
+	const operation = "kill_container"
+	defer recordOperation(operation, time.Now())
+
+	err := in.client.KillContainer(id, signal)
+	recordError(operation, err)
+	return err
+}
+
 func (in instrumentedDockerInterface) RemoveContainer(id string, opts dockertypes.ContainerRemoveOptions) error {
 	const operation = "remove_container"
 	defer recordOperation(operation, time.Now())
@@ -107,6 +163,15 @@ func (in instrumentedDockerInterface) RemoveContainer(id string, opts dockertype
 	return err
 }
 
+func (in instrumentedDockerInterface) UpdateContainerResources(id string, updateConfig dockercontainer.UpdateConfig) error {
+	const operation = "update_container"
+	defer recordOperation(operation, time.Now())
+
+	err := in.client.UpdateContainerResources(id, updateConfig)
+	recordError(operation, err)
+	return err
+}
+
 func (in instrumentedDockerInterface) InspectImageByRef(image string) (*dockertypes.ImageInspect, error) {
 	const operation = "inspect_image"
 	defer recordOperation(operation, time.Now())
@@ -142,6 +207,14 @@ func (in instrumentedDockerInterface) PullImage(imageID string, auth dockertypes
 	return err
 }
 
+func (in instrumentedDockerInterface) TagImage(image string, ref string) error {
+	const operation = "tag_image"
+	defer recordOperation(operation, time.Now())
+	err := in.client.TagImage(image, ref)
+	recordError(operation, err)
+	return err
+}
+
 func (in instrumentedDockerInterface) RemoveImage(image string, opts dockertypes.ImageRemoveOptions) ([]dockertypes.ImageDelete, error) {
 	const operation = "remove_image"
 	defer recordOperation(operation, time.Now())
@@ -169,6 +242,15 @@ func (in instrumentedDockerInterface) Version() (*dockertypes.Version, error) {
 	return out, err
 }
 
+func (in instrumentedDockerInterface) Events(options dockertypes.EventsOptions) (io.ReadCloser, error) {
+	const operation = "events"
+	defer recordOperation(operation, time.Now())
+
+	out, err := in.client.Events(options)
+	recordError(operation, err)
+	return out, err
+}
+
 func (in instrumentedDockerInterface) Info() (*dockertypes.Info, error) {
 	const operation = "info"
 	defer recordOperation(operation, time.Now())
@@ -33,6 +33,8 @@ import (
 	dockerstdcopy "github.com/docker/docker/pkg/stdcopy"
 	dockerapi "github.com/docker/engine-api/client"
 	dockertypes "github.com/docker/engine-api/types"
+	dockercontainer "github.com/docker/engine-api/types/container"
+	"github.com/docker/go-units"
 	"golang.org/x/net/context"
 )
 
@@ -129,6 +131,21 @@ func (d *kubeDockerClient) InspectContainer(id string) (*dockertypes.ContainerJS
 	return &containerJSON, nil
 }
 
+// InspectContainerWithSize is like InspectContainer, but also asks the daemon
+// to compute the size of the container's writable layer and root filesystem.
+func (d *kubeDockerClient) InspectContainerWithSize(id string) (*dockertypes.ContainerJSON, error) {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	containerJSON, _, err := d.client.ContainerInspectWithRaw(ctx, id, true)
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &containerJSON, nil
+}
+
 func (d *kubeDockerClient) CreateContainer(opts dockertypes.ContainerCreateConfig) (*dockertypes.ContainerCreateResponse, error) {
 	ctx, cancel := d.getTimeoutContext()
 	defer cancel()
@@ -150,7 +167,50 @@ func (d *kubeDockerClient) CreateContainer(opts dockertypes.ContainerCreateConfi
 func (d *kubeDockerClient) StartContainer(id string) error {
 	ctx, cancel := d.getTimeoutContext()
 	defer cancel()
-	err := d.client.ContainerStart(ctx, id)
+	err := d.client.ContainerStart(ctx, id, dockertypes.ContainerStartOptions{})
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func (d *kubeDockerClient) StartContainerFromCheckpoint(id string, checkpointID string, checkpointDir string) error {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	err := d.client.ContainerStart(ctx, id, dockertypes.ContainerStartOptions{
+		CheckpointID:  checkpointID,
+		CheckpointDir: checkpointDir,
+	})
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func (d *kubeDockerClient) CheckpointContainer(id string, opts dockertypes.CheckpointCreateOptions) error {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	err := d.client.CheckpointCreate(ctx, id, opts)
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func (d *kubeDockerClient) ListCheckpoints(id string, opts dockertypes.CheckpointListOptions) ([]dockertypes.Checkpoint, error) {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	checkpoints, err := d.client.CheckpointList(ctx, id, opts)
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return checkpoints, err
+}
+
+func (d *kubeDockerClient) RemoveCheckpoint(id string, opts dockertypes.CheckpointDeleteOptions) error {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	err := d.client.CheckpointDelete(ctx, id, opts)
 	if ctxErr := contextError(ctx); ctxErr != nil {
 		return ctxErr
 	}
@@ -168,6 +228,16 @@ func (d *kubeDockerClient) StopContainer(id string, timeout int) error {
 	return err
 }
 
+func (d *kubeDockerClient) KillContainer(id string, signal string) error {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	err := d.client.ContainerKill(ctx, id, signal)
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
 func (d *kubeDockerClient) RemoveContainer(id string, opts dockertypes.ContainerRemoveOptions) error {
 	ctx, cancel := d.getTimeoutContext()
 	defer cancel()
@@ -178,6 +248,16 @@ func (d *kubeDockerClient) RemoveContainer(id string, opts dockertypes.Container
 	return err
 }
 
+func (d *kubeDockerClient) UpdateContainerResources(id string, updateConfig dockercontainer.UpdateConfig) error {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	err := d.client.ContainerUpdate(ctx, id, updateConfig)
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
 func (d *kubeDockerClient) inspectImageRaw(ref string) (*dockertypes.ImageInspect, error) {
 	ctx, cancel := d.getTimeoutContext()
 	defer cancel()
@@ -250,6 +330,22 @@ func base64EncodeAuth(auth dockertypes.AuthConfig) (string, error) {
 	return base64.URLEncoding.EncodeToString(buf.Bytes()), nil
 }
 
+// layerProgress is the pull progress of a single layer of an image.
+type layerProgress struct {
+	// status is the latest docker status of the layer, e.g. "Downloading" or
+	// "Pull complete".
+	status string
+	// current and total are the downloaded and full size of the layer in
+	// bytes, when known.
+	current int64
+	total   int64
+}
+
+// complete checks whether the layer needs no further downloading.
+func (l *layerProgress) complete() bool {
+	return l.status == "Pull complete" || l.status == "Already exists"
+}
+
 // progress is a wrapper of dockermessage.JSONMessage with a lock protecting it.
 type progress struct {
 	sync.RWMutex
@@ -257,10 +353,12 @@ type progress struct {
 	message *dockermessage.JSONMessage
 	// timestamp of the latest update.
 	timestamp time.Time
+	// layers stores the per-layer progress seen so far, keyed by layer ID.
+	layers map[string]*layerProgress
 }
 
 func newProgress() *progress {
-	return &progress{timestamp: time.Now()}
+	return &progress{timestamp: time.Now(), layers: map[string]*layerProgress{}}
 }
 
 func (p *progress) set(msg *dockermessage.JSONMessage) {
@@ -268,6 +366,20 @@ func (p *progress) set(msg *dockermessage.JSONMessage) {
 	defer p.Unlock()
 	p.message = msg
 	p.timestamp = time.Now()
+	// Messages with an ID describe a single layer of the image.
+	if msg.ID == "" {
+		return
+	}
+	layer, ok := p.layers[msg.ID]
+	if !ok {
+		layer = &layerProgress{}
+		p.layers[msg.ID] = layer
+	}
+	layer.status = msg.Status
+	if msg.Progress != nil {
+		layer.current = msg.Progress.Current
+		layer.total = msg.Progress.Total
+	}
 }
 
 func (p *progress) get() (string, time.Time) {
@@ -287,6 +399,28 @@ func (p *progress) get() (string, time.Time) {
 	return fmt.Sprintf("%s%s %s", prefix, p.message.Status, p.message.Progress.String()), p.timestamp
 }
 
+// summary returns an aggregated view of the pull so far: the number of
+// layers still to be pulled and the bytes downloaded. It returns an empty
+// string before any layer has been reported.
+func (p *progress) summary() string {
+	p.RLock()
+	defer p.RUnlock()
+	if len(p.layers) == 0 {
+		return ""
+	}
+	var current, total int64
+	remaining := 0
+	for _, layer := range p.layers {
+		current += layer.current
+		total += layer.total
+		if !layer.complete() {
+			remaining++
+		}
+	}
+	return fmt.Sprintf("%d of %d layers remaining, %s of %s downloaded",
+		remaining, len(p.layers), units.HumanSize(float64(current)), units.HumanSize(float64(total)))
+}
+
 // progressReporter keeps the newest image pulling progress and periodically report the newest progress.
 type progressReporter struct {
 	*progress
@@ -323,7 +457,11 @@ func (p *progressReporter) start() {
 					p.cancel()
 					return
 				}
-				glog.V(2).Infof("Pulling image %q: %q", p.image, progress)
+				if summary := p.progress.summary(); summary != "" {
+					glog.V(2).Infof("Pulling image %q (%s): %q", p.image, summary, progress)
+				} else {
+					glog.V(2).Infof("Pulling image %q: %q", p.image, progress)
+				}
 			case <-p.stopCh:
 				progress, _ := p.progress.get()
 				glog.V(2).Infof("Stop pulling image %q: %q", p.image, progress)
@@ -373,6 +511,16 @@ func (d *kubeDockerClient) PullImage(image string, auth dockertypes.AuthConfig,
 	return nil
 }
 
+func (d *kubeDockerClient) TagImage(image string, ref string) error {
+	ctx, cancel := d.getTimeoutContext()
+	defer cancel()
+	err := d.client.ImageTag(ctx, image, ref, dockertypes.ImageTagOptions{})
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
 func (d *kubeDockerClient) RemoveImage(image string, opts dockertypes.ImageRemoveOptions) ([]dockertypes.ImageDelete, error) {
 	ctx, cancel := d.getTimeoutContext()
 	defer cancel()
@@ -397,6 +545,30 @@ func (d *kubeDockerClient) Logs(id string, opts dockertypes.ContainerLogsOptions
 	return d.redirectResponseToOutputStream(sopts.RawTerminal, sopts.OutputStream, sopts.ErrorStream, resp)
 }
 
+// Events returns a stream of raw docker daemon events. Closing the returned
+// ReadCloser cancels the underlying request.
+func (d *kubeDockerClient) Events(options dockertypes.EventsOptions) (io.ReadCloser, error) {
+	ctx, cancel := d.getCancelableContext()
+	resp, err := d.client.Events(ctx, options)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelableReadCloser{ReadCloser: resp, cancel: cancel}, nil
+}
+
+// cancelableReadCloser cancels the context of a long running request when
+// the response stream is closed.
+type cancelableReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelableReadCloser) Close() error {
+	c.cancel()
+	return c.ReadCloser.Close()
+}
+
 func (d *kubeDockerClient) Version() (*dockertypes.Version, error) {
 	ctx, cancel := d.getTimeoutContext()
 	defer cancel()
@@ -605,6 +777,13 @@ func (e operationTimeout) Error() string {
 	return fmt.Sprintf("operation timeout: %v", e.err)
 }
 
+// IsOperationTimeoutError checks whether the error is an operation timeout
+// error. This is exposed to share with dockershim.
+func IsOperationTimeoutError(err error) bool {
+	_, ok := err.(operationTimeout)
+	return ok
+}
+
 // containerNotFoundErrorRegx is the regexp of container not found error message.
 var containerNotFoundErrorRegx = regexp.MustCompile(`No such container: [0-9a-z]+`)
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	dockermessage "github.com/docker/docker/pkg/jsonmessage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,3 +32,18 @@ func TestIsContainerNotFoundError(t *testing.T) {
 	assert.True(t, IsContainerNotFoundError(containerNotFoundError))
 	assert.False(t, IsContainerNotFoundError(otherError))
 }
+
+func TestPullProgressSummary(t *testing.T) {
+	p := newProgress()
+	// No summary before any layer has been reported.
+	assert.Equal(t, "", p.summary())
+	p.set(&dockermessage.JSONMessage{Status: "Pulling from library/foo"})
+	assert.Equal(t, "", p.summary())
+
+	p.set(&dockermessage.JSONMessage{ID: "a", Status: "Downloading", Progress: &dockermessage.JSONProgress{Current: 50, Total: 100}})
+	p.set(&dockermessage.JSONMessage{ID: "b", Status: "Already exists"})
+	p.set(&dockermessage.JSONMessage{ID: "c", Status: "Downloading", Progress: &dockermessage.JSONProgress{Current: 200, Total: 400}})
+	p.set(&dockermessage.JSONMessage{ID: "c", Status: "Pull complete", Progress: &dockermessage.JSONProgress{Current: 400, Total: 400}})
+
+	assert.Equal(t, "1 of 3 layers remaining, 450 B of 500 B downloaded", p.summary())
+}
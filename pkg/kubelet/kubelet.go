@@ -146,6 +146,11 @@ const (
 	// and/or when there are many container changes in one cycle.
 	plegRelistPeriod = time.Second * 1
 
+	// Evented PLEG relists only when the runtime shim reports a container
+	// event, so its periodic relist is just a backstop for event gaps and can
+	// be much slower. It must stay well below the PLEG health threshold.
+	plegRelistBackstopPeriod = time.Minute * 1
+
 	// backOffPeriod is the period to back off when pod syncing results in an
 	// error. It is also used as the base period for the exponential backoff
 	// container restarts and image pulls.
@@ -540,6 +545,9 @@ func NewMainKubelet(kubeCfg *componentconfig.KubeletConfiguration, kubeDeps *Kub
 	var nl *noOpLegacyHost
 	pluginSettings.LegacyRuntimeHost = nl
 
+	// Set when the runtime shim can push container events to the PLEG.
+	var plegEventSubscriber pleg.ContainerEventSubscriber
+
 	// rktnetes cannot be run with CRI.
 	if kubeCfg.ContainerRuntime != "rkt" && kubeCfg.EnableCRI {
 		// kubelet defers to the runtime shim to setup networking. Setting
@@ -552,8 +560,36 @@ func NewMainKubelet(kubeCfg *componentconfig.KubeletConfiguration, kubeDeps *Kub
 		case "docker":
 			// Create and start the CRI shim running as a grpc server.
 			streamingConfig := getStreamingConfig(kubeCfg, kubeDeps)
-			ds, err := dockershim.NewDockerService(klet.dockerClient, kubeCfg.SeccompProfileRoot, kubeCfg.PodInfraContainerImage,
-				streamingConfig, &pluginSettings, kubeCfg.RuntimeCgroups, kubeCfg.CgroupDriver, dockerExecHandler)
+			ds, err := dockershim.NewDockerService(&dockershim.Config{
+				Client:                  klet.dockerClient,
+				SeccompProfileRoot:      kubeCfg.SeccompProfileRoot,
+				PodSandboxImage:         kubeCfg.PodInfraContainerImage,
+				StreamingConfig:         streamingConfig,
+				PluginSettings:          &pluginSettings,
+				CgroupsName:             kubeCfg.RuntimeCgroups,
+				CgroupDriver:            kubeCfg.CgroupDriver,
+				ExecHandler:             dockerExecHandler,
+				FeatureGates:            kubeCfg.DockershimFeatureGates,
+				OOMPolicy:               kubeCfg.DockershimOOMPolicy,
+				SecurityProfile:         kubeCfg.DockershimSecurityProfile,
+				HostConfigExtensions:    kubeCfg.DockershimHostConfigExtensions,
+				UlimitCaps:              kubeCfg.DockershimUlimitCaps,
+				ImagePolicyEndpoint:     kubeCfg.DockershimImagePolicyEndpoint,
+				CredentialHelpers:       kubeCfg.DockershimCredentialHelpers,
+				NetworkPolicyHook:       kubeCfg.DockershimNetworkPolicyHook,
+				NamespacePolicyFile:     kubeCfg.DockershimNamespacePolicyFile,
+				ClusterDomain:           kubeCfg.ClusterDomain,
+				MinimumDockerAPIVersion: kubeCfg.DockershimMinimumDockerAPIVersion,
+				SandboxGCPolicy: &dockershim.SandboxGCPolicy{
+					MinAge:       kubeCfg.MinimumGCAge.Duration,
+					MaxSandboxes: int(kubeCfg.MaxContainerCount),
+				},
+				PullLimits: &dockershim.ImagePullLimits{
+					QPS:        float32(kubeCfg.RegistryPullQPS),
+					Burst:      int(kubeCfg.RegistryBurst),
+					Serialized: kubeCfg.SerializeImagePulls,
+				},
+			})
 			if err != nil {
 				return nil, err
 			}
@@ -563,6 +599,7 @@ func NewMainKubelet(kubeCfg *componentconfig.KubeletConfiguration, kubeDeps *Kub
 			// For now, the CRI shim redirects the streaming requests to the
 			// kubelet, which handles the requests using DockerService..
 			klet.criHandler = ds
+			plegEventSubscriber = ds
 
 			const (
 				// The unix socket for kubelet <-> dockershim communication.
@@ -681,7 +718,13 @@ func NewMainKubelet(kubeCfg *componentconfig.KubeletConfiguration, kubeDeps *Kub
 	// TODO: Factor out "StatsProvider" from Kubelet so we don't have a cyclic dependency
 	klet.resourceAnalyzer = stats.NewResourceAnalyzer(klet, kubeCfg.VolumeStatsAggPeriod.Duration, klet.containerRuntime)
 
-	klet.pleg = pleg.NewGenericPLEG(klet.containerRuntime, plegChannelCapacity, plegRelistPeriod, klet.podCache, clock.RealClock{})
+	if plegEventSubscriber != nil {
+		// The shim pushes container events, so the kubelet relists on events
+		// and falls back to a slow periodic relist to cover event gaps.
+		klet.pleg = pleg.NewEventedPLEG(klet.containerRuntime, plegEventSubscriber, plegChannelCapacity, plegRelistBackstopPeriod, klet.podCache, clock.RealClock{})
+	} else {
+		klet.pleg = pleg.NewGenericPLEG(klet.containerRuntime, plegChannelCapacity, plegRelistPeriod, klet.podCache, clock.RealClock{})
+	}
 	klet.runtimeState = newRuntimeState(maxWaitForContainerRuntime)
 	klet.updatePodCIDR(kubeCfg.PodCIDR)
 
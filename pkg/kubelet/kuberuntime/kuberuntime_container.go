@@ -112,8 +112,9 @@ func (m *kubeGenericRuntimeManager) startContainer(podSandboxID string, podSandb
 			legacySymlink, containerID, containerLog, err)
 	}
 
-	// Step 4: execute the post start hook.
-	if container.Lifecycle != nil && container.Lifecycle.PostStart != nil {
+	// Step 4: execute the post start hook, unless the runtime already ran it
+	// as part of StartContainer.
+	if container.Lifecycle != nil && container.Lifecycle.PostStart != nil && !runtimeExecutesLifecycleHook(container.Lifecycle.PostStart) {
 		kubeContainerID := kubecontainer.ContainerID{
 			Type: m.runtimeName,
 			ID:   containerID,
@@ -534,8 +535,9 @@ func (m *kubeGenericRuntimeManager) killContainer(pod *v1.Pod, containerID kubec
 
 	glog.V(2).Infof("Killing container %q with %d second grace period", containerID.String(), gracePeriod)
 
-	// Run the pre-stop lifecycle hooks if applicable.
-	if containerSpec.Lifecycle != nil && containerSpec.Lifecycle.PreStop != nil {
+	// Run the pre-stop lifecycle hooks if applicable, unless the runtime
+	// runs them within StopContainer's grace period itself.
+	if containerSpec.Lifecycle != nil && containerSpec.Lifecycle.PreStop != nil && !runtimeExecutesLifecycleHook(containerSpec.Lifecycle.PreStop) {
 		gracePeriod = gracePeriod - m.executePreStopHook(pod, containerID, containerSpec, gracePeriod)
 	}
 	// always give containers a minimal shutdown window to avoid unnecessary SIGKILLs
@@ -22,7 +22,9 @@ import (
 
 	"github.com/golang/glog"
 	kubetypes "k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/features"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/kubelet/util/format"
@@ -39,6 +41,11 @@ const (
 	containerTerminationMessagePolicyLabel = "io.kubernetes.container.terminationMessagePolicy"
 	containerPreStopHandlerLabel           = "io.kubernetes.container.preStopHandler"
 	containerPortsLabel                    = "io.kubernetes.container.ports"
+
+	// Exec hook commands ride along as annotations for runtimes that execute
+	// lifecycle hooks themselves (behind ExperimentalRuntimeLifecycleHooks).
+	containerPostStartExecAnnotation = "lifecycle.alpha.kubernetes.io/post-start-exec"
+	containerPreStopExecAnnotation   = "lifecycle.alpha.kubernetes.io/pre-stop-exec"
 )
 
 type labeledPodSandboxInfo struct {
@@ -129,6 +136,27 @@ func newContainerAnnotations(container *v1.Container, pod *v1.Pod, restartCount
 		}
 	}
 
+	// When the runtime executes lifecycle hooks, pass exec hook commands
+	// along so the shim can run them on the container transitions.
+	if container.Lifecycle != nil && utilfeature.DefaultFeatureGate.Enabled(features.ExperimentalRuntimeLifecycleHooks) {
+		if postStart := container.Lifecycle.PostStart; postStart != nil && postStart.Exec != nil {
+			rawCommand, err := json.Marshal(postStart.Exec.Command)
+			if err != nil {
+				glog.Errorf("Unable to marshal lifecycle PostStart exec command for container %q of pod %q: %v", container.Name, format.Pod(pod), err)
+			} else {
+				annotations[containerPostStartExecAnnotation] = string(rawCommand)
+			}
+		}
+		if preStop := container.Lifecycle.PreStop; preStop != nil && preStop.Exec != nil {
+			rawCommand, err := json.Marshal(preStop.Exec.Command)
+			if err != nil {
+				glog.Errorf("Unable to marshal lifecycle PreStop exec command for container %q of pod %q: %v", container.Name, format.Pod(pod), err)
+			} else {
+				annotations[containerPreStopExecAnnotation] = string(rawCommand)
+			}
+		}
+	}
+
 	if len(container.Ports) > 0 {
 		rawContainerPorts, err := json.Marshal(container.Ports)
 		if err != nil {
@@ -141,6 +169,13 @@ func newContainerAnnotations(container *v1.Container, pod *v1.Pod, restartCount
 	return annotations
 }
 
+// runtimeExecutesLifecycleHook returns whether the hook is executed by the
+// runtime shim rather than the kubelet. Only exec hooks travel to the
+// runtime; HTTP hooks always run in the kubelet.
+func runtimeExecutesLifecycleHook(handler *v1.Handler) bool {
+	return handler.Exec != nil && utilfeature.DefaultFeatureGate.Enabled(features.ExperimentalRuntimeLifecycleHooks)
+}
+
 // getPodSandboxInfoFromLabels gets labeledPodSandboxInfo from labels.
 func getPodSandboxInfoFromLabels(labels map[string]string) *labeledPodSandboxInfo {
 	podSandboxInfo := &labeledPodSandboxInfo{
@@ -22,6 +22,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/kubernetes/pkg/api/v1"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 )
@@ -148,6 +149,20 @@ func TestContainerAnnotations(t *testing.T) {
 		t.Errorf("expected %v, got %v", expected, containerInfo)
 	}
 
+	// The exec hook annotation is only produced when the runtime executes
+	// lifecycle hooks.
+	if value, found := annotations[containerPreStopExecAnnotation]; found {
+		t.Errorf("unexpected annotation %q=%q with ExperimentalRuntimeLifecycleHooks disabled", containerPreStopExecAnnotation, value)
+	}
+	if err := utilfeature.DefaultFeatureGate.Set("ExperimentalRuntimeLifecycleHooks=true"); err != nil {
+		t.Fatalf("unexpected error setting feature gate: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set("ExperimentalRuntimeLifecycleHooks=false")
+	annotations = newContainerAnnotations(container, pod, restartCount)
+	if value := annotations[containerPreStopExecAnnotation]; value != `["action1","action2"]` {
+		t.Errorf("expected preStop exec command annotation, got %q", value)
+	}
+
 	// Test when DeletionGracePeriodSeconds, TerminationGracePeriodSeconds and Lifecycle are nil,
 	// the information got from annotations should also be nil
 	container.Lifecycle = nil
@@ -19,13 +19,17 @@ package cni
 import (
 	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/containernetworking/cni/libcni"
 	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/fsnotify/fsnotify"
 	"github.com/golang/glog"
+	utilsets "k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/kubernetes/pkg/apis/componentconfig"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
@@ -60,6 +64,10 @@ type cniNetwork struct {
 	name          string
 	NetworkConfig *libcni.NetworkConfig
 	CNIConfig     libcni.CNI
+	// plugins holds the chained configuration loaded from a .conflist file;
+	// when non-empty, NetworkConfig is unset and the entries are invoked in
+	// sequence.
+	plugins []*libcni.NetworkConfig
 }
 
 func probeNetworkPluginsWithVendorCNIDirPrefix(pluginDir, binDir, vendorCNIDirPrefix string) []network.NetworkPlugin {
@@ -89,15 +97,30 @@ func getDefaultCNINetwork(pluginDir, binDir, vendorCNIDirPrefix string) (*cniNet
 		pluginDir = DefaultNetDir
 	}
 	files, err := libcni.ConfFiles(pluginDir)
-	switch {
-	case err != nil:
+	if err != nil {
 		return nil, err
-	case len(files) == 0:
+	}
+	// Chained-plugin configurations live in .conflist files, which libcni
+	// does not enumerate.
+	listFiles, err := confListFiles(pluginDir)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, listFiles...)
+	if len(files) == 0 {
 		return nil, fmt.Errorf("No networks found in %s", pluginDir)
 	}
 
 	sort.Strings(files)
 	for _, confFile := range files {
+		if filepath.Ext(confFile) == ".conflist" {
+			network, err := getCNINetworkFromConfList(confFile, binDir, vendorCNIDirPrefix)
+			if err != nil {
+				glog.Warningf("Error loading CNI config list file %s: %v", confFile, err)
+				continue
+			}
+			return network, nil
+		}
 		conf, err := libcni.ConfFromFile(confFile)
 		if err != nil {
 			glog.Warningf("Error loading CNI config file %s: %v", confFile, err)
@@ -150,6 +173,10 @@ func (plugin *cniNetworkPlugin) Init(host network.Host, hairpinMode componentcon
 
 	plugin.host = host
 
+	// Watch the conf and binary directories so configuration changes are
+	// picked up as soon as they land on disk.
+	plugin.watchNetworkConfig()
+
 	// sync network config from pluginDir periodically to detect network config updates
 	go wait.Forever(func() {
 		plugin.syncNetworkConfig()
@@ -157,6 +184,56 @@ func (plugin *cniNetworkPlugin) Init(host network.Host, hairpinMode componentcon
 	return nil
 }
 
+// watchNetworkConfig re-syncs the network configuration whenever the CNI
+// conf or binary directories change on disk. The switch is atomic via
+// setDefaultNetwork: operations already in flight keep the *cniNetwork they
+// started with, and only subsequent calls see the new configuration. The
+// periodic sync remains as a fallback for environments where inotify is
+// unavailable.
+func (plugin *cniNetworkPlugin) watchNetworkConfig() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		glog.Warningf("Unable to create CNI config watcher, relying on periodic sync: %v", err)
+		return
+	}
+	confDir := plugin.pluginDir
+	if confDir == "" {
+		confDir = DefaultNetDir
+	}
+	watching := false
+	for _, dir := range []string{confDir, plugin.binDir} {
+		if dir == "" {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			glog.Warningf("Unable to watch %q for CNI config changes: %v", dir, err)
+			continue
+		}
+		watching = true
+	}
+	if !watching {
+		watcher.Close()
+		return
+	}
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				glog.V(4).Infof("CNI config change detected: %v", event)
+				plugin.syncNetworkConfig()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				glog.Warningf("CNI config watcher error: %v", err)
+			}
+		}
+	}()
+}
+
 func (plugin *cniNetworkPlugin) syncNetworkConfig() {
 	network, err := getDefaultCNINetwork(plugin.pluginDir, plugin.binDir, plugin.vendorCNIDirPrefix)
 	if err != nil {
@@ -189,6 +266,24 @@ func (plugin *cniNetworkPlugin) Name() string {
 	return CNIPluginName
 }
 
+// Capabilities advertises live reconfiguration and attachment checking: the
+// CNI spec defines a repeated ADD as updating the existing attachment, so
+// SetUpPod can be re-invoked against a running sandbox after a configuration
+// change, and CheckPod can verify an attachment in place. When the
+// configured chain includes the bandwidth plugin, shaping is advertised as
+// well so the runtime does not apply tc rules on top.
+func (plugin *cniNetworkPlugin) Capabilities() utilsets.Int {
+	caps := utilsets.NewInt(network.NET_PLUGIN_CAPABILITY_LIVE_RECONFIGURE, network.NET_PLUGIN_CAPABILITY_CHECK)
+	if netw := plugin.getDefaultNetwork(); netw != nil {
+		for _, conf := range netw.plugins {
+			if conf.Network.Type == "bandwidth" {
+				caps.Insert(network.NET_PLUGIN_CAPABILITY_SHAPING)
+			}
+		}
+	}
+	return caps
+}
+
 func (plugin *cniNetworkPlugin) SetUpPod(namespace string, name string, id kubecontainer.ContainerID) error {
 	if err := plugin.checkInitialized(); err != nil {
 		return err
@@ -198,13 +293,13 @@ func (plugin *cniNetworkPlugin) SetUpPod(namespace string, name string, id kubec
 		return fmt.Errorf("CNI failed to retrieve network namespace path: %v", err)
 	}
 
-	_, err = plugin.loNetwork.addToNetwork(name, namespace, id, netnsPath)
+	_, err = plugin.loNetwork.addToNetwork(name, namespace, id, netnsPath, nil)
 	if err != nil {
 		glog.Errorf("Error while adding to cni lo network: %s", err)
 		return err
 	}
 
-	_, err = plugin.getDefaultNetwork().addToNetwork(name, namespace, id, netnsPath)
+	_, err = plugin.getDefaultNetwork().addToNetwork(name, namespace, id, netnsPath, plugin.podCNIArgs(namespace, name))
 	if err != nil {
 		glog.Errorf("Error while adding to cni network: %s", err)
 		return err
@@ -213,6 +308,26 @@ func (plugin *cniNetworkPlugin) SetUpPod(namespace string, name string, id kubec
 	return err
 }
 
+// podCNIArgs builds additional CNI_ARGS from the pod's annotations. A
+// requested static IP is forwarded as the IP arg, which IPAM plugins
+// supporting requested IPs (e.g. host-local) honor; other plugins ignore
+// unknown args.
+func (plugin *cniNetworkPlugin) podCNIArgs(namespace, name string) [][2]string {
+	pod, ok := plugin.host.GetPodByName(namespace, name)
+	if !ok || pod == nil {
+		return nil
+	}
+	requested := pod.Annotations[network.PodRequestedIPAnnotation]
+	if requested == "" {
+		return nil
+	}
+	if net.ParseIP(requested) == nil {
+		glog.Warningf("Ignoring invalid requested IP %q for pod %s/%s", requested, namespace, name)
+		return nil
+	}
+	return [][2]string{{"IP", requested}}
+}
+
 func (plugin *cniNetworkPlugin) TearDownPod(namespace string, name string, id kubecontainer.ContainerID) error {
 	if err := plugin.checkInitialized(); err != nil {
 		return err
@@ -222,7 +337,19 @@ func (plugin *cniNetworkPlugin) TearDownPod(namespace string, name string, id ku
 		return fmt.Errorf("CNI failed to retrieve network namespace path: %v", err)
 	}
 
-	return plugin.getDefaultNetwork().deleteFromNetwork(name, namespace, id, netnsPath)
+	return plugin.getDefaultNetwork().deleteFromNetwork(name, namespace, id, netnsPath, nil)
+}
+
+// CheckPod verifies the pod's existing network attachment. The vendored CNI
+// version predates the CHECK verb, so the check enters the pod's network
+// namespace and reads the default interface's address the way
+// GetPodNetworkStatus does: a sandbox whose default interface lost its
+// address has broken networking, whatever the cause.
+func (plugin *cniNetworkPlugin) CheckPod(namespace string, name string, id kubecontainer.ContainerID) error {
+	if _, err := plugin.GetPodNetworkStatus(namespace, name, id); err != nil {
+		return err
+	}
+	return nil
 }
 
 // TODO: Use the addToNetwork function to obtain the IP of the Pod. That will assume idempotent ADD call to the plugin.
@@ -241,13 +368,17 @@ func (plugin *cniNetworkPlugin) GetPodNetworkStatus(namespace string, name strin
 	return &network.PodNetworkStatus{IP: ip}, nil
 }
 
-func (network *cniNetwork) addToNetwork(podName string, podNamespace string, podInfraContainerID kubecontainer.ContainerID, podNetnsPath string) (*cnitypes.Result, error) {
-	rt, err := buildCNIRuntimeConf(podName, podNamespace, podInfraContainerID, podNetnsPath)
+func (network *cniNetwork) addToNetwork(podName string, podNamespace string, podInfraContainerID kubecontainer.ContainerID, podNetnsPath string, extraArgs [][2]string) (*cnitypes.Result, error) {
+	rt, err := buildCNIRuntimeConf(podName, podNamespace, podInfraContainerID, podNetnsPath, extraArgs)
 	if err != nil {
 		glog.Errorf("Error adding network: %v", err)
 		return nil, err
 	}
 
+	if len(network.plugins) > 0 {
+		return network.addChainToNetwork(rt)
+	}
+
 	netconf, cninet := network.NetworkConfig, network.CNIConfig
 	glog.V(4).Infof("About to run with conf.Network.Type=%v", netconf.Network.Type)
 	res, err := cninet.AddNetwork(netconf, rt)
@@ -259,13 +390,17 @@ func (network *cniNetwork) addToNetwork(podName string, podNamespace string, pod
 	return res, nil
 }
 
-func (network *cniNetwork) deleteFromNetwork(podName string, podNamespace string, podInfraContainerID kubecontainer.ContainerID, podNetnsPath string) error {
-	rt, err := buildCNIRuntimeConf(podName, podNamespace, podInfraContainerID, podNetnsPath)
+func (network *cniNetwork) deleteFromNetwork(podName string, podNamespace string, podInfraContainerID kubecontainer.ContainerID, podNetnsPath string, extraArgs [][2]string) error {
+	rt, err := buildCNIRuntimeConf(podName, podNamespace, podInfraContainerID, podNetnsPath, extraArgs)
 	if err != nil {
 		glog.Errorf("Error deleting network: %v", err)
 		return err
 	}
 
+	if len(network.plugins) > 0 {
+		return network.delChainFromNetwork(rt)
+	}
+
 	netconf, cninet := network.NetworkConfig, network.CNIConfig
 	glog.V(4).Infof("About to run with conf.Network.Type=%v", netconf.Network.Type)
 	err = cninet.DelNetwork(netconf, rt)
@@ -276,7 +411,7 @@ func (network *cniNetwork) deleteFromNetwork(podName string, podNamespace string
 	return nil
 }
 
-func buildCNIRuntimeConf(podName string, podNs string, podInfraContainerID kubecontainer.ContainerID, podNetnsPath string) (*libcni.RuntimeConf, error) {
+func buildCNIRuntimeConf(podName string, podNs string, podInfraContainerID kubecontainer.ContainerID, podNetnsPath string, extraArgs [][2]string) (*libcni.RuntimeConf, error) {
 	glog.V(4).Infof("Got netns path %v", podNetnsPath)
 	glog.V(4).Infof("Using netns path %v", podNs)
 
@@ -291,6 +426,7 @@ func buildCNIRuntimeConf(podName string, podNs string, podInfraContainerID kubec
 			{"K8S_POD_INFRA_CONTAINER_ID", podInfraContainerID.ID},
 		},
 	}
+	rt.Args = append(rt.Args, extraArgs...)
 
 	return rt, nil
 }
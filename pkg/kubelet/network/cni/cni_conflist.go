@@ -0,0 +1,151 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containernetworking/cni/libcni"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/golang/glog"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// cniConfList mirrors the on-disk format of a .conflist file: a named chain
+// of plugin configurations executed in sequence (e.g. a bridge plugin
+// followed by portmap and tuning plugins).
+type cniConfList struct {
+	Name       string            `json:"name"`
+	CNIVersion string            `json:"cniVersion"`
+	Plugins    []json.RawMessage `json:"plugins"`
+}
+
+// confListFiles returns the .conflist files in dir. libcni.ConfFiles only
+// enumerates single-plugin .conf files.
+func confListFiles(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	switch {
+	case err == nil: // break
+	case os.IsNotExist(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	confFiles := []string{}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if filepath.Ext(f.Name()) == ".conflist" {
+			confFiles = append(confFiles, filepath.Join(dir, f.Name()))
+		}
+	}
+	return confFiles, nil
+}
+
+// getCNINetworkFromConfList builds a chained cniNetwork from a .conflist
+// file. Each entry in the chain inherits the list's name and cniVersion,
+// matching the CNI conventions for chained execution.
+func getCNINetworkFromConfList(confFile, binDir, vendorCNIDirPrefix string) (*cniNetwork, error) {
+	bytes, err := ioutil.ReadFile(confFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", confFile, err)
+	}
+	list := &cniConfList{}
+	if err := json.Unmarshal(bytes, list); err != nil {
+		return nil, fmt.Errorf("error parsing configuration list: %v", err)
+	}
+	if list.Name == "" {
+		return nil, fmt.Errorf("configuration list %s has no name", confFile)
+	}
+	if len(list.Plugins) == 0 {
+		return nil, fmt.Errorf("configuration list %s has no plugins", confFile)
+	}
+
+	// Search the vendor dir of every plugin type in the chain, in addition
+	// to the default binary dir.
+	paths := []string{binDir}
+	plugins := []*libcni.NetworkConfig{}
+	for _, raw := range list.Plugins {
+		conf, err := libcni.ConfFromBytes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing configuration list entry: %v", err)
+		}
+		if conf, err = libcni.InjectConf(conf, "name", list.Name); err != nil {
+			return nil, err
+		}
+		if list.CNIVersion != "" {
+			if conf, err = libcni.InjectConf(conf, "cniVersion", list.CNIVersion); err != nil {
+				return nil, err
+			}
+		}
+		paths = append(paths, vendorCNIDir(vendorCNIDirPrefix, conf.Network.Type))
+		plugins = append(plugins, conf)
+	}
+	return &cniNetwork{
+		name:      list.Name,
+		plugins:   plugins,
+		CNIConfig: &libcni.CNIConfig{Path: paths},
+	}, nil
+}
+
+// addChainToNetwork invokes each plugin of a chained configuration in order,
+// passing the previous plugin's result along as prevResult. The result of
+// the last plugin is the result of the chain.
+func (network *cniNetwork) addChainToNetwork(rt *libcni.RuntimeConf) (*cnitypes.Result, error) {
+	var res *cnitypes.Result
+	for _, netconf := range network.plugins {
+		if res != nil {
+			var err error
+			if netconf, err = libcni.InjectConf(netconf, "prevResult", res); err != nil {
+				return nil, fmt.Errorf("error injecting previous result: %v", err)
+			}
+		}
+		glog.V(4).Infof("About to run chained plugin with conf.Network.Type=%v", netconf.Network.Type)
+		r, err := network.CNIConfig.AddNetwork(netconf, rt)
+		if err != nil {
+			glog.Errorf("Error adding %q to network: %v", netconf.Network.Type, err)
+			return nil, err
+		}
+		if r != nil {
+			res = r
+		}
+	}
+	return res, nil
+}
+
+// delChainFromNetwork invokes the plugins of a chained configuration in
+// reverse order, mirroring how they were added. Teardown continues past
+// individual failures so the remaining plugins still get a chance to clean
+// up.
+func (network *cniNetwork) delChainFromNetwork(rt *libcni.RuntimeConf) error {
+	errs := []error{}
+	for i := len(network.plugins) - 1; i >= 0; i-- {
+		netconf := network.plugins[i]
+		glog.V(4).Infof("About to run chained plugin with conf.Network.Type=%v", netconf.Network.Type)
+		if err := network.CNIConfig.DelNetwork(netconf, rt); err != nil {
+			glog.Errorf("Error removing %q from network: %v", netconf.Network.Type, err)
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,175 @@
+// +build linux
+
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"path"
+	"testing"
+
+	"github.com/containernetworking/cni/libcni"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/stretchr/testify/mock"
+	utiltesting "k8s.io/client-go/util/testing"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/network/cni/testing"
+)
+
+func TestGetCNINetworkFromConfList(t *testing.T) {
+	tmpDir := utiltesting.MkTmpdirOrDie("cni-conflist-test")
+	defer tearDownPlugin(tmpDir)
+
+	confFile := path.Join(tmpDir, "mynet.conflist")
+	confList := `{
+  "cniVersion": "0.3.0",
+  "name": "mynet",
+  "plugins": [
+    { "type": "bridge", "bridge": "cni0" },
+    { "type": "portmap" }
+  ]
+}`
+	if err := ioutil.WriteFile(confFile, []byte(confList), 0644); err != nil {
+		t.Fatalf("Failed to write conflist: %v", err)
+	}
+
+	network, err := getCNINetworkFromConfList(confFile, "/opt/cni/bin", "")
+	if err != nil {
+		t.Fatalf("Failed to load conflist: %v", err)
+	}
+	if network.name != "mynet" {
+		t.Errorf("Expected network name %q, got %q", "mynet", network.name)
+	}
+	if network.NetworkConfig != nil {
+		t.Errorf("Expected no single-plugin config for a chained network")
+	}
+	if len(network.plugins) != 2 {
+		t.Fatalf("Expected 2 chained plugins, got %d", len(network.plugins))
+	}
+	// Every entry inherits the list's name and cniVersion.
+	for _, conf := range network.plugins {
+		if conf.Network.Name != "mynet" {
+			t.Errorf("Expected chained plugin to inherit name %q, got %q", "mynet", conf.Network.Name)
+		}
+		config := map[string]interface{}{}
+		if err := json.Unmarshal(conf.Bytes, &config); err != nil {
+			t.Fatalf("Failed to parse chained plugin config: %v", err)
+		}
+		if config["cniVersion"] != "0.3.0" {
+			t.Errorf("Expected chained plugin to inherit cniVersion %q, got %v", "0.3.0", config["cniVersion"])
+		}
+	}
+	if network.plugins[0].Network.Type != "bridge" || network.plugins[1].Network.Type != "portmap" {
+		t.Errorf("Chained plugins out of order: %q, %q", network.plugins[0].Network.Type, network.plugins[1].Network.Type)
+	}
+
+	// Lists without a name or without plugins are rejected.
+	for _, invalid := range []string{
+		`{"cniVersion": "0.3.0", "plugins": [{"type": "bridge"}]}`,
+		`{"cniVersion": "0.3.0", "name": "mynet", "plugins": []}`,
+	} {
+		if err := ioutil.WriteFile(confFile, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to write conflist: %v", err)
+		}
+		if _, err := getCNINetworkFromConfList(confFile, "/opt/cni/bin", ""); err == nil {
+			t.Errorf("Expected error for conflist %s", invalid)
+		}
+	}
+}
+
+// TestChainedBandwidthPluginCapability verifies that shaping is advertised
+// exactly when the configured chain includes the bandwidth plugin.
+func TestChainedBandwidthPluginCapability(t *testing.T) {
+	plugin := &cniNetworkPlugin{}
+	if plugin.Capabilities().Has(network.NET_PLUGIN_CAPABILITY_SHAPING) {
+		t.Errorf("Expected no shaping capability without a bandwidth plugin")
+	}
+
+	conf, err := libcni.ConfFromBytes([]byte(`{"name": "mynet", "type": "bandwidth"}`))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	plugin.setDefaultNetwork(&cniNetwork{name: "mynet", plugins: []*libcni.NetworkConfig{conf}})
+	if !plugin.Capabilities().Has(network.NET_PLUGIN_CAPABILITY_SHAPING) {
+		t.Errorf("Expected shaping capability with a chained bandwidth plugin")
+	}
+}
+
+func TestConfListChainInvocation(t *testing.T) {
+	bridgeConf, err := libcni.ConfFromBytes([]byte(`{"cniVersion": "0.3.0", "name": "mynet", "type": "bridge"}`))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	portmapConf, err := libcni.ConfFromBytes([]byte(`{"cniVersion": "0.3.0", "name": "mynet", "type": "portmap"}`))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	mockCNI := &mock_cni.MockCNI{}
+	cniNet := &cniNetwork{
+		name:      "mynet",
+		plugins:   []*libcni.NetworkConfig{bridgeConf, portmapConf},
+		CNIConfig: mockCNI,
+	}
+	rt := &libcni.RuntimeConf{ContainerID: "test_infra_container"}
+
+	res := &cnitypes.Result{IP4: &cnitypes.IPConfig{IP: net.IPNet{IP: []byte{10, 1, 0, 23}}}}
+	addOrder := []string{}
+	prevResults := []bool{}
+	mockCNI.On("AddNetwork", mock.AnythingOfType("*libcni.NetworkConfig"), rt).Return(res, nil).Run(func(args mock.Arguments) {
+		conf := args.Get(0).(*libcni.NetworkConfig)
+		addOrder = append(addOrder, conf.Network.Type)
+		config := map[string]interface{}{}
+		if err := json.Unmarshal(conf.Bytes, &config); err != nil {
+			t.Fatalf("Failed to parse chained plugin config: %v", err)
+		}
+		_, ok := config["prevResult"]
+		prevResults = append(prevResults, ok)
+	})
+	delOrder := []string{}
+	mockCNI.On("DelNetwork", mock.AnythingOfType("*libcni.NetworkConfig"), rt).Return(nil).Run(func(args mock.Arguments) {
+		delOrder = append(delOrder, args.Get(0).(*libcni.NetworkConfig).Network.Type)
+	})
+
+	r, err := cniNet.addChainToNetwork(rt)
+	if err != nil {
+		t.Fatalf("Failed to add chain: %v", err)
+	}
+	if r != res {
+		t.Errorf("Expected the last plugin's result to be returned")
+	}
+	if len(addOrder) != 2 || addOrder[0] != "bridge" || addOrder[1] != "portmap" {
+		t.Errorf("Unexpected ADD order: %v", addOrder)
+	}
+	// The first plugin sees no prevResult; later plugins receive the result
+	// of their predecessor.
+	if len(prevResults) != 2 || prevResults[0] || !prevResults[1] {
+		t.Errorf("Unexpected prevResult passing: %v", prevResults)
+	}
+
+	if err := cniNet.delChainFromNetwork(rt); err != nil {
+		t.Fatalf("Failed to delete chain: %v", err)
+	}
+	if len(delOrder) != 2 || delOrder[0] != "portmap" || delOrder[1] != "bridge" {
+		t.Errorf("Expected reverse-order teardown, got: %v", delOrder)
+	}
+
+	mockCNI.AssertExpectations(t)
+}
@@ -26,11 +26,14 @@ import (
 	"net"
 	"os"
 	"path"
+	"reflect"
 	"testing"
 	"text/template"
+	"time"
 
 	cnitypes "github.com/containernetworking/cni/pkg/types"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/wait"
 	utiltesting "k8s.io/client-go/util/testing"
 	"k8s.io/kubernetes/pkg/api/v1"
 	"k8s.io/kubernetes/pkg/apis/componentconfig"
@@ -254,6 +257,87 @@ func TestCNIPlugin(t *testing.T) {
 	mockLoCNI.AssertExpectations(t)
 }
 
+// fakePodAnnotationsHost is a fakeNetworkHost that can resolve one pod, so
+// annotation-driven behavior can be exercised.
+type fakePodAnnotationsHost struct {
+	*fakeNetworkHost
+	pod *v1.Pod
+}
+
+func (h *fakePodAnnotationsHost) GetPodByName(namespace, name string) (*v1.Pod, bool) {
+	if h.pod != nil && h.pod.Namespace == namespace && h.pod.Name == name {
+		return h.pod, true
+	}
+	return nil, false
+}
+
+// TestPodCNIArgs verifies that a pod-requested static IP is forwarded as a
+// CNI arg, and that invalid or missing requests produce no extra args.
+func TestPodCNIArgs(t *testing.T) {
+	pod := &v1.Pod{}
+	pod.Name = "podName"
+	pod.Namespace = "podNamespace"
+	plugin := &cniNetworkPlugin{host: &fakePodAnnotationsHost{fakeNetworkHost: NewFakeHost(nil, nil), pod: pod}}
+
+	// Without the annotation there are no extra args.
+	if args := plugin.podCNIArgs("podNamespace", "podName"); args != nil {
+		t.Errorf("Expected no extra args, got %v", args)
+	}
+
+	pod.Annotations = map[string]string{network.PodRequestedIPAnnotation: "10.1.2.3"}
+	args := plugin.podCNIArgs("podNamespace", "podName")
+	if !reflect.DeepEqual(args, [][2]string{{"IP", "10.1.2.3"}}) {
+		t.Errorf("Unexpected extra args: %v", args)
+	}
+
+	// Invalid requests are dropped instead of being forwarded.
+	pod.Annotations[network.PodRequestedIPAnnotation] = "not-an-ip"
+	if args := plugin.podCNIArgs("podNamespace", "podName"); args != nil {
+		t.Errorf("Expected no extra args for an invalid IP, got %v", args)
+	}
+
+	// Unknown pods produce no args.
+	if args := plugin.podCNIArgs("other", "pod"); args != nil {
+		t.Errorf("Expected no extra args for an unknown pod, got %v", args)
+	}
+}
+
+// TestNetworkConfigHotReload verifies that a change in the CNI conf
+// directory is picked up without waiting for the periodic sync.
+func TestNetworkConfigHotReload(t *testing.T) {
+	tmpDir := utiltesting.MkTmpdirOrDie("cni-hot-reload-test")
+	defer tearDownPlugin(tmpDir)
+
+	confA := path.Join(tmpDir, "10-mynet.conf")
+	if err := ioutil.WriteFile(confA, []byte(`{"name": "mynet", "type": "bridge"}`), 0644); err != nil {
+		t.Fatalf("Failed to write network config: %v", err)
+	}
+
+	plugins := probeNetworkPluginsWithVendorCNIDirPrefix(tmpDir, "", "")
+	cniPlugin, ok := plugins[0].(*cniNetworkPlugin)
+	if !ok {
+		t.Fatalf("Not a CNI network plugin!")
+	}
+	if net := cniPlugin.getDefaultNetwork(); net == nil || net.name != "mynet" {
+		t.Fatalf("Expected mynet as the default network, got %v", net)
+	}
+
+	cniPlugin.watchNetworkConfig()
+
+	// An earlier-sorting config file takes precedence once it lands on disk.
+	confB := path.Join(tmpDir, "00-newnet.conf")
+	if err := ioutil.WriteFile(confB, []byte(`{"name": "newnet", "type": "bridge"}`), 0644); err != nil {
+		t.Fatalf("Failed to write network config: %v", err)
+	}
+	err := wait.Poll(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		net := cniPlugin.getDefaultNetwork()
+		return net != nil && net.name == "newnet", nil
+	})
+	if err != nil {
+		t.Errorf("Network config was not reloaded after the conf dir changed: %v", err)
+	}
+}
+
 func TestLoNetNonNil(t *testing.T) {
 	if conf := getLoNetwork("", ""); conf == nil {
 		t.Error("Expected non-nil lo network")
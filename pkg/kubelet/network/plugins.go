@@ -46,8 +46,21 @@ const NET_PLUGIN_EVENT_POD_CIDR_CHANGE_DETAIL_CIDR = "pod-cidr"
 const (
 	// Indicates the plugin handles Kubernetes bandwidth shaping annotations internally
 	NET_PLUGIN_CAPABILITY_SHAPING int = 1
+
+	// Indicates the plugin can re-apply its configuration to a live pod by
+	// invoking SetUpPod again, without the sandbox being recreated
+	NET_PLUGIN_CAPABILITY_LIVE_RECONFIGURE int = 2
+
+	// Indicates the plugin can verify an existing pod network attachment
+	// (the CNI CHECK verb), so the runtime may probe pods periodically
+	NET_PLUGIN_CAPABILITY_CHECK int = 3
 )
 
+// PodRequestedIPAnnotation lets a pod request a specific static IP address.
+// The value is handed through to plugins that support requested IPs (e.g.
+// CNI IPAM plugins honoring the IP arg); other plugins ignore it.
+const PodRequestedIPAnnotation = "network.alpha.kubernetes.io/requested-ip"
+
 // Plugin is an interface to network plugins for the kubelet
 type NetworkPlugin interface {
 	// Init initializes the plugin.  This will be called exactly once
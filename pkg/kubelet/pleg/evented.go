@@ -0,0 +1,117 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pleg
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/clock"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+// ContainerEventSubscriber is implemented by runtime shims that can push
+// container lifecycle events. The returned channel is closed when the
+// subscription ends; the returned function cancels it.
+type ContainerEventSubscriber interface {
+	SubscribeContainerEvents() (<-chan *runtimeapi.ContainerEvent, func())
+}
+
+const (
+	// eventedResubscribePeriod is the delay before re-subscribing to the
+	// container event stream after the subscription channel closes.
+	eventedResubscribePeriod = 5 * time.Second
+)
+
+// EventedPLEG generates pod lifecycle events by relisting, like GenericPLEG,
+// but relists when the runtime shim reports a container event instead of on a
+// short fixed period. A slow periodic relist remains as a backstop: the shim
+// drops events for slow subscribers and loses them entirely while its own
+// connection to the runtime is down, so event gaps must be assumed. Because
+// the backstop only fires when no event-driven relist has happened recently,
+// a quiet dense node pays for a relist once per backstop period rather than
+// once per second.
+type EventedPLEG struct {
+	*GenericPLEG
+	// The source of container events.
+	subscriber ContainerEventSubscriber
+	// Serializes relists from the event watcher and the backstop.
+	relistLock sync.Mutex
+}
+
+// NewEventedPLEG instantiates a new EventedPLEG. The relistPeriod is the
+// backstop period; it should be well below the Healthy threshold but can be
+// far longer than GenericPLEG's, since events cover the common case.
+func NewEventedPLEG(runtime kubecontainer.Runtime, subscriber ContainerEventSubscriber, channelCapacity int,
+	relistPeriod time.Duration, cache kubecontainer.Cache, clock clock.Clock) PodLifecycleEventGenerator {
+	return &EventedPLEG{
+		GenericPLEG: &GenericPLEG{
+			relistPeriod: relistPeriod,
+			runtime:      runtime,
+			eventChannel: make(chan *PodLifecycleEvent, channelCapacity),
+			podRecords:   make(podRecords),
+			cache:        cache,
+			clock:        clock,
+		},
+		subscriber: subscriber,
+	}
+}
+
+// Start spawns the event watcher and the backstop relist goroutine.
+func (e *EventedPLEG) Start() {
+	go wait.Until(e.watchEvents, eventedResubscribePeriod, wait.NeverStop)
+	go wait.Until(e.relistIfStale, e.relistPeriod, wait.NeverStop)
+}
+
+// watchEvents consumes one subscription until its channel closes, relisting
+// whenever events arrive. Buffered events are coalesced into a single relist
+// since relisting observes the effects of all of them at once.
+func (e *EventedPLEG) watchEvents() {
+	ch, cancel := e.subscriber.SubscribeContainerEvents()
+	defer cancel()
+	for ev := range ch {
+		glog.V(5).Infof("EventedPLEG: Relisting on %s event for %q", ev.EventType, ev.ContainerId)
+		for drained := false; !drained; {
+			select {
+			case _, ok := <-ch:
+				drained = !ok
+			default:
+				drained = true
+			}
+		}
+		e.lockedRelist()
+	}
+	glog.V(4).Infof("EventedPLEG: Event subscription ended, re-subscribing")
+}
+
+// relistIfStale relists only if no relist has happened within the backstop
+// period, i.e. the event stream has been quiet or broken.
+func (e *EventedPLEG) relistIfStale() {
+	if e.clock.Since(e.getRelistTime()) < e.relistPeriod {
+		return
+	}
+	e.lockedRelist()
+}
+
+func (e *EventedPLEG) lockedRelist() {
+	e.relistLock.Lock()
+	defer e.relistLock.Unlock()
+	e.relist()
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pleg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/clock"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/api/v1alpha1/runtime"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	containertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
+)
+
+type fakeEventSubscriber struct {
+	ch chan *runtimeapi.ContainerEvent
+}
+
+func (f *fakeEventSubscriber) SubscribeContainerEvents() (<-chan *runtimeapi.ContainerEvent, func()) {
+	return f.ch, func() {}
+}
+
+func newTestEventedPLEG() (*EventedPLEG, *containertest.FakeRuntime, *fakeEventSubscriber, *clock.FakeClock) {
+	fakeRuntime := &containertest.FakeRuntime{}
+	fakeSubscriber := &fakeEventSubscriber{ch: make(chan *runtimeapi.ContainerEvent, 10)}
+	fakeClock := clock.NewFakeClock(time.Now())
+	pleg := &EventedPLEG{
+		GenericPLEG: &GenericPLEG{
+			relistPeriod: time.Minute,
+			runtime:      fakeRuntime,
+			eventChannel: make(chan *PodLifecycleEvent, 100),
+			podRecords:   make(podRecords),
+			clock:        fakeClock,
+		},
+		subscriber: fakeSubscriber,
+	}
+	return pleg, fakeRuntime, fakeSubscriber, fakeClock
+}
+
+// TestEventedRelist tests that container events trigger a relist and that the
+// relist produces the usual pod lifecycle events.
+func TestEventedRelist(t *testing.T) {
+	pleg, runtime, subscriber, _ := newTestEventedPLEG()
+	runtime.AllPodList = []*containertest.FakePod{
+		{Pod: &kubecontainer.Pod{
+			ID: "1234",
+			Containers: []*kubecontainer.Container{
+				createTestContainer("c1", kubecontainer.ContainerStateRunning),
+			},
+		}},
+	}
+
+	// Queue an event and close the subscription so that watchEvents returns
+	// once it has processed the event.
+	subscriber.ch <- &runtimeapi.ContainerEvent{ContainerId: "c1", EventType: "died"}
+	close(subscriber.ch)
+	pleg.watchEvents()
+
+	expected := []*PodLifecycleEvent{
+		{ID: "1234", Type: ContainerStarted, Data: "c1"},
+	}
+	verifyEvents(t, expected, getEventsFromChannel(pleg.Watch()))
+}
+
+// TestEventedBackstop tests that the backstop relists only when no relist has
+// happened within the backstop period.
+func TestEventedBackstop(t *testing.T) {
+	pleg, _, _, fakeClock := newTestEventedPLEG()
+
+	// No relist has ever happened, so the backstop fires immediately.
+	pleg.relistIfStale()
+	relistTime := pleg.getRelistTime()
+	assert.False(t, relistTime.IsZero())
+
+	// Within the backstop period the last relist is fresh enough.
+	fakeClock.Step(pleg.relistPeriod / 2)
+	pleg.relistIfStale()
+	assert.Equal(t, relistTime, pleg.getRelistTime())
+
+	// Once the last relist is older than the backstop period, relist again.
+	fakeClock.Step(pleg.relistPeriod)
+	pleg.relistIfStale()
+	assert.NotEqual(t, relistTime, pleg.getRelistTime())
+}
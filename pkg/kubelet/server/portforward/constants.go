@@ -21,3 +21,16 @@ package portforward
 const ProtocolV1Name = "portforward.k8s.io"
 
 var SupportedProtocols = []string{ProtocolV1Name}
+
+// PortProtocolHeader is the name of the optional stream header used to
+// request a transport protocol other than TCP for the forwarded port.
+const PortProtocolHeader = "protocol"
+
+const (
+	// PortProtocolTCP is the default transport protocol for forwarded ports.
+	PortProtocolTCP = "tcp"
+	// PortProtocolUDP requests datagram forwarding. Datagrams are framed on
+	// the data stream with a two byte big-endian length prefix in both
+	// directions.
+	PortProtocolUDP = "udp"
+)
@@ -95,6 +95,13 @@ func httpStreamReceived(streams chan httpstream.Stream) func(httpstream.Stream,
 			return fmt.Errorf("invalid stream type %q", streamType)
 		}
 
+		// make sure the protocol header, when given, is supported
+		switch protocol := stream.Headers().Get(PortProtocolHeader); protocol {
+		case "", PortProtocolTCP, PortProtocolUDP:
+		default:
+			return fmt.Errorf("invalid protocol %q", protocol)
+		}
+
 		streams <- stream
 		return nil
 	}
@@ -241,13 +248,26 @@ func (h *httpStreamHandler) portForward(p *httpStreamPair) {
 
 	portString := p.dataStream.Headers().Get(api.PortHeader)
 	port, _ := strconv.ParseInt(portString, 10, 32)
+	protocol := p.dataStream.Headers().Get(PortProtocolHeader)
+	if protocol == "" {
+		protocol = PortProtocolTCP
+	}
 
-	glog.V(5).Infof("(conn=%p, request=%s) invoking forwarder.PortForward for port %s", h.conn, p.requestID, portString)
-	err := h.forwarder.PortForward(h.pod, h.uid, int32(port), p.dataStream)
-	glog.V(5).Infof("(conn=%p, request=%s) done invoking forwarder.PortForward for port %s", h.conn, p.requestID, portString)
+	glog.V(5).Infof("(conn=%p, request=%s) invoking forwarder.PortForward for %s port %s", h.conn, p.requestID, protocol, portString)
+	var err error
+	if protocol == PortProtocolUDP {
+		if udpForwarder, ok := h.forwarder.(UDPPortForwarder); ok {
+			err = udpForwarder.PortForwardUDP(h.pod, h.uid, int32(port), p.dataStream)
+		} else {
+			err = errors.New("UDP forwarding is not supported")
+		}
+	} else {
+		err = h.forwarder.PortForward(h.pod, h.uid, int32(port), p.dataStream)
+	}
+	glog.V(5).Infof("(conn=%p, request=%s) done invoking forwarder.PortForward for %s port %s", h.conn, p.requestID, protocol, portString)
 
 	if err != nil {
-		msg := fmt.Errorf("error forwarding port %d to pod %s, uid %v: %v", port, h.pod, h.uid, err)
+		msg := fmt.Errorf("error forwarding %s port %d to pod %s, uid %v: %v", protocol, port, h.pod, h.uid, err)
 		utilruntime.HandleError(msg)
 		fmt.Fprint(p.errorStream, msg.Error())
 	}
@@ -29,6 +29,7 @@ func TestHTTPStreamReceived(t *testing.T) {
 	tests := map[string]struct {
 		port          string
 		streamType    string
+		protocol      string
 		expectedError string
 	}{
 		"missing port": {
@@ -59,6 +60,17 @@ func TestHTTPStreamReceived(t *testing.T) {
 			streamType:    "foo",
 			expectedError: `invalid stream type "foo"`,
 		},
+		"valid udp protocol": {
+			port:       "53",
+			streamType: "data",
+			protocol:   "udp",
+		},
+		"invalid protocol": {
+			port:          "80",
+			streamType:    "data",
+			protocol:      "icmp",
+			expectedError: `invalid protocol "icmp"`,
+		},
 	}
 	for name, test := range tests {
 		streams := make(chan httpstream.Stream, 1)
@@ -70,6 +82,9 @@ func TestHTTPStreamReceived(t *testing.T) {
 		if len(test.streamType) > 0 {
 			stream.headers.Set("streamType", test.streamType)
 		}
+		if len(test.protocol) > 0 {
+			stream.headers.Set("protocol", test.protocol)
+		}
 		replySent := make(chan struct{})
 		err := f(stream, replySent)
 		close(replySent)
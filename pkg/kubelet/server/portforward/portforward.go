@@ -33,6 +33,15 @@ type PortForwarder interface {
 	PortForward(name string, uid types.UID, port int32, stream io.ReadWriteCloser) error
 }
 
+// UDPPortForwarder is implemented by port forwarders that can also relay UDP
+// datagrams. Datagrams are carried on the data stream with a two byte
+// big-endian length prefix in both directions.
+type UDPPortForwarder interface {
+	// PortForwardUDP relays framed datagrams between the data stream and a
+	// UDP port in a pod.
+	PortForwardUDP(name string, uid types.UID, port int32, stream io.ReadWriteCloser) error
+}
+
 // ServePortForward handles a port forwarding request.  A single request is
 // kept alive as long as the client is still alive and the connection has not
 // been timed out due to idleness. This function handles multiple forwarded
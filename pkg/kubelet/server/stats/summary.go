@@ -151,6 +151,7 @@ func (sb *summaryBuilder) build() (*stats.Summary, error) {
 				Inodes:         sb.imageFsInfo.Inodes,
 				InodesUsed:     imageFsInodesUsed,
 			},
+			SandboxOverhead: sb.sandboxOverheadStats(),
 		},
 	}
 
@@ -176,6 +177,38 @@ func (sb *summaryBuilder) build() (*stats.Summary, error) {
 	return &summary, nil
 }
 
+// sandboxOverheadStats aggregates the resource usage of all pod
+// infrastructure (sandbox) containers on the node. Their stats are
+// otherwise hidden from the summary (only their network stats surface on
+// the pod), so the aggregate is the only way to quantify how much of the
+// node's capacity the infra containers themselves consume.
+func (sb *summaryBuilder) sandboxOverheadStats() *stats.SandboxOverheadStats {
+	overhead := &stats.SandboxOverheadStats{}
+	var cpu, memory uint64
+	for key, cinfo := range sb.infos {
+		if strings.HasSuffix(key, ".mount") || !sb.isPodManagedContainer(&cinfo) {
+			continue
+		}
+		if types.GetContainerName(cinfo.Spec.Labels) != leaky.PodInfraContainerName {
+			continue
+		}
+		overhead.SandboxCount++
+		cstat, found := sb.latestContainerStats(&cinfo)
+		if !found {
+			continue
+		}
+		if cinfo.Spec.HasCpu && cstat.Cpu != nil {
+			cpu += cstat.Cpu.Usage.Total
+		}
+		if cinfo.Spec.HasMemory && cstat.Memory != nil {
+			memory += cstat.Memory.WorkingSet
+		}
+	}
+	overhead.UsageCoreNanoSeconds = &cpu
+	overhead.WorkingSetBytes = &memory
+	return overhead
+}
+
 // containerInfoV2FsStats populates the container fs stats
 func (sb *summaryBuilder) containerInfoV2FsStats(
 	info *cadvisorapiv2.ContainerInfo,
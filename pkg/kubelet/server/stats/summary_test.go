@@ -187,6 +187,18 @@ func TestBuildSummary(t *testing.T) {
 		assert.Nil(t, sys.Rootfs, name+".Rootfs")
 	}
 
+	// Validate the aggregate infra (sandbox) container overhead.
+	overhead := nodeStats.Runtime.SandboxOverhead
+	assert.EqualValues(t, 3, overhead.SandboxCount)
+	expectedOverheadCPU := uint64(seedPod0Infra+offsetCPUUsageCoreSeconds) +
+		uint64(seedPod1Infra+offsetCPUUsageCoreSeconds) +
+		uint64(seedPod2Infra+offsetCPUUsageCoreSeconds)
+	assert.EqualValues(t, expectedOverheadCPU, *overhead.UsageCoreNanoSeconds)
+	expectedOverheadMemory := uint64(seedPod0Infra+offsetMemWorkingSetBytes) +
+		uint64(seedPod1Infra+offsetMemWorkingSetBytes) +
+		uint64(seedPod2Infra+offsetMemWorkingSetBytes)
+	assert.EqualValues(t, expectedOverheadMemory, *overhead.WorkingSetBytes)
+
 	assert.Equal(t, 3, len(summary.Pods))
 	indexPods := make(map[kubestats.PodReference]kubestats.PodStats, len(summary.Pods))
 	for _, pod := range summary.Pods {
@@ -62,6 +62,9 @@ type Runtime interface {
 	Exec(containerID string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool, resize <-chan term.Size) error
 	Attach(containerID string, in io.Reader, out, err io.WriteCloser, tty bool, resize <-chan term.Size) error
 	PortForward(podSandboxID string, port int32, stream io.ReadWriteCloser) error
+	// PortForwardUDP relays UDP datagrams, framed on the stream with a two
+	// byte big-endian length prefix in both directions.
+	PortForwardUDP(podSandboxID string, port int32, stream io.ReadWriteCloser) error
 }
 
 // Config defines the options used for running the stream server.
@@ -118,6 +121,8 @@ func NewServer(config Config, runtime Runtime) (Server, error) {
 		}
 	}
 
+	// Each endpoint accepts both SPDY and WebSocket upgrades; WebSocket
+	// clients multiplex the individual streams over numbered channels.
 	ws := &restful.WebService{}
 	endpoints := []struct {
 		path    string
@@ -329,6 +334,7 @@ type criAdapter struct {
 var _ remotecommand.Executor = &criAdapter{}
 var _ remotecommand.Attacher = &criAdapter{}
 var _ portforward.PortForwarder = &criAdapter{}
+var _ portforward.UDPPortForwarder = &criAdapter{}
 
 func (a *criAdapter) ExecInContainer(podName string, podUID types.UID, container string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool, resize <-chan term.Size, timeout time.Duration) error {
 	return a.Exec(container, cmd, in, out, err, tty, resize)
@@ -341,3 +347,7 @@ func (a *criAdapter) AttachContainer(podName string, podUID types.UID, container
 func (a *criAdapter) PortForward(podName string, podUID types.UID, port int32, stream io.ReadWriteCloser) error {
 	return a.Runtime.PortForward(podName, port, stream)
 }
+
+func (a *criAdapter) PortForwardUDP(podName string, podUID types.UID, port int32, stream io.ReadWriteCloser) error {
+	return a.Runtime.PortForwardUDP(podName, port, stream)
+}
@@ -29,7 +29,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 
+	"k8s.io/apiserver/pkg/util/wsstream"
 	"k8s.io/client-go/pkg/api"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
@@ -227,6 +229,14 @@ func TestServeAttach(t *testing.T) {
 	runRemoteCommandTest(t, "attach")
 }
 
+func TestServeWSExec(t *testing.T) {
+	runWebSocketCommandTest(t, "exec")
+}
+
+func TestServeWSAttach(t *testing.T) {
+	runWebSocketCommandTest(t, "attach")
+}
+
 func TestServePortForward(t *testing.T) {
 	s, testServer := startTestServer(t)
 	defer testServer.Close()
@@ -260,6 +270,42 @@ func TestServePortForward(t *testing.T) {
 	doClientStreams(t, "portforward", stream, stream, nil)
 }
 
+// TestServePortForwardUDP checks that data streams carrying the udp protocol
+// header are dispatched to the runtime's UDP forwarder.
+func TestServePortForwardUDP(t *testing.T) {
+	s, testServer := startTestServer(t)
+	defer testServer.Close()
+
+	resp, err := s.GetPortForward(&runtimeapi.PortForwardRequest{
+		PodSandboxId: testPodSandboxID,
+	})
+	require.NoError(t, err)
+	reqURL, err := url.Parse(resp.Url)
+	require.NoError(t, err)
+
+	exec, err := remotecommand.NewExecutor(&restclient.Config{}, "POST", reqURL)
+	require.NoError(t, err)
+	streamConn, _, err := exec.Dial(kubeletportforward.ProtocolV1Name)
+	require.NoError(t, err)
+	defer streamConn.Close()
+
+	// Create the streams.
+	headers := http.Header{}
+	headers.Set(kubeletportforward.PortProtocolHeader, kubeletportforward.PortProtocolUDP)
+	// Error stream is required, but unused in this test.
+	headers.Set(api.StreamType, api.StreamTypeError)
+	headers.Set(api.PortHeader, strconv.Itoa(testPort))
+	_, err = streamConn.CreateStream(headers)
+	require.NoError(t, err)
+	// Setup the data stream.
+	headers.Set(api.StreamType, api.StreamTypeData)
+	headers.Set(api.PortHeader, strconv.Itoa(testPort))
+	stream, err := streamConn.CreateStream(headers)
+	require.NoError(t, err)
+
+	doClientStreams(t, "portforward-udp", stream, stream, nil)
+}
+
 // Run the remote command test.
 // commandType is either "exec" or "attach".
 func runRemoteCommandTest(t *testing.T, commandType string) {
@@ -325,6 +371,82 @@ func runRemoteCommandTest(t *testing.T, commandType string) {
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
 
+// The websocket channel numbers used to multiplex the individual streams.
+// They must match the channel layout served by the remotecommand package.
+const (
+	wsStdinChannel = iota
+	wsStdoutChannel
+	wsStderrChannel
+)
+
+// Run the remote command test over a websocket connection with the binary
+// subprotocol. commandType is either "exec" or "attach".
+func runWebSocketCommandTest(t *testing.T, commandType string) {
+	s, testServer := startTestServer(t)
+	defer testServer.Close()
+
+	var reqURL string
+	containerID := testContainerID
+	switch commandType {
+	case "exec":
+		resp, err := s.GetExec(&runtimeapi.ExecRequest{
+			ContainerId: containerID,
+			Cmd:         []string{"echo"},
+			Stdin:       true,
+		})
+		require.NoError(t, err)
+		reqURL = resp.Url
+	case "attach":
+		resp, err := s.GetAttach(&runtimeapi.AttachRequest{
+			ContainerId: containerID,
+			Stdin:       true,
+		})
+		require.NoError(t, err)
+		reqURL = resp.Url
+	}
+
+	wsURL := strings.Replace(reqURL, "http://", "ws://", 1)
+	ws, err := websocket.Dial(wsURL, wsstream.ChannelWebSocketProtocol, "http://127.0.0.1/")
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// The server writes stderr before reading stdin, and stdout afterwards
+	// (see doServerStreams).
+	wsReadExpected(t, ws, wsStderrChannel, commandType+testErr)
+	require.NoError(t, wsWrite(ws, wsStdinChannel, []byte(commandType+testInput)))
+	wsReadExpected(t, ws, wsStdoutChannel, commandType+testOutput)
+
+	// Repeat request with the same URL should be a 404.
+	resp, err := http.Get(reqURL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// wsWrite sends data over the given websocket channel as a single binary frame.
+func wsWrite(conn *websocket.Conn, channel byte, data []byte) error {
+	frame := make([]byte, len(data)+1)
+	frame[0] = channel
+	copy(frame[1:], data)
+	return websocket.Message.Send(conn, frame)
+}
+
+// wsReadExpected reads frames until the expected string has been received on
+// the given channel. Empty frames (e.g. the connection-established message)
+// are skipped.
+func wsReadExpected(t *testing.T, conn *websocket.Conn, channel byte, expected string) {
+	received := []byte{}
+	for len(received) < len(expected) {
+		var frame []byte
+		require.NoError(t, websocket.Message.Receive(conn, &frame), "channel %d", channel)
+		if len(frame) <= 1 {
+			continue
+		}
+		require.Equal(t, channel, frame[0], "channel %d", channel)
+		received = append(received, frame[1:]...)
+	}
+	assert.Equal(t, expected, string(received), "channel %d", channel)
+}
+
 func startTestServer(t *testing.T) (Server, *httptest.Server) {
 	var s Server
 	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -386,6 +508,13 @@ func (f *fakeRuntime) PortForward(podSandboxID string, port int32, stream io.Rea
 	return nil
 }
 
+func (f *fakeRuntime) PortForwardUDP(podSandboxID string, port int32, stream io.ReadWriteCloser) error {
+	assert.Equal(f.t, testPodSandboxID, podSandboxID)
+	assert.EqualValues(f.t, testPort, port)
+	doServerStreams(f.t, "portforward-udp", stream, stream, nil)
+	return nil
+}
+
 // Send & receive expected input/output. Must be the inverse of doClientStreams.
 // Function will block until the expected i/o is finished.
 func doServerStreams(t *testing.T, prefix string, stdin io.Reader, stdout, stderr io.Writer) {